@@ -42,12 +42,13 @@ func (s *Gin) SetupApi() error {
 	apiRoutes := s.Gin.Group("/api/v1")
 
 	// Middlewares
-	authMiddleware := middleware.NewAuthMiddleware(s.factory.Service.UserManager.Auth)
+	authMiddleware := middleware.NewAuthMiddleware(s.factory.Service.UserManager.Auth, s.factory.Service.UserManager.OAuth, s.factory.Service.Denylist, s.factory.Service.UserManager.Role, s.factory.Service.UserManager.Organization)
 
 	//Static files
 	s.Gin.StaticFS("/web", http.Dir("static"))
 
 	//Routes
 	routes.NewRoutes(apiRoutes, s.factory, authMiddleware).ConfigRoutes()
+	routes.NewOAuthRoutes(&s.Gin.RouterGroup, s.factory).ConfigRoutes()
 	return nil
 }
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	oauth_usecases "auth-api/src/internal/modules/user-manager/usecases/oauth"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClientHandler struct {
+	useCases *oauth_usecases.UseCases
+}
+
+func NewClientHandler(useCases *oauth_usecases.UseCases) *ClientHandler {
+	return &ClientHandler{
+		useCases: useCases,
+	}
+}
+
+type createClientInput struct {
+	Name           string   `json:"name"`
+	RedirectURIs   []string `json:"redirectUris"`
+	Scopes         []string `json:"scopes"`
+	AccessTokenTTL int      `json:"accessTokenTtl"`
+	Confidential   bool     `json:"confidential"`
+}
+
+func (h *ClientHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, createClientInput{}, func(ctx context.Context, input createClientInput) (*oauth.CreateClientOutput, error) {
+			return h.useCases.CreateClient.Execute(ctx, oauth_usecases.CreateClientInput{
+				CreateClientInput: oauth.CreateClientInput{
+					Name:           input.Name,
+					RedirectURIs:   input.RedirectURIs,
+					Scopes:         input.Scopes,
+					AccessTokenTTL: input.AccessTokenTTL,
+				},
+				Confidential: input.Confidential,
+			})
+		})
+	}
+}
+
+func (h *ClientHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clients, err := h.useCases.ListClients.Execute(c.Request.Context())
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, clients)
+	}
+}
+
+func (h *ClientHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client, err := h.useCases.GetClient.Execute(c.Request.Context(), oauth_usecases.GetClientInput{
+			GetClientInput: oauth.GetClientInput{
+				ClientID: c.Param("id"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, client)
+	}
+}
+
+type updateClientInput struct {
+	Name           *string   `json:"name"`
+	RedirectURIs   *[]string `json:"redirectUris"`
+	Scopes         *[]string `json:"scopes"`
+	AccessTokenTTL *int      `json:"accessTokenTtl"`
+}
+
+func (h *ClientHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, updateClientInput{}, func(ctx context.Context, input updateClientInput) (*oauth.Client, error) {
+			return h.useCases.UpdateClient.Execute(ctx, oauth_usecases.UpdateClientInput{
+				UpdateClientInput: oauth.UpdateClientInput{
+					ClientID:       c.Param("id"),
+					Name:           input.Name,
+					RedirectURIs:   input.RedirectURIs,
+					Scopes:         input.Scopes,
+					AccessTokenTTL: input.AccessTokenTTL,
+				},
+			})
+		})
+	}
+}
+
+func (h *ClientHandler) RotateSecret() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		out, err := h.useCases.RotateClientSecret.Execute(c.Request.Context(), oauth_usecases.RotateClientSecretInput{
+			ClientID: c.Param("id"),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, out)
+	}
+}
+
+func (h *ClientHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.DeleteClient.Execute(c.Request.Context(), oauth_usecases.DeleteClientInput{
+			DeleteClientInput: oauth.DeleteClientInput{
+				ClientID: c.Param("id"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	useCases *export_usecases.UseCases
+}
+
+type requestExportInput struct {
+	Format export.Format `json:"format"`
+}
+
+func NewExportHandler(useCases *export_usecases.UseCases) *ExportHandler {
+	return &ExportHandler{
+		useCases: useCases,
+	}
+}
+
+// Request starts the export of the signed in user, the ready notification goes to their email.
+func (h *ExportHandler) Request() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		h.request(c, claims.Id, claims.Email)
+	}
+}
+
+func (h *ExportHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		h.list(c, claims.Id)
+	}
+}
+
+func (h *ExportHandler) Download() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		h.download(c, claims.Id)
+	}
+}
+
+// AdminRequest starts the export of the user of the id path parameter, the ready notification goes to the admin.
+func (h *ExportHandler) AdminRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		h.request(c, c.Param("id"), claims.Email)
+	}
+}
+
+func (h *ExportHandler) AdminList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.list(c, c.Param("id"))
+	}
+}
+
+func (h *ExportHandler) AdminDownload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.download(c, c.Param("id"))
+	}
+}
+
+func (h *ExportHandler) request(c *gin.Context, userID, notifyEmail string) {
+	var input requestExportInput
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	exp, err := h.useCases.RequestExport.Execute(c.Request.Context(), export_usecases.RequestExportInput{
+		UserID:      userID,
+		NotifyEmail: notifyEmail,
+		Format:      input.Format,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, exp)
+}
+
+func (h *ExportHandler) list(c *gin.Context, userID string) {
+	exports, err := h.useCases.ListExports.Execute(c.Request.Context(), export.ListExportsInput{UserID: userID})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, exports)
+}
+
+func (h *ExportHandler) download(c *gin.Context, userID string) {
+	archive, err := h.useCases.DownloadExport.Execute(c.Request.Context(), export.GetExportInput{
+		ID:     c.Param("exportId"),
+		UserID: userID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.FileName))
+	c.Data(http.StatusOK, archive.ContentType, archive.Data)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
 	"auth-api/src/pkg/app_error"
 	"context"
 	"io"
@@ -26,6 +28,13 @@ func bindQuery(c *gin.Context, obj interface{}) error {
 	return nil
 }
 
+func bindForm(c *gin.Context, obj interface{}) error {
+	if err := c.ShouldBind(obj); err != nil {
+		return app_error.NewApiError(400, "Invalid request")
+	}
+	return nil
+}
+
 func processRequest[T any, U any](c *gin.Context, input T, executeFunc func(context.Context, T) (U, error)) {
 	if err := bindJSON(c, &input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -66,3 +75,37 @@ func processRequestQuery[T any, U any](c *gin.Context, input T, executeFunc func
 	}
 	c.JSON(http.StatusOK, output)
 }
+
+func processRequestForm[T any, U any](c *gin.Context, input T, executeFunc func(context.Context, T) (U, error)) {
+	if err := bindForm(c, &input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := executeFunc(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// getClaims returns the claims stored by the auth middleware.
+func getClaims(c *gin.Context) (*auth.Claims, error) {
+	claims, exists := c.Get("claims")
+	if !exists {
+		return nil, app_error.NewApiError(401, "Unauthorized")
+	}
+	userClaims, ok := claims.(*auth.Claims)
+	if !ok {
+		return nil, app_error.NewApiError(401, "Unauthorized")
+	}
+	return userClaims, nil
+}
+
+// getOrgMember returns the membership stored by RequireOrgPermission, nil for platform admins.
+func getOrgMember(c *gin.Context) *organization.Member {
+	member, _ := c.Get("orgMember")
+	orgMember, _ := member.(*organization.Member)
+	return orgMember
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	oauth_usecases "auth-api/src/internal/modules/user-manager/usecases/oauth"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OAuthHandler struct {
+	useCases *oauth_usecases.UseCases
+}
+
+func NewOAuthHandler(useCases *oauth_usecases.UseCases) *OAuthHandler {
+	return &OAuthHandler{
+		useCases: useCases,
+	}
+}
+
+func (h *OAuthHandler) Discovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, h.useCases.Discovery.Configuration())
+	}
+}
+
+func (h *OAuthHandler) JWKS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, h.useCases.Discovery.JWKS())
+	}
+}
+
+type authorizeInput struct {
+	ResponseType        string `form:"response_type" json:"response_type"`
+	ClientID            string `form:"client_id" json:"client_id"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+func (input authorizeInput) toDomain() oauth.AuthorizeInput {
+	return oauth.AuthorizeInput{
+		ResponseType:        input.ResponseType,
+		ClientID:            input.ClientID,
+		RedirectURI:         input.RedirectURI,
+		Scope:               input.Scope,
+		State:               input.State,
+		Nonce:               input.Nonce,
+		CodeChallenge:       input.CodeChallenge,
+		CodeChallengeMethod: input.CodeChallengeMethod,
+	}
+}
+
+func (h *OAuthHandler) Authorize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input authorizeInput
+		if err := bindQuery(c, &input); err != nil {
+			c.Error(err)
+			return
+		}
+
+		out, err := h.useCases.Authorize.Execute(c.Request.Context(), oauth_usecases.AuthorizeInput{
+			AuthorizeInput: input.toDomain(),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.Redirect(http.StatusFound, out.RedirectTo)
+	}
+}
+
+type authorizeLoginInput struct {
+	authorizeInput
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (h *OAuthHandler) AuthorizeLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, authorizeLoginInput{}, func(ctx context.Context, input authorizeLoginInput) (*oauth.AuthorizeOutput, error) {
+			return h.useCases.AuthorizeLogin.Execute(ctx, oauth_usecases.AuthorizeLoginInput{
+				AuthorizeInput: input.toDomain(),
+				LoginInput: auth.LoginInput{
+					Username: input.Email,
+					Password: input.Password,
+				},
+			})
+		})
+	}
+}
+
+type authorizeMfaInput struct {
+	authorizeInput
+	Email   string `json:"email"`
+	Code    string `json:"code"`
+	Session string `json:"session"`
+}
+
+func (h *OAuthHandler) AuthorizeMfa() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, authorizeMfaInput{}, func(ctx context.Context, input authorizeMfaInput) (*oauth.AuthorizeOutput, error) {
+			return h.useCases.AuthorizeMFA.Execute(ctx, oauth_usecases.AuthorizeMFAInput{
+				AuthorizeInput: input.toDomain(),
+				VerifyMFAInput: auth.VerifyMFAInput{
+					Code:     input.Code,
+					Username: input.Email,
+					Session:  input.Session,
+				},
+			})
+		})
+	}
+}
+
+type tokenInput struct {
+	GrantType    string `form:"grant_type"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+}
+
+func (h *OAuthHandler) Token() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.Header("Pragma", "no-cache")
+		processRequestForm(c, tokenInput{}, func(ctx context.Context, input tokenInput) (*oauth.TokenOutput, error) {
+			// client_secret_basic takes precedence over credentials sent in the body (RFC 6749 section 2.3.1)
+			if clientID, clientSecret, ok := basicClientCredentials(c); ok {
+				input.ClientID = clientID
+				input.ClientSecret = clientSecret
+				c.Header("WWW-Authenticate", `Basic realm="oauth2"`)
+			}
+
+			return h.useCases.Token.Execute(ctx, oauth_usecases.TokenInput{
+				TokenInput: oauth.TokenInput{
+					GrantType:    input.GrantType,
+					ClientID:     input.ClientID,
+					ClientSecret: input.ClientSecret,
+					Code:         input.Code,
+					RedirectURI:  input.RedirectURI,
+					CodeVerifier: input.CodeVerifier,
+					RefreshToken: input.RefreshToken,
+					Scope:        input.Scope,
+				},
+			})
+		})
+	}
+}
+
+func basicClientCredentials(c *gin.Context) (string, string, bool) {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return "", "", false
+	}
+	clientSecret, err := url.QueryUnescape(password)
+	if err != nil {
+		return "", "", false
+	}
+	return clientID, clientSecret, true
+}
+
+type tokenHintInput struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+func (input *tokenHintInput) applyBasicAuth(c *gin.Context) {
+	if clientID, clientSecret, ok := basicClientCredentials(c); ok {
+		input.ClientID = clientID
+		input.ClientSecret = clientSecret
+		c.Header("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+}
+
+func (h *OAuthHandler) Introspect() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		processRequestForm(c, tokenHintInput{}, func(ctx context.Context, input tokenHintInput) (*oauth.IntrospectOutput, error) {
+			input.applyBasicAuth(c)
+
+			return h.useCases.Introspect.Execute(ctx, oauth_usecases.IntrospectInput{
+				IntrospectInput: oauth.IntrospectInput{
+					Token:         input.Token,
+					TokenTypeHint: input.TokenTypeHint,
+					ClientID:      input.ClientID,
+					ClientSecret:  input.ClientSecret,
+				},
+			})
+		})
+	}
+}
+
+// Revoke answers 200 with an empty body, also for unknown tokens (RFC 7009 section 2.2).
+func (h *OAuthHandler) Revoke() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		input := tokenHintInput{}
+		if err := bindForm(c, &input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		input.applyBasicAuth(c)
+
+		err := h.useCases.Revoke.Execute(c.Request.Context(), oauth_usecases.RevokeInput{
+			RevokeInput: oauth.RevokeInput{
+				Token:         input.Token,
+				TokenTypeHint: input.TokenTypeHint,
+				ClientID:      input.ClientID,
+				ClientSecret:  input.ClientSecret,
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+func (h *OAuthHandler) UserInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		out, err := h.useCases.UserInfo.Execute(c.Request.Context(), oauth_usecases.UserInfoInput{
+			UserInfoInput: oauth.UserInfoInput{
+				AccessToken: bearerToken(c),
+			},
+		})
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, out)
+	}
+}
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	organization_usecases "auth-api/src/internal/modules/user-manager/usecases/organization"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	useCases *organization_usecases.UseCases
+}
+
+func NewOrganizationHandler(useCases *organization_usecases.UseCases) *OrganizationHandler {
+	return &OrganizationHandler{
+		useCases: useCases,
+	}
+}
+
+type createOrganizationInput struct {
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	OwnerUsername string `json:"ownerUsername"`
+}
+
+func (h *OrganizationHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, createOrganizationInput{}, func(ctx context.Context, input createOrganizationInput) (*organization.Organization, error) {
+			return h.useCases.CreateOrganization.Execute(ctx, organization_usecases.CreateOrganizationInput{
+				CreateOrganizationInput: organization.CreateOrganizationInput{
+					Name: input.Name,
+					Slug: input.Slug,
+				},
+				OwnerUsername: input.OwnerUsername,
+			})
+		})
+	}
+}
+
+func (h *OrganizationHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orgs, err := h.useCases.ListOrganizations.Execute(c.Request.Context())
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, orgs)
+	}
+}
+
+func (h *OrganizationHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.DeleteOrganization.Execute(c.Request.Context(), organization_usecases.DeleteOrganizationInput{
+			DeleteOrganizationInput: organization.DeleteOrganizationInput{
+				ID: c.Param("orgId"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+func (h *OrganizationHandler) ListMine() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		memberships, err := h.useCases.ListMemberships.Execute(c.Request.Context(), organization_usecases.ListMembershipsInput{
+			ListMembershipsInput: organization.ListMembershipsInput{
+				UserID: claims.Id,
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, memberships)
+	}
+}
+
+func (h *OrganizationHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		org, err := h.useCases.GetOrganization.Execute(c.Request.Context(), organization_usecases.GetOrganizationInput{
+			GetOrganizationInput: organization.GetOrganizationInput{
+				ID: c.Param("orgId"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, org)
+	}
+}
+
+type updateOrganizationInput struct {
+	Name *string `json:"name"`
+	Slug *string `json:"slug"`
+}
+
+func (h *OrganizationHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, updateOrganizationInput{}, func(ctx context.Context, input updateOrganizationInput) (*organization.Organization, error) {
+			return h.useCases.UpdateOrganization.Execute(ctx, organization_usecases.UpdateOrganizationInput{
+				UpdateOrganizationInput: organization.UpdateOrganizationInput{
+					ID:   c.Param("orgId"),
+					Name: input.Name,
+					Slug: input.Slug,
+				},
+			})
+		})
+	}
+}
+
+func (h *OrganizationHandler) ListMembers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		members, err := h.useCases.ListMembers.Execute(c.Request.Context(), organization_usecases.ListMembersInput{
+			ListMembersInput: organization.ListMembersInput{
+				OrganizationID: c.Param("orgId"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, members)
+	}
+}
+
+type addMemberInput struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (h *OrganizationHandler) AddMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, addMemberInput{}, func(ctx context.Context, input addMemberInput) (*organization.Member, error) {
+			return h.useCases.AddMember.Execute(ctx, organization_usecases.AddMemberInput{
+				OrganizationID: c.Param("orgId"),
+				Username:       input.Username,
+				Role:           input.Role,
+				Actor:          getOrgMember(c),
+			})
+		})
+	}
+}
+
+type updateMemberInput struct {
+	Role string `json:"role"`
+}
+
+func (h *OrganizationHandler) UpdateMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, updateMemberInput{}, func(ctx context.Context, input updateMemberInput) (*organization.Member, error) {
+			return h.useCases.UpdateMember.Execute(ctx, organization_usecases.UpdateMemberInput{
+				UpdateMemberInput: organization.UpdateMemberInput{
+					OrganizationID: c.Param("orgId"),
+					UserID:         c.Param("userId"),
+					Role:           input.Role,
+				},
+				Actor: getOrgMember(c),
+			})
+		})
+	}
+}
+
+func (h *OrganizationHandler) RemoveMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.RemoveMember.Execute(c.Request.Context(), organization_usecases.RemoveMemberInput{
+			RemoveMemberInput: organization.RemoveMemberInput{
+				OrganizationID: c.Param("orgId"),
+				UserID:         c.Param("userId"),
+			},
+			Actor: getOrgMember(c),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+type issueTokenInput struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (h *OrganizationHandler) IssueToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		processRequest(c, issueTokenInput{}, func(ctx context.Context, input issueTokenInput) (*auth.RefreshTokenOutput, error) {
+			return h.useCases.IssueToken.Execute(ctx, organization_usecases.IssueTokenInput{
+				RefreshTokenInput: auth.RefreshTokenInput{
+					RefreshToken:   input.RefreshToken,
+					OrganizationID: c.Param("orgId"),
+				},
+				UserID: claims.Id,
+			})
+		})
+	}
+}
+
+type createInvitationInput struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+func (h *OrganizationHandler) CreateInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		processRequest(c, createInvitationInput{}, func(ctx context.Context, input createInvitationInput) (*organization.Invitation, error) {
+			return h.useCases.CreateInvitation.Execute(ctx, organization_usecases.CreateInvitationInput{
+				OrganizationID: c.Param("orgId"),
+				Email:          input.Email,
+				Role:           input.Role,
+				InvitedBy:      claims.Id,
+				Actor:          getOrgMember(c),
+			})
+		})
+	}
+}
+
+func (h *OrganizationHandler) ListInvitations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invitations, err := h.useCases.ListInvitations.Execute(c.Request.Context(), organization_usecases.ListInvitationsInput{
+			ListInvitationsInput: organization.ListInvitationsInput{
+				OrganizationID: c.Param("orgId"),
+				Status:         organization.InvitationStatus(c.Query("status")),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, invitations)
+	}
+}
+
+func (h *OrganizationHandler) ResendInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		invitation, err := h.useCases.ResendInvitation.Execute(c.Request.Context(), organization_usecases.ResendInvitationInput{
+			OrganizationID: c.Param("orgId"),
+			InvitationID:   c.Param("invitationId"),
+			Actor:          getOrgMember(c),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, invitation)
+	}
+}
+
+func (h *OrganizationHandler) RevokeInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.RevokeInvitation.Execute(c.Request.Context(), organization_usecases.RevokeInvitationInput{
+			OrganizationID: c.Param("orgId"),
+			InvitationID:   c.Param("invitationId"),
+			Actor:          getOrgMember(c),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+type invitationTokenInput struct {
+	Token string `form:"token"`
+}
+
+func (h *OrganizationHandler) GetInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input invitationTokenInput
+		if err := bindQuery(c, &input); err != nil {
+			c.Error(err)
+			return
+		}
+
+		out, err := h.useCases.GetInvitation.Execute(c.Request.Context(), organization_usecases.GetInvitationInput{
+			Token: input.Token,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, out)
+	}
+}
+
+type acceptInvitationInput struct {
+	Token string `json:"token"`
+}
+
+func (h *OrganizationHandler) AcceptInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		processRequest(c, acceptInvitationInput{}, func(ctx context.Context, input acceptInvitationInput) (*organization.Member, error) {
+			return h.useCases.AcceptInvitation.Execute(ctx, organization_usecases.AcceptInvitationInput{
+				Token:  input.Token,
+				UserID: claims.Id,
+				Email:  claims.Email,
+			})
+		})
+	}
+}
+
+type acceptInvitationSignUpInput struct {
+	Token    string  `json:"token"`
+	Name     string  `json:"name"`
+	Password string  `json:"password"`
+	Phone    *string `json:"phone"`
+}
+
+func (h *OrganizationHandler) AcceptInvitationSignUp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, acceptInvitationSignUpInput{}, func(ctx context.Context, input acceptInvitationSignUpInput) (*organization.Member, error) {
+			return h.useCases.AcceptInvitationSignUp.Execute(ctx, organization_usecases.AcceptInvitationSignUpInput{
+				Token:    input.Token,
+				Name:     input.Name,
+				Password: input.Password,
+				Phone:    input.Phone,
+			})
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	reconcile_usecases "auth-api/src/internal/modules/user-manager/usecases/reconcile"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconcileHandler struct {
+	useCases *reconcile_usecases.UseCases
+}
+
+func NewReconcileHandler(useCases *reconcile_usecases.UseCases) *ReconcileHandler {
+	return &ReconcileHandler{
+		useCases: useCases,
+	}
+}
+
+type reconcileQuery struct {
+	Repair bool `form:"repair"`
+}
+
+// Reconcile only reports the issues unless repair=true.
+func (h *ReconcileHandler) Reconcile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query reconcileQuery
+		if err := bindQuery(c, &query); err != nil {
+			c.Error(err)
+			return
+		}
+
+		report, err := h.useCases.Reconcile.Execute(c.Request.Context(), reconcile_usecases.ReconcileInput{
+			DryRun: !query.Repair,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	role_usecases "auth-api/src/internal/modules/user-manager/usecases/role"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	useCases *role_usecases.UseCases
+}
+
+func NewRoleHandler(useCases *role_usecases.UseCases) *RoleHandler {
+	return &RoleHandler{
+		useCases: useCases,
+	}
+}
+
+type createRoleInput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (h *RoleHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, createRoleInput{}, func(ctx context.Context, input createRoleInput) (*role.Role, error) {
+			return h.useCases.CreateRole.Execute(ctx, role_usecases.CreateRoleInput{
+				CreateRoleInput: role.CreateRoleInput{
+					Name:        input.Name,
+					Description: input.Description,
+					Permissions: input.Permissions,
+				},
+			})
+		})
+	}
+}
+
+func (h *RoleHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, err := h.useCases.ListRoles.Execute(c.Request.Context())
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, roles)
+	}
+}
+
+func (h *RoleHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		found, err := h.useCases.GetRole.Execute(c.Request.Context(), role_usecases.GetRoleInput{
+			GetRoleInput: role.GetRoleInput{
+				Name: c.Param("name"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, found)
+	}
+}
+
+type updateRoleInput struct {
+	Description *string   `json:"description"`
+	Permissions *[]string `json:"permissions"`
+}
+
+func (h *RoleHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequest(c, updateRoleInput{}, func(ctx context.Context, input updateRoleInput) (*role.Role, error) {
+			return h.useCases.UpdateRole.Execute(ctx, role_usecases.UpdateRoleInput{
+				UpdateRoleInput: role.UpdateRoleInput{
+					Name:        c.Param("name"),
+					Description: input.Description,
+					Permissions: input.Permissions,
+				},
+			})
+		})
+	}
+}
+
+func (h *RoleHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.DeleteRole.Execute(c.Request.Context(), role_usecases.DeleteRoleInput{
+			DeleteRoleInput: role.DeleteRoleInput{
+				Name: c.Param("name"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+type assignRoleInput struct {
+	Username string `json:"username"`
+}
+
+func (h *RoleHandler) Assign() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequestNoOutput(c, assignRoleInput{}, func(ctx context.Context, input assignRoleInput) error {
+			return h.useCases.AssignRole.Execute(ctx, role_usecases.AssignRoleInput{
+				AssignRoleInput: role.AssignRoleInput{
+					Name:     c.Param("name"),
+					Username: input.Username,
+				},
+			})
+		})
+	}
+}
+
+func (h *RoleHandler) Unassign() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.useCases.UnassignRole.Execute(c.Request.Context(), role_usecases.UnassignRoleInput{
+			UnassignRoleInput: role.UnassignRoleInput{
+				Name:     c.Param("name"),
+				Username: c.Param("username"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+func (h *RoleHandler) ListUserRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, err := h.useCases.ListUserRoles.Execute(c.Request.Context(), role_usecases.ListUserRolesInput{
+			ListUserRolesInput: role.ListUserRolesInput{
+				Username: c.Query("username"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, roles)
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SagaHandler struct {
+	sagaService saga.SagaService
+}
+
+func NewSagaHandler(sagaService saga.SagaService) *SagaHandler {
+	return &SagaHandler{
+		sagaService: sagaService,
+	}
+}
+
+type listSagasQuery struct {
+	Status string `form:"status"`
+	Type   string `form:"type"`
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+func (h *SagaHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequestQuery(c, listSagasQuery{}, func(ctx context.Context, query listSagasQuery) (*saga.SagaPage, error) {
+			return h.sagaService.List(ctx, saga.ListSagasInput{
+				Status: saga.Status(query.Status),
+				Type:   saga.Type(query.Type),
+				Cursor: query.Cursor,
+				Limit:  query.Limit,
+			})
+		})
+	}
+}
+
+func (h *SagaHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sg, err := h.sagaService.Get(c.Request.Context(), saga.GetSagaInput{
+			ID: c.Param("id"),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, sg)
+	}
+}
+
+// Resume retries a stuck or failed saga from the step it stopped at.
+func (h *SagaHandler) Resume() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sg, err := h.sagaService.Resume(c.Request.Context(), saga.ResumeSagaInput{
+			ID: c.Param("id"),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, sg)
+	}
+}
+
+// Compensate gives up on a saga, the worker undoes its completed steps.
+func (h *SagaHandler) Compensate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sg, err := h.sagaService.Compensate(c.Request.Context(), saga.CompensateSagaInput{
+			ID: c.Param("id"),
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, sg)
+	}
+}
@@ -6,6 +6,11 @@ import (
 	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
 	"auth-api/src/pkg/app_error"
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,10 +26,11 @@ func NewUserHandler(useCases *user_usecases.UseCases) *UserHandler {
 }
 
 type registerUserInput struct {
-	Email    string  `json:"email"`
-	Password string  `json:"password"`
-	Name     string  `json:"name"`
-	Phone    *string `json:"phone"`
+	Email      string          `json:"email"`
+	Password   string          `json:"password"`
+	Name       string          `json:"name"`
+	Phone      *string         `json:"phone"`
+	Attributes user.Attributes `json:"attributes"`
 }
 
 func (h *UserHandler) Register() gin.HandlerFunc {
@@ -37,9 +43,10 @@ func (h *UserHandler) Register() gin.HandlerFunc {
 					Name:     input.Name,
 				},
 				CreateUserInput: user.CreateUserInput{
-					Phone: input.Phone,
-					Name:  input.Name,
-					Email: input.Email,
+					Phone:      input.Phone,
+					Name:       input.Name,
+					Email:      input.Email,
+					Attributes: input.Attributes,
 				},
 			})
 			return err
@@ -48,8 +55,9 @@ func (h *UserHandler) Register() gin.HandlerFunc {
 }
 
 type updateUserInput struct {
-	Name  *string `json:"name"`
-	Phone *string `json:"phone"`
+	Name       *string         `json:"name"`
+	Phone      *string         `json:"phone"`
+	Attributes user.Attributes `json:"attributes"`
 }
 
 func (h *UserHandler) Update() gin.HandlerFunc {
@@ -75,12 +83,431 @@ func (h *UserHandler) Update() gin.HandlerFunc {
 			}
 			err = h.useCases.Update.Execute(ctx, user_usecases.UpdateUserInput{
 				UpdateUserInput: user.UpdateUserInput{
-					ID:    userId,
-					Name:  input.Name,
-					Phone: input.Phone,
+					ID:         userId,
+					Name:       input.Name,
+					Phone:      input.Phone,
+					Attributes: input.Attributes,
 				},
 			})
 			return err
 		})
 	}
 }
+
+type listUsersQuery struct {
+	Cursor        string     `form:"cursor"`
+	Limit         int        `form:"limit"`
+	Status        string     `form:"status"`
+	Group         string     `form:"group"`
+	Search        string     `form:"q"`
+	CreatedAfter  *time.Time `form:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (h *UserHandler) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		processRequestQuery(c, listUsersQuery{}, func(ctx context.Context, query listUsersQuery) (*user_usecases.ListUsersOutput, error) {
+			return h.useCases.List.Execute(ctx, user_usecases.ListUsersInput{
+				ListUsersInput: user.ListUsersInput{
+					Cursor:        query.Cursor,
+					Limit:         query.Limit,
+					Search:        query.Search,
+					CreatedAfter:  query.CreatedAfter,
+					CreatedBefore: query.CreatedBefore,
+				},
+				Status: auth.UserStatus(query.Status),
+				Group:  auth.UserGroup(query.Group),
+			})
+		})
+	}
+}
+
+func (h *UserHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usr, err := h.useCases.Get.Execute(c.Request.Context(), user_usecases.GetUserInput{
+			GetUserInput: user.GetUserInput{
+				ID: c.Param("id"),
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, usr)
+	}
+}
+
+func (h *UserHandler) AdminUpdate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		processRequest(c, updateUserInput{}, func(ctx context.Context, input updateUserInput) (*user.User, error) {
+			userID, err := user.ParseUserID(c.Param("id"))
+			if err != nil {
+				return nil, err
+			}
+			return h.useCases.AdminUpdate.Execute(ctx, user_usecases.AdminUpdateUserInput{
+				UpdateUserInput: user.UpdateUserInput{
+					ID:         userID,
+					Name:       input.Name,
+					Phone:      input.Phone,
+					Attributes: input.Attributes,
+				},
+				PerformedBy:    claims.Id,
+				PerformerRoles: claims.UserGroups,
+			})
+		})
+	}
+}
+
+// accountAction runs an admin lifecycle use case on the user of the id path parameter.
+func (h *UserHandler) accountAction(execute func(context.Context, user_usecases.AccountInput) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if err := execute(c.Request.Context(), user_usecases.AccountInput{
+			ID:             c.Param("id"),
+			PerformedBy:    claims.Id,
+			PerformerRoles: claims.UserGroups,
+		}); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *UserHandler) Disable() gin.HandlerFunc {
+	return h.accountAction(h.useCases.Disable.Execute)
+}
+
+func (h *UserHandler) Enable() gin.HandlerFunc {
+	return h.accountAction(h.useCases.Enable.Execute)
+}
+
+func (h *UserHandler) ForcePasswordReset() gin.HandlerFunc {
+	return h.accountAction(h.useCases.ForcePasswordReset.Execute)
+}
+
+func (h *UserHandler) Confirm() gin.HandlerFunc {
+	return h.accountAction(h.useCases.Confirm.Execute)
+}
+
+func (h *UserHandler) ResendConfirmation() gin.HandlerFunc {
+	return h.accountAction(h.useCases.ResendConfirmation.Execute)
+}
+
+func (h *UserHandler) Delete() gin.HandlerFunc {
+	return h.accountAction(h.useCases.Delete.Execute)
+}
+
+type deleteAccountInput struct {
+	Password string `json:"password"`
+	MFACode  string `json:"mfaCode"`
+}
+
+// DeleteAccount answers 204 when the account is gone and 202 with the pending deletion during a grace period.
+func (h *UserHandler) DeleteAccount() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		var input deleteAccountInput
+		if err := bindJSON(c, &input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, err := user.ParseUserID(claims.Id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		deletion, err := h.useCases.DeleteAccount.Execute(c.Request.Context(), user_usecases.DeleteAccountInput{
+			DeleteAccountInput: user.DeleteAccountInput{
+				ID:       userID,
+				Username: claims.Email,
+				Password: input.Password,
+				MFACode:  input.MFACode,
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if deletion != nil {
+			c.JSON(http.StatusAccepted, deletion)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+func (h *UserHandler) GetAccountDeletion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		deletion, err := h.useCases.GetAccountDeletion.Execute(c.Request.Context(), claims.Id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, deletion)
+	}
+}
+
+func (h *UserHandler) CancelAccountDeletion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if err := h.useCases.CancelAccountDeletion.Execute(c.Request.Context(), claims.Id); err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+type requestEmailChangeInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	MFACode  string `json:"mfaCode"`
+}
+
+type confirmEmailChangeInput struct {
+	Code string `json:"code"`
+}
+
+func (h *UserHandler) RequestEmailChange() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		var input requestEmailChangeInput
+		if err := bindJSON(c, &input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, err := user.ParseUserID(claims.Id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		change, err := h.useCases.RequestEmailChange.Execute(c.Request.Context(), user_usecases.RequestEmailChangeInput{
+			RequestEmailChangeInput: user.RequestEmailChangeInput{
+				ID:       userID,
+				NewEmail: input.Email,
+				Username: claims.Email,
+				Password: input.Password,
+				MFACode:  input.MFACode,
+			},
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusAccepted, change)
+	}
+}
+
+func (h *UserHandler) ConfirmEmailChange() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		processRequestNoOutput(c, confirmEmailChangeInput{}, func(ctx context.Context, input confirmEmailChangeInput) error {
+			userID, err := user.ParseUserID(claims.Id)
+			if err != nil {
+				return err
+			}
+			return h.useCases.ConfirmEmailChange.Execute(ctx, user_usecases.ConfirmEmailChangeInput{
+				ConfirmEmailChangeInput: user.ConfirmEmailChangeInput{
+					ID:   userID,
+					Code: input.Code,
+				},
+			})
+		})
+	}
+}
+
+func (h *UserHandler) CancelEmailChange() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if err := h.useCases.CancelEmailChange.Execute(c.Request.Context(), claims.Id); err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+type verifyPhoneInput struct {
+	Code string `json:"code"`
+}
+
+func (h *UserHandler) SendPhoneVerification() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if err := h.useCases.SendPhoneVerification.Execute(c.Request.Context(), claims.Id); err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusNoContent, gin.H{})
+	}
+}
+
+func (h *UserHandler) VerifyPhone() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := getClaims(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		processRequestNoOutput(c, verifyPhoneInput{}, func(ctx context.Context, input verifyPhoneInput) error {
+			userID, err := user.ParseUserID(claims.Id)
+			if err != nil {
+				return err
+			}
+			return h.useCases.VerifyPhone.Execute(ctx, user_usecases.VerifyPhoneInput{
+				VerifyPhoneInput: user.VerifyPhoneInput{
+					ID:   userID,
+					Code: input.Code,
+				},
+			})
+		})
+	}
+}
+
+type importUsersInput struct {
+	Format string `form:"format"`
+	DryRun bool   `form:"dryRun"`
+	Invite bool   `form:"invite"`
+}
+
+// ImportUsers reads the CSV or JSON lines file from the request body, up to the configured size.
+func (h *UserHandler) ImportUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input importUsersInput
+		if err := bindQuery(c, &input); err != nil {
+			c.Error(err)
+			return
+		}
+		if input.Format == "" {
+			input.Format = string(user.ImportCSV)
+		}
+
+		if maxBytes := h.useCases.Import.MaxBytes(); maxBytes > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		report, err := h.useCases.Import.Execute(c.Request.Context(), user_usecases.ImportUsersInput{
+			File:            c.Request.Body,
+			Format:          user.ImportFormat(input.Format),
+			DryRun:          input.DryRun,
+			SendInvitations: input.Invite,
+		})
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				err = user.ErrImportFileTooLarge
+			}
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+type exportUsersQuery struct {
+	Format        string     `form:"format"`
+	Fields        string     `form:"fields"`
+	Status        string     `form:"status"`
+	Group         string     `form:"group"`
+	Search        string     `form:"q"`
+	CreatedAfter  *time.Time `form:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ExportUsers streams the users matching the directory filters, errors after the first page only end the response.
+func (h *UserHandler) ExportUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query exportUsersQuery
+		if err := bindQuery(c, &query); err != nil {
+			c.Error(err)
+			return
+		}
+
+		var fields []string
+		if query.Fields != "" {
+			fields = strings.Split(query.Fields, ",")
+		}
+
+		export, err := h.useCases.Export.Execute(c.Request.Context(), user_usecases.ExportUsersInput{
+			Search:        query.Search,
+			CreatedAfter:  query.CreatedAfter,
+			CreatedBefore: query.CreatedBefore,
+			Status:        auth.UserStatus(query.Status),
+			Group:         auth.UserGroup(query.Group),
+			Format:        user_usecases.UserExportFormat(query.Format),
+			Fields:        fields,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Header("Content-Type", export.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName()))
+		c.Status(http.StatusOK)
+		if err := export.WriteTo(c.Request.Context(), c.Writer); err != nil {
+			c.Abort()
+		}
+	}
+}
@@ -2,29 +2,113 @@ package middleware
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
 	"auth-api/src/pkg/app_error"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthMiddleware interface {
 	AuthMiddleware(groupNames ...auth.UserGroup) gin.HandlerFunc
+	// AuthMiddlewareWithScopes also accepts client credentials tokens, authorized by scopes instead of user groups.
+	AuthMiddlewareWithScopes(scopes []string, groupNames ...auth.UserGroup) gin.HandlerFunc
+	// RequirePermission lets users through when one of their roles grants the permission, and client
+	// credentials tokens when they carry every given scope.
+	RequirePermission(permission string, scopes ...string) gin.HandlerFunc
+	// RequireOrgPermission authorizes users inside the organization of the orgId path parameter, or of the
+	// X-Org-Id header, through the role of their membership. The organization ID and the membership are
+	// stored in the context as "orgId" and "orgMember".
+	RequireOrgPermission(permission string) gin.HandlerFunc
 }
 
 type AuthMiddlewareImpl struct {
-	auth auth.AuthService
+	auth     auth.AuthService
+	oauth    oauth.OAuthService
+	denylist denylist.DenylistService
+	roles    role.RoleService
+	orgs     organization.OrganizationService
 }
 
-func NewAuthMiddleware(a auth.AuthService) AuthMiddleware {
+func NewAuthMiddleware(a auth.AuthService, o oauth.OAuthService, d denylist.DenylistService, r role.RoleService, orgs organization.OrganizationService) AuthMiddleware {
 	return &AuthMiddlewareImpl{
-		auth: a,
+		auth:     a,
+		oauth:    o,
+		denylist: d,
+		roles:    r,
+		orgs:     orgs,
 	}
 }
 
 func (a *AuthMiddlewareImpl) AuthMiddleware(groupNames ...auth.UserGroup) gin.HandlerFunc {
+	return a.AuthMiddlewareWithScopes(nil, groupNames...)
+}
+
+func (a *AuthMiddlewareImpl) AuthMiddlewareWithScopes(scopes []string, groupNames ...auth.UserGroup) gin.HandlerFunc {
+	return a.authenticate(scopes, func(c *gin.Context, claims *auth.Claims) error {
+		userGroups := make(map[string]struct{}, len(claims.UserGroups))
+		for _, group := range claims.UserGroups {
+			userGroups[group] = struct{}{}
+		}
+
+		for _, groupName := range groupNames {
+			if _, exists := userGroups[string(groupName)]; exists {
+				return nil
+			}
+		}
+		return app_error.NewApiError(401, "Unauthorized")
+	})
+}
+
+func (a *AuthMiddlewareImpl) RequirePermission(permission string, scopes ...string) gin.HandlerFunc {
+	return a.authenticate(scopes, func(c *gin.Context, claims *auth.Claims) error {
+		allowed, err := a.roles.HasPermission(c.Request.Context(), claims.UserGroups, permission)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return app_error.NewApiError(403, "Missing permission", "Permission: "+permission)
+		}
+		return nil
+	})
+}
+
+func (a *AuthMiddlewareImpl) RequireOrgPermission(permission string) gin.HandlerFunc {
+	return a.authenticate(nil, func(c *gin.Context, claims *auth.Claims) error {
+		orgID := c.Param("orgId")
+		if orgID == "" {
+			orgID = c.GetHeader("X-Org-Id")
+		}
+		// A token bound to an organization cannot be used on another one.
+		if claims.OrgId != "" && claims.OrgId != orgID {
+			return organization.ErrOrganizationMismatch
+		}
+
+		member, err := a.orgs.Authorize(c.Request.Context(), organization.AuthorizeInput{
+			OrganizationID: orgID,
+			UserID:         claims.Id,
+			Groups:         claims.UserGroups,
+			Permission:     permission,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.Set("orgId", orgID)
+		c.Set("orgMember", member)
+		return nil
+	})
+}
+
+// authenticate validates the bearer token and lets the request through if authorize accepts the user.
+// Client credentials tokens are authorized by scopes instead.
+func (a *AuthMiddlewareImpl) authenticate(scopes []string, authorize func(c *gin.Context, claims *auth.Claims) error) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if len(authHeader) < 7 {
 			c.Error(app_error.NewApiError(401, "Unauthorized"))
 			c.Abort()
 			return
@@ -32,35 +116,95 @@ func (a *AuthMiddlewareImpl) AuthMiddleware(groupNames ...auth.UserGroup) gin.Ha
 
 		token := authHeader[7:] // remove Bearer from token
 
+		if a.oauth.IsClientToken(token) {
+			a.authorizeClient(c, token, scopes)
+			return
+		}
+
 		claims, err := a.auth.ValidateToken(c.Request.Context(), token)
 		if err != nil {
-			c.Error(app_error.NewApiError(401, "Unauthorized"))
+			c.Error(unauthorizedError(err))
 			c.Abort()
 			return
 		}
 
-		userGroups := make(map[string]struct{}, len(claims.UserGroups))
-		for _, group := range claims.UserGroups {
-			userGroups[group] = struct{}{}
-		}
-
-		authorized := false
-		for _, groupName := range groupNames {
-			if _, exists := userGroups[string(groupName)]; exists {
-				authorized = true
-				break
-			}
+		if !a.checkDenylist(c, denylist.IsDeniedInput{
+			Jti:       claims.Jti,
+			OriginJti: claims.OriginJti,
+			Subject:   claims.Id,
+			IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		}) {
+			return
 		}
 
-		if !authorized {
-			c.Error(app_error.NewApiError(401, "Unauthorized"))
+		if err := authorize(c, claims); err != nil {
+			c.Error(err)
 			c.Abort()
 			return
 		}
 
 		c.Set("jwtToken", token)
 		c.Set("claims", claims)
+		if claims.OrgId != "" {
+			c.Set("orgId", claims.OrgId)
+		}
 
 		c.Next()
 	}
 }
+
+// authorizeClient lets a client credentials token through only if it was granted every required scope.
+// Routes without required scopes are closed to client applications.
+func (a *AuthMiddlewareImpl) authorizeClient(c *gin.Context, token string, scopes []string) {
+	clientClaims, err := a.oauth.ValidateClientToken(c.Request.Context(), token)
+	if err != nil || len(scopes) == 0 {
+		c.Error(app_error.NewApiError(401, "Unauthorized"))
+		c.Abort()
+		return
+	}
+
+	if !a.checkDenylist(c, denylist.IsDeniedInput{
+		Jti:      clientClaims.Jti,
+		Subject:  clientClaims.ClientID,
+		IssuedAt: clientClaims.IssuedAt,
+	}) {
+		return
+	}
+
+	for _, scope := range scopes {
+		if !clientClaims.HasScope(scope) {
+			c.Error(app_error.NewApiError(403, "Insufficient scope", "Scope: "+scope))
+			c.Abort()
+			return
+		}
+	}
+
+	c.Set("jwtToken", token)
+	c.Set("clientClaims", clientClaims)
+
+	c.Next()
+}
+
+// checkDenylist aborts the request when the token was revoked. Lookup failures are not let through.
+func (a *AuthMiddlewareImpl) checkDenylist(c *gin.Context, input denylist.IsDeniedInput) bool {
+	denied, err := a.denylist.IsDenied(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return false
+	}
+	if denied {
+		c.Error(denylist.ErrTokenRevoked)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// unauthorizedError keeps the precise 401 reason of token validation errors and hides anything else.
+func unauthorizedError(err error) error {
+	if apiErr, ok := err.(*app_error.ApiError); ok && apiErr.StatusCode == 401 {
+		return apiErr
+	}
+	return app_error.NewApiError(401, "Unauthorized")
+}
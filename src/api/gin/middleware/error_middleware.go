@@ -16,6 +16,9 @@ func ErrorHandler(log logger.Logger) gin.HandlerFunc {
 			switch e := err.Err.(type) {
 			case *app_error.ApiError:
 				c.JSON(e.StatusCode, e)
+			case *app_error.OAuthError:
+				c.Header("Cache-Control", "no-store")
+				c.JSON(e.StatusCode, e)
 			default:
 				log.Error("Error occurred %v", e)
 				c.JSON(http.StatusInternalServerError, map[string]string{"message": e.Error()})
@@ -3,7 +3,7 @@ package routes
 import (
 	"auth-api/src/api/gin/handlers"
 	"auth-api/src/api/gin/middleware"
-	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
 	"time"
 )
 
@@ -12,7 +12,67 @@ func (r *routes) configAdminRoutes() {
 	adminGroup := r.gin.Group("/admin")
 	adminGroup.Use(middleware.TimeoutMiddleware(30 * time.Second))
 
-	adminGroup.PATCH("/", r.authMiddleware.AuthMiddleware(auth.GroupAdmin), handler.Update())
-	adminGroup.POST("/register", r.authMiddleware.AuthMiddleware(auth.GroupAdmin), handler.Register())
+	adminGroup.PATCH("/", r.authMiddleware.RequirePermission(role.PermissionAdminsWrite), handler.Update())
+	adminGroup.POST("/register", r.authMiddleware.RequirePermission(role.PermissionAdminsWrite), handler.Register())
+
+	userHandler := handlers.NewUserHandler(r.factory.UseCases.UserManager.User)
+	usersGroup := adminGroup.Group("/users")
+	usersGroup.GET("", r.authMiddleware.RequirePermission(role.PermissionUsersRead), userHandler.List())
+	usersGroup.GET("/:id", r.authMiddleware.RequirePermission(role.PermissionUsersRead), userHandler.Get())
+	usersGroup.PATCH("/:id", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.AdminUpdate())
+	usersGroup.POST("/:id/disable", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.Disable())
+	usersGroup.POST("/:id/enable", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.Enable())
+	usersGroup.POST("/:id/reset-password", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.ForcePasswordReset())
+	usersGroup.POST("/:id/confirm", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.Confirm())
+	usersGroup.POST("/:id/resend-confirmation", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.ResendConfirmation())
+	usersGroup.DELETE("/:id", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.Delete())
+
+	// Imports of large files outlive the admin timeout.
+	importGroup := r.gin.Group("/admin/users/import")
+	importGroup.Use(middleware.TimeoutMiddleware(10 * time.Minute))
+	importGroup.POST("", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), userHandler.ImportUsers())
+
+	reconcileHandler := handlers.NewReconcileHandler(r.factory.UseCases.UserManager.Reconcile)
+	reconcileGroup := r.gin.Group("/admin/users/reconcile")
+	reconcileGroup.Use(middleware.TimeoutMiddleware(10 * time.Minute))
+	reconcileGroup.POST("", r.authMiddleware.RequirePermission(role.PermissionUsersWrite), reconcileHandler.Reconcile())
+
+	// Exports are streamed and bound by the request context instead of a timeout.
+	r.gin.GET("/admin/users/export", r.authMiddleware.RequirePermission(role.PermissionUsersRead), userHandler.ExportUsers())
+
+	exportHandler := handlers.NewExportHandler(r.factory.UseCases.UserManager.Export)
+	usersGroup.POST("/:id/exports", r.authMiddleware.RequirePermission(role.PermissionUsersRead), exportHandler.AdminRequest())
+	usersGroup.GET("/:id/exports", r.authMiddleware.RequirePermission(role.PermissionUsersRead), exportHandler.AdminList())
+	usersGroup.GET("/:id/exports/:exportId/download", r.authMiddleware.RequirePermission(role.PermissionUsersRead), exportHandler.AdminDownload())
+
+	clientHandler := handlers.NewClientHandler(r.factory.UseCases.UserManager.OAuth)
+	clientsGroup := adminGroup.Group("/clients")
+	clientsGroup.Use(r.authMiddleware.RequirePermission(role.PermissionClientsManage))
+	clientsGroup.POST("", clientHandler.Create())
+	clientsGroup.GET("", clientHandler.List())
+	clientsGroup.GET("/:id", clientHandler.Get())
+	clientsGroup.PATCH("/:id", clientHandler.Update())
+	clientsGroup.POST("/:id/secret", clientHandler.RotateSecret())
+	clientsGroup.DELETE("/:id", clientHandler.Delete())
+
+	roleHandler := handlers.NewRoleHandler(r.factory.UseCases.UserManager.Role)
+	rolesGroup := adminGroup.Group("/roles")
+	rolesGroup.Use(r.authMiddleware.RequirePermission(role.PermissionRolesManage))
+	rolesGroup.POST("", roleHandler.Create())
+	rolesGroup.GET("", roleHandler.List())
+	rolesGroup.GET("/:name", roleHandler.Get())
+	rolesGroup.PATCH("/:name", roleHandler.Update())
+	rolesGroup.DELETE("/:name", roleHandler.Delete())
+	rolesGroup.POST("/:name/assignments", roleHandler.Assign())
+	rolesGroup.DELETE("/:name/assignments/:username", roleHandler.Unassign())
+	adminGroup.GET("/role-assignments", r.authMiddleware.RequirePermission(role.PermissionRolesManage), roleHandler.ListUserRoles())
+
+	sagaHandler := handlers.NewSagaHandler(r.factory.Service.Saga)
+	sagasGroup := adminGroup.Group("/sagas")
+	sagasGroup.Use(r.authMiddleware.RequirePermission(role.PermissionSagasManage))
+	sagasGroup.GET("", sagaHandler.List())
+	sagasGroup.GET("/:id", sagaHandler.Get())
+	sagasGroup.POST("/:id/resume", sagaHandler.Resume())
+	sagasGroup.POST("/:id/compensate", sagaHandler.Compensate())
 
 }
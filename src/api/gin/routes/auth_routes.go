@@ -4,6 +4,8 @@ import (
 	"auth-api/src/api/gin/handlers"
 	"auth-api/src/api/gin/middleware"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
 	"time"
 )
 
@@ -26,12 +28,12 @@ func (r *routes) configAuthRoutes() {
 	mfaGroup.POST("/", handler.AddMfa())
 	mfaGroup.POST("/verify", handler.VerifyMfa())
 	mfaGroup.POST("/remove", handler.RemoveMfa())
-	mfaGroup.POST("/admin/remove", r.authMiddleware.AuthMiddleware(auth.GroupAdmin), handler.AdminRemoveMfa())
+	mfaGroup.POST("/admin/remove", r.authMiddleware.RequirePermission(role.PermissionMFAAdminRemove, oauth.ScopeMFAWrite), handler.AdminRemoveMfa())
 	mfaGroup.POST("/activate", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.ActivateMfa())
 
 	groupsGroup := authGroup.Group("/groups")
-	groupsGroup.POST("/add", r.authMiddleware.AuthMiddleware(auth.GroupAdmin), handler.AddGroup())
-	groupsGroup.POST("/remove", r.authMiddleware.AuthMiddleware(auth.GroupAdmin), handler.RemoveGroup())
+	groupsGroup.POST("/add", r.authMiddleware.RequirePermission(role.PermissionGroupsWrite, oauth.ScopeGroupsWrite), handler.AddGroup())
+	groupsGroup.POST("/remove", r.authMiddleware.RequirePermission(role.PermissionGroupsWrite, oauth.ScopeGroupsWrite), handler.RemoveGroup())
 
 	authenticatedGroup := authGroup.Group("/")
 	authenticatedGroup.Use(r.authMiddleware.AuthMiddleware(auth.GroupAdmin, auth.GroupUser))
@@ -0,0 +1,220 @@
+package routes_test
+
+import (
+	server "auth-api/src/api/gin"
+	"auth-api/src/factory"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	oauth_infra "auth-api/src/internal/modules/user-manager/infra/oauth"
+	role_infra "auth-api/src/internal/modules/user-manager/infra/role"
+	admin_usecases "auth-api/src/internal/modules/user-manager/usecases/admin"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	oauth_usecases "auth-api/src/internal/modules/user-manager/usecases/oauth"
+	organization_usecases "auth-api/src/internal/modules/user-manager/usecases/organization"
+	reconcile_usecases "auth-api/src/internal/modules/user-manager/usecases/reconcile"
+	role_usecases "auth-api/src/internal/modules/user-manager/usecases/role"
+	"auth-api/src/internal/modules/user-manager/usecases/usecasetest"
+	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
+	denylist_infra "auth-api/src/internal/shared/denylist/infra/denylist"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/saga/sagatest"
+	"auth-api/src/pkg/jwt_issuer"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newServer mounts the API with its middlewares and routes on a factory running on the fake Cognito user pool.
+func newServer(t *testing.T) (*gin.Engine, auth.AuthService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	log := usecasetest.NewLogger(t)
+
+	authService, _ := usecasetest.NewAuthService(t, log)
+	key, err := jwt_issuer.LoadPrivateKey("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oauthService := oauth_infra.NewOAuthService(nil, jwt_issuer.NewIssuer("http://localhost", key), time.Hour, log)
+	denylistService := denylist_infra.NewDenylistServiceImpl(denylist_infra.NewDenylistRepositoryMemory(), time.Hour, log)
+	roleService := role_infra.NewRoleServiceImpl(usecasetest.NewRoles(), time.Minute, log)
+	sagaService := sagatest.NewService(sagatest.NewRepository(), saga_infra.Options{}, log)
+
+	f := &factory.Factory{
+		Service: factory.Service{
+			Denylist: denylistService,
+			Saga:     sagaService,
+			UserManager: factory.UserManagerService{
+				Auth:  authService,
+				OAuth: oauthService,
+				Role:  roleService,
+			},
+		},
+		UseCases: factory.UseCases{
+			UserManager: factory.UserManagerUseCases{
+				Auth: auth_usecases.NewUseCases(authService, usecasetest.NewAdmins(), usecasetest.NewUsers(), denylistService, sagaService, log),
+				// The other modules are only registered, their routes are not called.
+				User:         &user_usecases.UseCases{},
+				Admin:        &admin_usecases.UseCases{},
+				OAuth:        &oauth_usecases.UseCases{},
+				Role:         &role_usecases.UseCases{},
+				Organization: &organization_usecases.UseCases{},
+				Export:       &export_usecases.UseCases{},
+				Reconcile:    &reconcile_usecases.UseCases{},
+			},
+		},
+	}
+
+	api := server.New(log, f)
+	api.SetupMiddlewares()
+	if err := api.SetupApi(); err != nil {
+		t.Fatal(err)
+	}
+	return api.Gin, authService
+}
+
+func serve(router *gin.Engine, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// login logs in through the login route and returns the access token the fake Cognito issued.
+func login(t *testing.T, router *gin.Engine, email string) string {
+	t.Helper()
+	rec := serve(router, http.MethodPost, "/api/v1/auth/login", "", `{"email":"`+email+`","password":"`+usecasetest.Password+`"}`)
+	var out auth.LoginOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil || out.AccessToken == nil {
+		t.Fatalf("login %s: status %d: %s", email, rec.Code, rec.Body)
+	}
+	return *out.AccessToken
+}
+
+// signUpAdmin signs up a user of the Admin group, whose role grants the groups:write permission.
+func signUpAdmin(t *testing.T, authService auth.AuthService, email string) {
+	t.Helper()
+	usecasetest.SignUp(t, authService, email, "Ada Admin")
+	if err := authService.AddGroup(context.Background(), auth.AddGroupInput{Username: email, GroupName: auth.GroupAdmin}); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+}
+
+func TestLoginRoute(t *testing.T) {
+	router, authService := newServer(t)
+	usecasetest.SignUp(t, authService, "grace@example.com", "Grace Hopper")
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "valid credentials", body: `{"email":"grace@example.com","password":"` + usecasetest.Password + `"}`, status: http.StatusOK},
+		{name: "wrong password", body: `{"email":"grace@example.com","password":"Wrong-password1"}`, status: http.StatusUnauthorized},
+		{name: "unknown user", body: `{"email":"nobody@example.com","password":"` + usecasetest.Password + `"}`, status: http.StatusUnauthorized},
+		{name: "malformed body", body: `{"email":`, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, http.MethodPost, "/api/v1/auth/login", "", tt.body)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+}
+
+func TestGetMeRouteAuthenticatesTheAccessToken(t *testing.T) {
+	router, authService := newServer(t)
+	usecasetest.SignUp(t, authService, "grace@example.com", "Grace Hopper")
+	token := login(t, router, "grace@example.com")
+
+	rec := serve(router, http.MethodGet, "/api/v1/auth/?accessToken="+token, token, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var me auth.GetMeOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &me); err != nil {
+		t.Fatal(err)
+	}
+	if me.Username != "grace@example.com" || me.Name != "Grace Hopper" {
+		t.Errorf("me = %+v", me)
+	}
+
+	if rec := serve(router, http.MethodGet, "/api/v1/auth/?accessToken="+token, "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("status without a bearer token = %d, want 401", rec.Code)
+	}
+	if rec := serve(router, http.MethodGet, "/api/v1/auth/?accessToken=not-a-token", "not-a-token", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("status with an invalid token = %d, want 401", rec.Code)
+	}
+}
+
+func TestLogoutRouteRevokesTheAccessToken(t *testing.T) {
+	router, authService := newServer(t)
+	usecasetest.SignUp(t, authService, "grace@example.com", "Grace Hopper")
+	token := login(t, router, "grace@example.com")
+
+	rec := serve(router, http.MethodPost, "/api/v1/auth/logout", "", `{"accessToken":"`+token+`"}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("logout status = %d: %s", rec.Code, rec.Body)
+	}
+
+	// The token still verifies, the denylist checked by the middleware refuses it.
+	rec = serve(router, http.MethodGet, "/api/v1/auth/?accessToken="+token, token, "")
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "Token revoked") {
+		t.Errorf("revoked token: status = %d, want 401 Token revoked: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestGroupRoutesRequireTheGroupsPermission(t *testing.T) {
+	router, authService := newServer(t)
+	usecasetest.SignUp(t, authService, "grace@example.com", "Grace Hopper")
+	signUpAdmin(t, authService, "ada@example.com")
+	userToken := login(t, router, "grace@example.com")
+	adminToken := login(t, router, "ada@example.com")
+	body := `{"email":"grace@example.com","name":"Grace Hopper","group":"Admin"}`
+
+	if rec := serve(router, http.MethodPost, "/api/v1/auth/groups/add", "", body); rec.Code != http.StatusUnauthorized {
+		t.Errorf("status without a token = %d, want 401", rec.Code)
+	}
+	if rec := serve(router, http.MethodPost, "/api/v1/auth/groups/add", userToken, body); rec.Code != http.StatusForbidden {
+		t.Errorf("status for a user = %d, want 403: %s", rec.Code, rec.Body)
+	}
+
+	rec := serve(router, http.MethodPost, "/api/v1/auth/groups/add", adminToken, body)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("add status = %d: %s", rec.Code, rec.Body)
+	}
+	if groups, _ := authService.ListUserGroups(context.Background(), auth.ListUserGroupsInput{Username: "grace@example.com"}); len(groups) != 2 {
+		t.Errorf("groups after add = %v, want Admin and User", groups)
+	}
+	// Adding the group signs the user out, the token issued before it is refused.
+	if rec := serve(router, http.MethodGet, "/api/v1/auth/?accessToken="+userToken, userToken, ""); rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "Token revoked") {
+		t.Errorf("token issued before the group change: status = %d, want 401 Token revoked: %s", rec.Code, rec.Body)
+	}
+
+	rec = serve(router, http.MethodPost, "/api/v1/auth/groups/remove", adminToken, `{"email":"grace@example.com","group":"Admin"}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("remove status = %d: %s", rec.Code, rec.Body)
+	}
+	if groups, _ := authService.ListUserGroups(context.Background(), auth.ListUserGroupsInput{Username: "grace@example.com"}); len(groups) != 1 {
+		t.Errorf("groups after remove = %v, want User", groups)
+	}
+
+	rec = serve(router, http.MethodPost, "/api/v1/auth/groups/add", adminToken, `{"email":"grace@example.com","group":"Unknown"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status with an unknown group = %d, want 400: %s", rec.Code, rec.Body)
+	}
+}
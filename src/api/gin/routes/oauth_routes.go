@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"auth-api/src/api/gin/handlers"
+	"auth-api/src/api/gin/middleware"
+	"auth-api/src/factory"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type oauthRoutes struct {
+	gin     *gin.RouterGroup
+	factory *factory.Factory
+}
+
+// NewOAuthRoutes registers the OAuth2/OIDC endpoints, they live at the server root instead of under /api/v1.
+func NewOAuthRoutes(g *gin.RouterGroup, factory *factory.Factory) Routes {
+	return &oauthRoutes{
+		gin:     g,
+		factory: factory,
+	}
+}
+
+func (r *oauthRoutes) ConfigRoutes() {
+	handler := handlers.NewOAuthHandler(r.factory.UseCases.UserManager.OAuth)
+
+	r.gin.GET("/.well-known/openid-configuration", handler.Discovery())
+
+	oauthGroup := r.gin.Group("/oauth2")
+	oauthGroup.Use(middleware.TimeoutMiddleware(30 * time.Second))
+
+	oauthGroup.GET("/jwks", handler.JWKS())
+	oauthGroup.GET("/authorize", handler.Authorize())
+	oauthGroup.POST("/authorize/login", handler.AuthorizeLogin())
+	oauthGroup.POST("/authorize/mfa", handler.AuthorizeMfa())
+	oauthGroup.POST("/token", handler.Token())
+	oauthGroup.POST("/introspect", handler.Introspect())
+	oauthGroup.POST("/revoke", handler.Revoke())
+	oauthGroup.GET("/userinfo", handler.UserInfo())
+	oauthGroup.POST("/userinfo", handler.UserInfo())
+}
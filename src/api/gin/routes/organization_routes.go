@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"auth-api/src/api/gin/handlers"
+	"auth-api/src/api/gin/middleware"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"time"
+)
+
+func (r *routes) configOrganizationRoutes() {
+	handler := handlers.NewOrganizationHandler(r.factory.UseCases.UserManager.Organization)
+
+	adminGroup := r.gin.Group("/admin/organizations")
+	adminGroup.Use(middleware.TimeoutMiddleware(30*time.Second), r.authMiddleware.RequirePermission(organization.PermissionOrgsManage))
+	adminGroup.POST("", handler.Create())
+	adminGroup.GET("", handler.List())
+	adminGroup.DELETE("/:orgId", handler.Delete())
+
+	orgGroup := r.gin.Group("/organizations")
+	orgGroup.Use(middleware.TimeoutMiddleware(30 * time.Second))
+	orgGroup.GET("", r.authMiddleware.AuthMiddleware(auth.GroupAdmin, auth.GroupUser), handler.ListMine())
+	orgGroup.POST("/:orgId/token", r.authMiddleware.AuthMiddleware(auth.GroupAdmin, auth.GroupUser), handler.IssueToken())
+	orgGroup.GET("/:orgId", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersRead), handler.Get())
+	orgGroup.PATCH("/:orgId", r.authMiddleware.RequireOrgPermission(organization.PermissionOrgWrite), handler.Update())
+	orgGroup.GET("/:orgId/members", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersRead), handler.ListMembers())
+	orgGroup.POST("/:orgId/members", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.AddMember())
+	orgGroup.PATCH("/:orgId/members/:userId", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.UpdateMember())
+	orgGroup.DELETE("/:orgId/members/:userId", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.RemoveMember())
+	orgGroup.POST("/:orgId/invitations", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.CreateInvitation())
+	orgGroup.GET("/:orgId/invitations", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersRead), handler.ListInvitations())
+	orgGroup.POST("/:orgId/invitations/:invitationId/resend", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.ResendInvitation())
+	orgGroup.DELETE("/:orgId/invitations/:invitationId", r.authMiddleware.RequireOrgPermission(organization.PermissionMembersWrite), handler.RevokeInvitation())
+
+	invitationsGroup := r.gin.Group("/invitations")
+	invitationsGroup.Use(middleware.TimeoutMiddleware(30 * time.Second))
+	invitationsGroup.GET("", handler.GetInvitation())
+	invitationsGroup.POST("/accept", r.authMiddleware.AuthMiddleware(auth.GroupAdmin, auth.GroupUser), handler.AcceptInvitation())
+	invitationsGroup.POST("/sign-up", handler.AcceptInvitationSignUp())
+}
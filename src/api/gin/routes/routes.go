@@ -29,4 +29,5 @@ func (r *routes) ConfigRoutes() {
 	r.configAuthRoutes()
 	r.configUserRoutes()
 	r.configAdminRoutes()
+	r.configOrganizationRoutes()
 }
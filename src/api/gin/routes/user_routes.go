@@ -14,5 +14,18 @@ func (r *routes) configUserRoutes() {
 
 	userGroup.PATCH("/", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.Update())
 	userGroup.POST("/register", handler.Register())
+	userGroup.DELETE("/", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.DeleteAccount())
+	userGroup.GET("/deletion", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.GetAccountDeletion())
+	userGroup.DELETE("/deletion", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.CancelAccountDeletion())
+	userGroup.POST("/email", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.RequestEmailChange())
+	userGroup.POST("/email/confirm", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.ConfirmEmailChange())
+	userGroup.DELETE("/email", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.CancelEmailChange())
+	userGroup.POST("/phone/verification", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.SendPhoneVerification())
+	userGroup.POST("/phone/verify", r.authMiddleware.AuthMiddleware(auth.GroupUser), handler.VerifyPhone())
+
+	exportHandler := handlers.NewExportHandler(r.factory.UseCases.UserManager.Export)
+	userGroup.POST("/export", r.authMiddleware.AuthMiddleware(auth.GroupUser), exportHandler.Request())
+	userGroup.GET("/export", r.authMiddleware.AuthMiddleware(auth.GroupUser), exportHandler.List())
+	userGroup.GET("/export/:exportId/download", r.authMiddleware.AuthMiddleware(auth.GroupUser), exportHandler.Download())
 
 }
@@ -0,0 +1,76 @@
+package exporter
+
+import (
+	"auth-api/src/factory"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Command is the name of the subcommand running the export.
+const Command = "export-users"
+
+// Run writes the users matching the filters to --output, or to the standard output.
+func Run(ctx context.Context, args []string, factory *factory.Factory) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	output := flags.String("output", "", "file to write, the standard output by default")
+	format := flags.String("format", string(user_usecases.UserExportCSV), "file format, csv or ndjson")
+	fields := flags.String("fields", "", "comma separated fields, every field by default")
+	search := flags.String("q", "", "search on email and name")
+	status := flags.String("status", "", "auth provider status")
+	group := flags.String("group", "", "auth provider group")
+	createdAfter := flags.String("created-after", "", "RFC 3339 timestamp")
+	createdBefore := flags.String("created-before", "", "RFC 3339 timestamp")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	input := user_usecases.ExportUsersInput{
+		Search: *search,
+		Status: auth.UserStatus(*status),
+		Group:  auth.UserGroup(*group),
+		Format: user_usecases.UserExportFormat(*format),
+	}
+	if *fields != "" {
+		input.Fields = strings.Split(*fields, ",")
+	}
+	var err error
+	if input.CreatedAfter, err = parseTime(*createdAfter); err != nil {
+		return err
+	}
+	if input.CreatedBefore, err = parseTime(*createdBefore); err != nil {
+		return err
+	}
+
+	export, err := factory.UseCases.UserManager.User.Export.Execute(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return export.WriteTo(ctx, w)
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"auth-api/src/factory"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
+	"auth-api/src/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Command is the name of the subcommand running the import.
+const Command = "import-users"
+
+// Run imports the users of a file and prints the JSON report, the CLI is not bound by the import row limit.
+func Run(ctx context.Context, args []string, factory *factory.Factory, logger logger.Logger) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	file := flags.String("file", "", "CSV or JSON lines file to import")
+	format := flags.String("format", string(user.ImportCSV), "file format, csv or jsonl")
+	dryRun := flags.Bool("dry-run", false, "validate the rows without creating the users")
+	invite := flags.Bool("invite", false, "send an invitation email to the created users")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("the --file flag is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := factory.UseCases.UserManager.User.Import.Execute(ctx, user_usecases.ImportUsersInput{
+		File:            f,
+		Format:          user.ImportFormat(*format),
+		DryRun:          *dryRun,
+		SendInvitations: *invite,
+		MaxRows:         -1,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"auth-api/src/cmd/exporter"
+	"auth-api/src/cmd/importer"
+	"auth-api/src/cmd/migrator"
+	"auth-api/src/cmd/reconciler"
 	"auth-api/src/cmd/server"
 	"auth-api/src/config"
 	"auth-api/src/factory"
@@ -55,6 +59,21 @@ func main() {
 		return
 	}
 
+	// Migrations run before the factory, which expects the tables to exist.
+	if len(os.Args) > 1 && os.Args[1] == migrator.Command {
+		if err := migrator.Run(ctx, os.Args[2:], db, logger); err != nil {
+			logger.Error("Error running %s %v", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+	if appConfig.Sql.AutoMigrate {
+		if err := migrator.AutoMigrate(ctx, db, logger); err != nil {
+			logger.Error("Error migrating database %v", err)
+			return
+		}
+	}
+
 	awsConfig, err := config.LoadAwsConfig(ctx, appConfig.Aws, logger)
 	if err != nil {
 		logger.Error("Error loading AWS configuration %v", err)
@@ -67,6 +86,16 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 {
+		if ran, err := runCommand(ctx, os.Args[1], os.Args[2:], factory, logger); ran {
+			if err != nil {
+				logger.Error("Error running %s %v", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	server := server.New(ctx, awsConfig, appConfig, logger, factory)
 
 	var wg sync.WaitGroup
@@ -83,3 +112,16 @@ func main() {
 	<-ctx.Done()
 	os.Exit(0)
 }
+
+// runCommand runs the subcommand named by the first argument, ran is false when there is no such command.
+func runCommand(ctx context.Context, command string, args []string, factory *factory.Factory, logger logger.Logger) (ran bool, err error) {
+	switch command {
+	case importer.Command:
+		return true, importer.Run(ctx, args, factory, logger)
+	case exporter.Command:
+		return true, exporter.Run(ctx, args, factory)
+	case reconciler.Command:
+		return true, reconciler.Run(ctx, args, factory)
+	}
+	return false, nil
+}
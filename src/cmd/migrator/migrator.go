@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"auth-api/src/migrations"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/migrate"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Command is the name of the subcommand running the migrations.
+const Command = "migrate"
+
+// Run applies (up, the default), reverts (down) or lists (status) the embedded migrations.
+func Run(ctx context.Context, args []string, db *sql.DB, logger logger.Logger) error {
+	action := "up"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		action, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet(Command+" "+action, flag.ContinueOnError)
+	target := flags.Int64("to", 0, "up: last version to apply, every pending migration by default")
+	steps := flags.Int("steps", 1, "down: number of migrations to revert")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	migrator, err := migrate.New(db, migrations.Files, logger)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		count, err := migrator.Up(ctx, *target)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", count)
+	case "down":
+		count, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migrations\n", count)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(statuses)
+	default:
+		return fmt.Errorf("unknown migrate action %q, expected up, down or status", action)
+	}
+	return nil
+}
+
+// AutoMigrate applies the pending migrations on startup.
+func AutoMigrate(ctx context.Context, db *sql.DB, logger logger.Logger) error {
+	migrator, err := migrate.New(db, migrations.Files, logger)
+	if err != nil {
+		return err
+	}
+	count, err := migrator.Up(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		logger.Info("Applied %d migrations", count)
+	}
+	return nil
+}
+
+func printStatus(statuses []migrate.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if status.Unknown {
+			state = "unknown"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,33 @@
+package reconciler
+
+import (
+	"auth-api/src/factory"
+	reconcile_usecases "auth-api/src/internal/modules/user-manager/usecases/reconcile"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+)
+
+// Command is the name of the subcommand running the reconciliation.
+const Command = "reconcile"
+
+// Run reconciles the auth provider and the profiles and prints the JSON report, issues are only repaired with --repair.
+func Run(ctx context.Context, args []string, factory *factory.Factory) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "repair the issues instead of only reporting them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := factory.UseCases.UserManager.Reconcile.Reconcile.Execute(ctx, reconcile_usecases.ReconcileInput{
+		DryRun: !*repair,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -2,10 +2,24 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	AuthProviderCognito     = "cognito"
+	AuthProviderCognitoFake = "cognito_fake"
+	AuthProviderLocal       = "local"
+
+	DenylistStoreMemory   = "memory"
+	DenylistStorePostgres = "postgres"
+	DenylistStoreDynamoDB = "dynamodb"
+
+	SmsProviderConsole = "console"
+	SmsProviderFile    = "file"
+)
+
 type AwsConfig struct {
 	Region            string `mapstructure:"region"`
 	CognitoClientId   string `mapstructure:"cognito_client_id"`
@@ -24,13 +38,148 @@ type SQLDatabaseConfig struct {
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	Database string `mapstructure:"database"`
+	// AutoMigrate applies the pending migrations on startup.
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+}
+
+type LocalAuthConfig struct {
+	Issuer          string        `mapstructure:"issuer"`
+	Audience        string        `mapstructure:"audience"`
+	SigningKeyPath  string        `mapstructure:"signing_key_path"`
+	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
+}
+
+type JWKSConfig struct {
+	RefreshInterval    time.Duration `mapstructure:"refresh_interval"`
+	MinRefetchInterval time.Duration `mapstructure:"min_refetch_interval"`
+}
+
+type TokenValidationConfig struct {
+	// AllowedClientIds are accepted besides aws.cognito_client_id.
+	AllowedClientIds []string      `mapstructure:"allowed_client_ids"`
+	Leeway           time.Duration `mapstructure:"leeway"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+type AuthConfig struct {
+	Provider        string                `mapstructure:"provider"`
+	Local           LocalAuthConfig       `mapstructure:"local"`
+	JWKS            JWKSConfig            `mapstructure:"jwks"`
+	TokenValidation TokenValidationConfig `mapstructure:"token_validation"`
+}
+
+type OAuthConfig struct {
+	Issuer               string        `mapstructure:"issuer"`
+	SigningKeyPath       string        `mapstructure:"signing_key_path"`
+	LoginPageURL         string        `mapstructure:"login_page_url"`
+	AuthorizationCodeTTL time.Duration `mapstructure:"authorization_code_ttl"`
+	IdTokenTTL           time.Duration `mapstructure:"id_token_ttl"`
+	// RefreshTokenTTL should match the refresh token lifetime of the auth provider.
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
+}
+
+type DenylistConfig struct {
+	Store string `mapstructure:"store"`
+	// Table is the DynamoDB table used by the dynamodb store.
+	Table string `mapstructure:"table"`
+	// TokenTTL must cover the longest access token lifetime.
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+}
+
+type InvitationsConfig struct {
+	// SigningSecret signs the invitation links, at least 32 bytes shared by every instance.
+	SigningSecret string        `mapstructure:"signing_secret"`
+	TTL           time.Duration `mapstructure:"ttl"`
+	// AcceptURL is the page invitation links point to, it receives the token as a query parameter.
+	AcceptURL string `mapstructure:"accept_url"`
+}
+
+type RolesConfig struct {
+	// CacheTTL bounds how long a role change takes to reach the permission checks of other instances.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+type AccountDeletionConfig struct {
+	// GracePeriod delays self-service deletions so they can be cancelled, 0 deletes right away.
+	GracePeriod   time.Duration `mapstructure:"grace_period"`
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+type ReconciliationConfig struct {
+	// Interval runs the reconciliation periodically, 0 disables it.
+	Interval time.Duration `mapstructure:"interval"`
+	// Repair fixes the issues found by the periodic runs instead of only reporting them.
+	Repair bool `mapstructure:"repair"`
+	// GracePeriod leaves alone the auth provider users created since, whose registration may still be running.
+	GracePeriod time.Duration `mapstructure:"grace_period"`
+}
+
+type SagasConfig struct {
+	// Lease is how long a saga stays locked by the instance running it.
+	Lease time.Duration `mapstructure:"lease"`
+	// RetryDelay is the first delay before the worker retries a saga, it doubles after each attempt.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+	// MaxAttempts marks a saga as failed once the worker retried it that many times.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// BatchSize is the number of sagas the worker runs per tick.
+	BatchSize int `mapstructure:"batch_size"`
+	// Retention is how long the finished sagas are kept, 0 keeps them.
+	Retention time.Duration `mapstructure:"retention"`
+	// WorkerInterval is how often the worker resumes the interrupted sagas, 0 disables it.
+	WorkerInterval time.Duration `mapstructure:"worker_interval"`
+}
+
+type UserImportConfig struct {
+	// BatchSize is the number of users created concurrently by an import.
+	BatchSize int `mapstructure:"batch_size"`
+	// PasswordResetURL is linked from the invitation emails of imported users.
+	PasswordResetURL string `mapstructure:"password_reset_url"`
+	// MaxRows limits the rows of an import, the CLI can override it.
+	MaxRows int `mapstructure:"max_rows"`
+	// MaxBytes limits the request body of an import, the CLI reads files of any size.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+}
+
+type DataExportsConfig struct {
+	// TTL is how long a generated export can be downloaded.
+	TTL time.Duration `mapstructure:"ttl"`
+	// SweepInterval is how often the exports whose generation stopped are retried, 0 disables it.
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+type SmsConfig struct {
+	Provider string `mapstructure:"provider"`
+	// FilePath is where the file provider appends the messages.
+	FilePath string `mapstructure:"file_path"`
+}
+
+// ProfileAttributeConfig defines a custom profile attribute, Type is string, number or boolean.
+type ProfileAttributeConfig struct {
+	Name      string `mapstructure:"name"`
+	Type      string `mapstructure:"type"`
+	Required  bool   `mapstructure:"required"`
+	Pattern   string `mapstructure:"pattern"`
+	AdminOnly bool   `mapstructure:"admin_only"`
 }
 
 type Config struct {
-	Aws AwsConfig         `mapstructure:"aws"`
-	Api ApiConfig         `mapstructure:"api"`
-	Sql SQLDatabaseConfig `mapstructure:"sql"`
-	Env string            `mapstructure:"env"`
+	Aws               AwsConfig                `mapstructure:"aws"`
+	Api               ApiConfig                `mapstructure:"api"`
+	Sql               SQLDatabaseConfig        `mapstructure:"sql"`
+	Auth              AuthConfig               `mapstructure:"auth"`
+	OAuth             OAuthConfig              `mapstructure:"oauth"`
+	Denylist          DenylistConfig           `mapstructure:"denylist"`
+	Roles             RolesConfig              `mapstructure:"roles"`
+	Invitations       InvitationsConfig        `mapstructure:"invitations"`
+	AccountDeletion   AccountDeletionConfig    `mapstructure:"account_deletion"`
+	DataExports       DataExportsConfig        `mapstructure:"data_exports"`
+	Sms               SmsConfig                `mapstructure:"sms"`
+	ProfileAttributes []ProfileAttributeConfig `mapstructure:"profile_attributes"`
+	UserImport        UserImportConfig         `mapstructure:"user_import"`
+	Reconciliation    ReconciliationConfig     `mapstructure:"reconciliation"`
+	Sagas             SagasConfig              `mapstructure:"sagas"`
+	Env               string                   `mapstructure:"env"`
 }
 
 func setDefaults() {
@@ -46,9 +195,63 @@ func setDefaults() {
 	viper.SetDefault("sql.user", "SET_ME")
 	viper.SetDefault("sql.password", "SET_ME")
 	viper.SetDefault("sql.database", "SET_ME")
+	viper.SetDefault("sql.auto_migrate", false)
 
 	viper.SetDefault("api.host", "0.0.0.0")
 	viper.SetDefault("api.port", 4000)
+
+	viper.SetDefault("auth.provider", AuthProviderCognito)
+	viper.SetDefault("auth.local.issuer", "http://localhost:4000")
+	viper.SetDefault("auth.local.audience", "auth-api")
+	viper.SetDefault("auth.local.signing_key_path", "")
+	viper.SetDefault("auth.local.access_token_ttl", "1h")
+	viper.SetDefault("auth.local.refresh_token_ttl", "720h")
+	viper.SetDefault("auth.jwks.refresh_interval", "1h")
+	viper.SetDefault("auth.jwks.min_refetch_interval", "1m")
+	viper.SetDefault("auth.token_validation.allowed_client_ids", []string{})
+	viper.SetDefault("auth.token_validation.leeway", "30s")
+	viper.SetDefault("auth.token_validation.max_age", "0s")
+
+	viper.SetDefault("oauth.issuer", "http://localhost:4000")
+	viper.SetDefault("oauth.signing_key_path", "")
+	viper.SetDefault("oauth.login_page_url", "/web/login.html")
+	viper.SetDefault("oauth.authorization_code_ttl", "5m")
+	viper.SetDefault("oauth.id_token_ttl", "1h")
+	viper.SetDefault("oauth.refresh_token_ttl", "720h")
+
+	viper.SetDefault("denylist.store", DenylistStorePostgres)
+	viper.SetDefault("denylist.table", "SET_ME")
+	viper.SetDefault("denylist.token_ttl", "24h")
+
+	viper.SetDefault("roles.cache_ttl", "30s")
+
+	viper.SetDefault("invitations.signing_secret", "SET_ME")
+	viper.SetDefault("invitations.ttl", "168h")
+	viper.SetDefault("invitations.accept_url", "SET_ME")
+
+	viper.SetDefault("account_deletion.grace_period", "0s")
+	viper.SetDefault("account_deletion.sweep_interval", "1m")
+
+	viper.SetDefault("data_exports.ttl", "168h")
+	viper.SetDefault("data_exports.sweep_interval", "1m")
+
+	viper.SetDefault("user_import.batch_size", 25)
+	viper.SetDefault("user_import.max_rows", 5000)
+	viper.SetDefault("user_import.max_bytes", 10<<20)
+
+	viper.SetDefault("reconciliation.interval", "0s")
+	viper.SetDefault("reconciliation.repair", false)
+	viper.SetDefault("reconciliation.grace_period", "15m")
+
+	viper.SetDefault("sagas.lease", "5m")
+	viper.SetDefault("sagas.retry_delay", "30s")
+	viper.SetDefault("sagas.max_attempts", 10)
+	viper.SetDefault("sagas.batch_size", 20)
+	viper.SetDefault("sagas.retention", "720h")
+	viper.SetDefault("sagas.worker_interval", "10s")
+
+	viper.SetDefault("sms.provider", SmsProviderConsole)
+	viper.SetDefault("sms.file_path", "sms.log")
 }
 
 func LoadConfig(configPath string) (*Config, error) {
@@ -1,27 +1,54 @@
 package factory
 
 import (
-	"auth-api/src/config"
+	appConfig "auth-api/src/config"
 	"auth-api/src/internal/events"
 	eventsIplm "auth-api/src/internal/events"
 	events_handlers "auth-api/src/internal/events/handlers"
 	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/principal"
+	"auth-api/src/internal/modules/user-manager/domain/role"
 	"auth-api/src/internal/modules/user-manager/domain/user"
 	admin_infra "auth-api/src/internal/modules/user-manager/infra/admin"
 	auth_infra "auth-api/src/internal/modules/user-manager/infra/auth"
+	"auth-api/src/internal/modules/user-manager/infra/auth/cognito_fake"
+	export_infra "auth-api/src/internal/modules/user-manager/infra/export"
+	oauth_infra "auth-api/src/internal/modules/user-manager/infra/oauth"
+	organization_infra "auth-api/src/internal/modules/user-manager/infra/organization"
+	principal_infra "auth-api/src/internal/modules/user-manager/infra/principal"
+	role_infra "auth-api/src/internal/modules/user-manager/infra/role"
 	user_infra "auth-api/src/internal/modules/user-manager/infra/user"
 	admin_usecases "auth-api/src/internal/modules/user-manager/usecases/admin"
 	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	oauth_usecases "auth-api/src/internal/modules/user-manager/usecases/oauth"
+	organization_usecases "auth-api/src/internal/modules/user-manager/usecases/organization"
+	reconcile_usecases "auth-api/src/internal/modules/user-manager/usecases/reconcile"
+	role_usecases "auth-api/src/internal/modules/user-manager/usecases/role"
 	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
 	"auth-api/src/internal/shared/code/domain/code"
 	code_infra "auth-api/src/internal/shared/code/infra/code"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	denylist_infra "auth-api/src/internal/shared/denylist/infra/denylist"
 	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/internal/shared/notification/domain/sms"
 	email_infra "auth-api/src/internal/shared/notification/infra/email"
+	sms_infra "auth-api/src/internal/shared/notification/infra/sms"
+	"auth-api/src/internal/shared/saga/domain/saga"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/jwt_issuer"
 	"auth-api/src/pkg/jwt_verify"
 	"auth-api/src/pkg/logger"
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
@@ -38,98 +65,422 @@ type Factory struct {
 
 type Service struct {
 	Code        code.CodeService
+	Denylist    denylist.DenylistService
 	Email       email.EmailService
+	Sms         sms.SmsService
+	Saga        saga.SagaService
+	Transaction transaction.UnitOfWork
 	UserManager UserManagerService
 }
 
 type Repository struct {
 	UserManager UserManagerRepo
 	Code        code.CodeRepository
+	Denylist    denylist.DenylistRepository
+	Saga        saga.SagaRepository
 }
 
 type UserManagerService struct {
-	Auth  auth.AuthService
-	User  user.UserService
-	Admin admin.AdminService
+	Auth         auth.AuthService
+	User         user.UserService
+	Admin        admin.AdminService
+	OAuth        oauth.OAuthService
+	Role         role.RoleService
+	Organization organization.OrganizationService
 }
 
 type UserManagerRepo struct {
-	User  user.UserRepository
-	Admin admin.AdminRepository
+	Principal         principal.PrincipalRepository
+	OAuthClient       oauth.ClientRepository
+	AuthorizationCode oauth.AuthorizationCodeRepository
+	RefreshToken      oauth.RefreshTokenRepository
+	Role              role.RoleRepository
+	Organization      organization.OrganizationRepository
+	Member            organization.MemberRepository
+	Invitation        organization.InvitationRepository
+	Deletion          user.DeletionRepository
+	EmailChange       user.EmailChangeRepository
+	PhoneVerification user.PhoneVerificationRepository
+	Export            export.ExportRepository
 }
 
 type UserManagerUseCases struct {
-	Auth  *auth_usecases.UseCases
-	User  *user_usecases.UseCases
-	Admin *admin_usecases.UseCases
+	Auth         *auth_usecases.UseCases
+	User         *user_usecases.UseCases
+	Admin        *admin_usecases.UseCases
+	OAuth        *oauth_usecases.UseCases
+	Role         *role_usecases.UseCases
+	Organization *organization_usecases.UseCases
+	Export       *export_usecases.UseCases
+	Reconcile    *reconcile_usecases.UseCases
 }
 
 type UseCases struct {
 	UserManager UserManagerUseCases
 }
 
-func newAuthService(logger logger.Logger, awsConfig *aws.Config, config config.Config, email email.EmailService, codeService code.CodeService) auth.AuthService {
-	cognitoClient := cognitoidentityprovider.NewFromConfig(*awsConfig)
-	jwtVerify := jwt_verify.NewAuth(config.Aws.Region, config.Aws.CognitoUserPoolID, logger)
-	jwtVerify.CacheJWK() //TODO: Check when we need to cache the JWK and how to handle the error
-	return auth_infra.NewAuthService(cognitoClient, config.Aws.CognitoClientId, jwtVerify, config.Aws.CognitoUserPoolID, logger, email, codeService)
+func newAuthService(ctx context.Context, logger logger.Logger, awsConfig *aws.Config, config appConfig.Config, db *sql.DB, email email.EmailService, codeService code.CodeService) (auth.AuthService, error) {
+	switch config.Auth.Provider {
+	case appConfig.AuthProviderLocal:
+		return newLocalAuthService(logger, config.Auth.Local, config.Auth.TokenValidation, db, email, codeService)
+	case appConfig.AuthProviderCognito:
+		cognitoClient := cognitoidentityprovider.NewFromConfig(*awsConfig)
+		jwtVerify := jwt_verify.NewAuthWithOptions(config.Aws.Region, config.Aws.CognitoUserPoolID, jwt_verify.Options{
+			RefreshInterval:    config.Auth.JWKS.RefreshInterval,
+			MinRefetchInterval: config.Auth.JWKS.MinRefetchInterval,
+			Rules:              cognitoValidationRules(config),
+		}, logger)
+		if err := jwtVerify.CacheJWK(); err != nil {
+			return nil, fmt.Errorf("error fetching the Cognito JWKS: %w", err)
+		}
+		jwtVerify.StartRefresh(ctx)
+		return auth_infra.NewAuthService(cognitoClient, config.Aws.CognitoClientId, jwtVerify, config.Aws.CognitoUserPoolID, logger, email, codeService), nil
+	case appConfig.AuthProviderCognitoFake:
+		logger.Warning("Using the in-memory fake Cognito user pool, data is lost on restart")
+		fake, err := cognito_fake.New(config.Aws.Region, config.Aws.CognitoUserPoolID, config.Aws.CognitoClientId, logger)
+		if err != nil {
+			return nil, err
+		}
+		jwtVerify := jwt_verify.NewAuthWithJWK(fake.JWK(), cognitoValidationRules(config), logger)
+		return auth_infra.NewAuthService(fake, config.Aws.CognitoClientId, jwtVerify, config.Aws.CognitoUserPoolID, logger, email, codeService), nil
+	default:
+		return nil, fmt.Errorf("unknown auth provider %q", config.Auth.Provider)
+	}
 }
 
-func newCodeRepository(awsConfig aws.Config, logger logger.Logger, config config.Config) code.CodeRepository {
+// cognitoValidationRules only accepts access tokens issued by the user pool to the configured app clients.
+func cognitoValidationRules(config appConfig.Config) jwt_verify.ValidationRules {
+	validation := config.Auth.TokenValidation
+	return jwt_verify.ValidationRules{
+		Issuer:    fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", config.Aws.Region, config.Aws.CognitoUserPoolID),
+		ClientIDs: append([]string{config.Aws.CognitoClientId}, validation.AllowedClientIds...),
+		TokenUse:  "access",
+		Leeway:    validation.Leeway,
+		MaxAge:    validation.MaxAge,
+	}
+}
+
+func newLocalAuthService(logger logger.Logger, localConfig appConfig.LocalAuthConfig, validation appConfig.TokenValidationConfig, db *sql.DB, email email.EmailService, codeService code.CodeService) (auth.AuthService, error) {
+	if localConfig.SigningKeyPath == "" {
+		logger.Warning("No signing key configured for the local auth provider, using an ephemeral key")
+	}
+	key, err := jwt_issuer.LoadPrivateKey(localConfig.SigningKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := jwt_issuer.NewIssuer(localConfig.Issuer, key)
+	jwtVerify := jwt_verify.NewAuthWithJWK(issuer.JWK(), jwt_verify.ValidationRules{
+		Issuer:   issuer.Issuer(),
+		TokenUse: "access",
+		Leeway:   validation.Leeway,
+		MaxAge:   validation.MaxAge,
+	}, logger)
+
+	return auth_infra.NewLocalAuthService(db, issuer, jwtVerify, auth_infra.LocalAuthOptions{
+		Audience:        localConfig.Audience,
+		AccessTokenTTL:  localConfig.AccessTokenTTL,
+		RefreshTokenTTL: localConfig.RefreshTokenTTL,
+	}, logger, email, codeService), nil
+}
+
+func newOAuthService(logger logger.Logger, oauthConfig appConfig.OAuthConfig, clients oauth.ClientRepository) (oauth.OAuthService, error) {
+	if oauthConfig.SigningKeyPath == "" {
+		logger.Warning("No signing key configured for the OAuth provider, using an ephemeral key")
+	}
+	key, err := jwt_issuer.LoadPrivateKey(oauthConfig.SigningKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth_infra.NewOAuthService(clients, jwt_issuer.NewIssuer(oauthConfig.Issuer, key), oauthConfig.IdTokenTTL, logger), nil
+}
+
+func newCodeRepository(awsConfig aws.Config, logger logger.Logger, config appConfig.Config) code.CodeRepository {
 	dynamoDBClient := dynamodb.NewFromConfig(awsConfig)
 	return code_infra.NewCodeRepositoryDynamoDB(config.Aws.CodesTable, dynamoDBClient, logger)
 }
 
+func newDenylistRepository(awsConfig aws.Config, logger logger.Logger, config appConfig.Config, db *sql.DB) (denylist.DenylistRepository, error) {
+	switch config.Denylist.Store {
+	case appConfig.DenylistStoreMemory:
+		logger.Warning("Using the in-memory token denylist, revocations are lost on restart and not shared between instances")
+		return denylist_infra.NewDenylistRepositoryMemory(), nil
+	case appConfig.DenylistStorePostgres:
+		return denylist_infra.NewDenylistRepositoryPostgres(db, logger), nil
+	case appConfig.DenylistStoreDynamoDB:
+		dynamoDBClient := dynamodb.NewFromConfig(awsConfig)
+		return denylist_infra.NewDenylistRepositoryDynamoDB(config.Denylist.Table, dynamoDBClient, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown denylist store %q", config.Denylist.Store)
+	}
+}
+
 func newEmailService(awsConfig aws.Config, logger logger.Logger) email.EmailService {
 	sesClient := ses.NewFromConfig(awsConfig)
 	return email_infra.NewEmailService(sesClient, logger)
 }
 
-func New(ctx context.Context, logger logger.Logger, awsConfig aws.Config, config config.Config, db *sql.DB) (*Factory, error) {
-	userRepo := user_infra.NewUserRepository(db, logger)
-	adminRepo := admin_infra.NewAdminRepository(db, logger)
+func newSmsService(logger logger.Logger, smsConfig appConfig.SmsConfig) (sms.SmsService, error) {
+	switch smsConfig.Provider {
+	case appConfig.SmsProviderConsole:
+		logger.Warning("Using the console SMS provider, messages are logged instead of sent")
+		return sms_infra.NewConsoleSmsService(logger), nil
+	case appConfig.SmsProviderFile:
+		logger.Warning("Using the file SMS provider, messages are written to %s instead of sent", smsConfig.FilePath)
+		return sms_infra.NewFileSmsService(smsConfig.FilePath, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %q", smsConfig.Provider)
+	}
+}
+
+func newAttributeSchema(attributes []appConfig.ProfileAttributeConfig) (*user.AttributeSchema, error) {
+	definitions := make([]user.AttributeDefinition, 0, len(attributes))
+	for _, attribute := range attributes {
+		definitions = append(definitions, user.AttributeDefinition{
+			Name:      attribute.Name,
+			Type:      user.AttributeType(attribute.Type),
+			Required:  attribute.Required,
+			Pattern:   attribute.Pattern,
+			AdminOnly: attribute.AdminOnly,
+		})
+	}
+
+	schema, err := user.NewAttributeSchema(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the profile attributes: %w", err)
+	}
+	return schema, nil
+}
+
+func New(ctx context.Context, logger logger.Logger, awsConfig aws.Config, config appConfig.Config, db *sql.DB) (*Factory, error) {
+	transactions := transaction_infra.NewUnitOfWork(db, logger)
+	locker := transaction_infra.NewLocker(db, logger)
+
+	principalRepo := principal_infra.NewPrincipalRepository(db, transactions, logger)
+	oauthClientRepo := oauth_infra.NewClientRepository(db, logger)
+	authorizationCodeRepo := oauth_infra.NewAuthorizationCodeRepository(db, logger)
+	refreshTokenRepo := oauth_infra.NewRefreshTokenRepository(db, logger)
+	roleRepo := role_infra.NewRoleRepository(db, logger)
+	organizationRepo := organization_infra.NewOrganizationRepository(db, logger)
+	memberRepo := organization_infra.NewMemberRepository(db, logger)
+	invitationRepo := organization_infra.NewInvitationRepository(db, logger)
+	deletionRepo := user_infra.NewDeletionRepository(db, logger)
+	emailChangeRepo := user_infra.NewEmailChangeRepository(db, logger)
+	phoneVerificationRepo := user_infra.NewPhoneVerificationRepository(db, logger)
+	exportRepo := export_infra.NewExportRepository(db, logger)
+	sagaRepo := saga_infra.NewSagaRepository(db, transactions, logger)
 	codeRepo := newCodeRepository(awsConfig, logger, config)
+	denylistRepo, err := newDenylistRepository(awsConfig, logger, config, db)
+	if err != nil {
+		return nil, err
+	}
 
 	codeService := code_infra.NewCodeServiceImpl(codeRepo, logger)
+	denylistService := denylist_infra.NewDenylistServiceImpl(denylistRepo, config.Denylist.TokenTTL, logger)
 	emailService := newEmailService(awsConfig, logger)
+	smsService, err := newSmsService(logger, config.Sms)
+	if err != nil {
+		return nil, err
+	}
+	sagaService := saga_infra.NewSagaService(sagaRepo, transactions, locker, saga_infra.Options{
+		Lease:       config.Sagas.Lease,
+		RetryDelay:  config.Sagas.RetryDelay,
+		MaxAttempts: config.Sagas.MaxAttempts,
+		BatchSize:   config.Sagas.BatchSize,
+		Retention:   config.Sagas.Retention,
+	}, logger)
 
-	authService := newAuthService(logger, &awsConfig, config, emailService, codeService)
-	userService := user_infra.NewUserService(userRepo)
-	adminService := admin_infra.NewAdminService(adminRepo, logger)
+	authService, err := newAuthService(ctx, logger, &awsConfig, config, db, emailService, codeService)
+	if err != nil {
+		return nil, err
+	}
+	userService := user_infra.NewUserService(principalRepo)
+	adminService := admin_infra.NewAdminService(principalRepo, logger)
+	oauthService, err := newOAuthService(logger, config.OAuth, oauthClientRepo)
+	if err != nil {
+		return nil, err
+	}
+	roleService := role_infra.NewRoleServiceImpl(roleRepo, config.Roles.CacheTTL, logger)
+	organizationService := organization_infra.NewOrganizationServiceImpl(memberRepo, roleService, logger)
+	invitationSigner, err := organization_infra.NewInvitationSigner(config.Invitations.SigningSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeSchema, err := newAttributeSchema(config.ProfileAttributes)
+	if err != nil {
+		return nil, err
+	}
 
 	dispatcher := eventsIplm.NewEventDispatcher(logger)
 
-	authUseCases := auth_usecases.NewUseCases(authService, adminService, userService, logger)
-	adminUseCases := admin_usecases.NewUseCases(adminService, authService, logger)
-	userUseCases := user_usecases.NewUseCases(userService, authService, logger, dispatcher)
+	authUseCases := auth_usecases.NewUseCases(authService, adminService, userService, denylistService, sagaService, logger)
+	adminUseCases := admin_usecases.NewUseCases(adminService, authService, sagaService, logger)
+	userUseCases := user_usecases.NewUseCases(userService, adminService, authService, denylistService, codeService, smsService, emailService, sagaService, roleService, memberRepo, transactions, deletionRepo, emailChangeRepo, phoneVerificationRepo, user_usecases.Options{
+		DeletionGracePeriod: config.AccountDeletion.GracePeriod,
+		AttributeSchema:     attributeSchema,
+		Import: user_usecases.ImportOptions{
+			BatchSize:        config.UserImport.BatchSize,
+			PasswordResetURL: config.UserImport.PasswordResetURL,
+			MaxRows:          config.UserImport.MaxRows,
+			MaxBytes:         config.UserImport.MaxBytes,
+		},
+	}, logger, dispatcher)
+	oauthUseCases := oauth_usecases.NewUseCases(authUseCases, authService, oauthService, oauthClientRepo, authorizationCodeRepo, refreshTokenRepo, denylistService, oauth_usecases.Options{
+		LoginPageURL:         config.OAuth.LoginPageURL,
+		AuthorizationCodeTTL: config.OAuth.AuthorizationCodeTTL,
+		RefreshTokenTTL:      config.OAuth.RefreshTokenTTL,
+	}, logger)
+	roleUseCases := role_usecases.NewUseCases(roleRepo, roleService, authService, denylistService, logger)
+	organizationUseCases := organization_usecases.NewUseCases(userUseCases, organizationRepo, memberRepo, invitationRepo, roleRepo, authService, invitationSigner, emailService, transactions, organization_usecases.Options{
+		InvitationTTL: config.Invitations.TTL,
+		InvitationURL: config.Invitations.AcceptURL,
+	}, logger)
+
+	exportUseCases := export_usecases.NewUseCases(exportRepo, userService, authService, memberRepo, emailService, dispatcher, export_usecases.Options{
+		TTL: config.DataExports.TTL,
+	}, logger)
 
-	handlers := events_handlers.NewEventsHandlers(logger, *authUseCases)
+	reconcileUseCases := reconcile_usecases.NewUseCases(authService, userService, adminService, locker, reconcile_usecases.Options{
+		GracePeriod: config.Reconciliation.GracePeriod,
+	}, logger)
+
+	handlers := events_handlers.NewEventsHandlers(logger, *authUseCases, memberRepo, emailService, *exportUseCases, exportRepo)
 	handlers.RegisterHandlers(dispatcher)
 
+	if config.AccountDeletion.GracePeriod > 0 {
+		go runAccountDeletions(ctx, userUseCases.ProcessAccountDeletions, config.AccountDeletion.SweepInterval, logger)
+	}
+	if config.DataExports.SweepInterval > 0 {
+		go runDataExports(ctx, exportUseCases.RetryStale, config.DataExports.SweepInterval, logger)
+	}
+	if config.Reconciliation.Interval > 0 {
+		go runReconciliation(ctx, reconcileUseCases.Reconcile, config.Reconciliation, logger)
+	}
+	if config.Sagas.WorkerInterval > 0 {
+		go runSagas(ctx, sagaService, config.Sagas.WorkerInterval, logger)
+	}
+
 	return &Factory{
 		Repository: Repository{
 			UserManager: UserManagerRepo{
-				User:  userRepo,
-				Admin: adminRepo,
+				Principal:         principalRepo,
+				OAuthClient:       oauthClientRepo,
+				AuthorizationCode: authorizationCodeRepo,
+				RefreshToken:      refreshTokenRepo,
+				Role:              roleRepo,
+				Organization:      organizationRepo,
+				Member:            memberRepo,
+				Invitation:        invitationRepo,
+				Deletion:          deletionRepo,
+				EmailChange:       emailChangeRepo,
+				PhoneVerification: phoneVerificationRepo,
+				Export:            exportRepo,
 			},
-			Code: codeRepo,
+			Code:     codeRepo,
+			Denylist: denylistRepo,
+			Saga:     sagaRepo,
 		},
 		Service: Service{
 			UserManager: UserManagerService{
-				Auth:  authService,
-				User:  userService,
-				Admin: adminService,
+				Auth:         authService,
+				User:         userService,
+				Admin:        adminService,
+				OAuth:        oauthService,
+				Role:         roleService,
+				Organization: organizationService,
 			},
-			Code:  codeService,
-			Email: emailService,
+			Code:        codeService,
+			Denylist:    denylistService,
+			Email:       emailService,
+			Sms:         smsService,
+			Saga:        sagaService,
+			Transaction: transactions,
 		},
 		UseCases: UseCases{
 			UserManager: UserManagerUseCases{
-				Auth:  authUseCases,
-				User:  userUseCases,
-				Admin: adminUseCases,
+				Auth:         authUseCases,
+				User:         userUseCases,
+				Admin:        adminUseCases,
+				OAuth:        oauthUseCases,
+				Role:         roleUseCases,
+				Organization: organizationUseCases,
+				Export:       exportUseCases,
+				Reconcile:    reconcileUseCases,
 			},
 		},
 		Event: dispatcher,
 	}, nil
 }
+
+// runAccountDeletions runs the account deletions whose grace period is over until the context is cancelled.
+func runAccountDeletions(ctx context.Context, uc *user_usecases.ProcessAccountDeletionsUseCase, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Execute(ctx); err != nil {
+				logger.Error("Error running account deletions: %v", err)
+			}
+		}
+	}
+}
+
+// runDataExports retries the data exports whose generation stopped until the context is cancelled.
+func runDataExports(ctx context.Context, uc *export_usecases.RetryStaleExportsUseCase, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Execute(ctx); err != nil {
+				logger.Error("Error retrying data exports: %v", err)
+			}
+		}
+	}
+}
+
+// runReconciliation reconciles the auth provider and the profiles every interval until the context is cancelled.
+func runReconciliation(ctx context.Context, uc *reconcile_usecases.ReconcileUseCase, config appConfig.ReconciliationConfig, logger logger.Logger) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := uc.Execute(ctx, reconcile_usecases.ReconcileInput{DryRun: !config.Repair})
+			if err == reconcile_usecases.ErrReconciliationRunning {
+				logger.Info("Reconciliation skipped, another instance is running it")
+			} else if err != nil {
+				logger.Error("Error running reconciliation: %v", err)
+			}
+		}
+	}
+}
+
+// runSagas resumes the interrupted sagas and retries the failed compensations until the context is cancelled.
+func runSagas(ctx context.Context, sagaService saga.SagaService, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := sagaService.ProcessDue(ctx); err != nil {
+				logger.Error("Error processing sagas: %v", err)
+			}
+		}
+	}
+}
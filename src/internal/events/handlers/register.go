@@ -2,24 +2,36 @@ package events_handlers
 
 import (
 	"auth-api/src/internal/events"
+	export_handlers "auth-api/src/internal/events/handlers/user-manager/export"
 	user_manager "auth-api/src/internal/events/handlers/user-manager/user"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
 	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	"auth-api/src/internal/shared/notification/domain/email"
 	"auth-api/src/pkg/logger"
 )
 
 type EventsHandlers struct {
 	userManagerHandlers *user_manager.EventsHandlers
+	exportHandlers      *export_handlers.EventsHandlers
 }
 
 func NewEventsHandlers(
 	logger logger.Logger,
 	authUsecases auth_usecases.UseCases,
+	members organization.MemberRepository,
+	emailService email.EmailService,
+	exportUseCases export_usecases.UseCases,
+	exports export.ExportRepository,
 ) *EventsHandlers {
 	return &EventsHandlers{
-		userManagerHandlers: user_manager.NewEventsHandlers(logger, authUsecases),
+		userManagerHandlers: user_manager.NewEventsHandlers(logger, authUsecases, members, emailService),
+		exportHandlers:      export_handlers.NewEventsHandlers(logger, exportUseCases, exports),
 	}
 }
 
 func (h *EventsHandlers) RegisterHandlers(dispatcher events.EventDispatcher) {
 	h.userManagerHandlers.RegisterHandlers(dispatcher)
+	h.exportHandlers.RegisterHandlers(dispatcher)
 }
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	export_events "auth-api/src/internal/modules/user-manager/events/export"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	"auth-api/src/pkg/logger"
+)
+
+type EventsHandlers struct {
+	generateExportHandler events.EventHandler
+	removeExportsHandler  events.EventHandler
+}
+
+func NewEventsHandlers(
+	logger logger.Logger,
+	exportUseCases export_usecases.UseCases,
+	exports export.ExportRepository,
+) *EventsHandlers {
+	return &EventsHandlers{
+		generateExportHandler: export_events.NewGenerateExportHandler(logger, exportUseCases),
+		removeExportsHandler:  export_events.NewRemoveExportsHandler(logger, exports),
+	}
+}
+
+func (h *EventsHandlers) RegisterHandlers(dispatcher events.EventDispatcher) {
+	dispatcher.Register(export.DataExportRequested, h.generateExportHandler)
+	dispatcher.Register(user.UserDeleted, h.removeExportsHandler)
+}
@@ -2,25 +2,40 @@ package user
 
 import (
 	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
 	"auth-api/src/internal/modules/user-manager/domain/user"
 	user_events "auth-api/src/internal/modules/user-manager/events/user"
 	"auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/notification/domain/email"
 	"auth-api/src/pkg/logger"
 )
 
 type EventsHandlers struct {
-	sendConfirmationHandler events.EventHandler
+	sendConfirmationHandler  events.EventHandler
+	accountAuditHandler      events.EventHandler
+	removeMembershipsHandler events.EventHandler
+	emailChangedHandler      events.EventHandler
 }
 
 func NewEventsHandlers(
 	logger logger.Logger,
 	authUsecases auth.UseCases,
+	members organization.MemberRepository,
+	emailService email.EmailService,
 ) *EventsHandlers {
 	return &EventsHandlers{
-		sendConfirmationHandler: user_events.NewSendConfirmationHandler(logger, authUsecases),
+		sendConfirmationHandler:  user_events.NewSendConfirmationHandler(logger, authUsecases),
+		accountAuditHandler:      user_events.NewAccountAuditHandler(logger),
+		removeMembershipsHandler: user_events.NewRemoveMembershipsHandler(logger, members),
+		emailChangedHandler:      user_events.NewEmailChangedHandler(logger, emailService),
 	}
 }
 
 func (h *EventsHandlers) RegisterHandlers(dispatcher events.EventDispatcher) {
 	dispatcher.Register(user.UserRegistered, h.sendConfirmationHandler)
+	for _, eventType := range user.AccountEventTypes {
+		dispatcher.Register(eventType, h.accountAuditHandler)
+	}
+	dispatcher.Register(user.UserDeleted, h.removeMembershipsHandler)
+	dispatcher.Register(user.UserEmailChanged, h.emailChangedHandler)
 }
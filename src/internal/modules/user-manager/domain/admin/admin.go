@@ -29,6 +29,12 @@ type Admin struct {
 	Email string  `json:"email"`
 }
 
+// AdminPage is a page of admins, NextCursor is empty on the last page.
+type AdminPage struct {
+	Admins     []*Admin
+	NextCursor string
+}
+
 func (id *AdminID) Scan(value interface{}) error {
 	if value == nil {
 		return fmt.Errorf("scanning a nil value into AdminID")
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"auth-api/src/internal/modules/user-manager/domain/principal"
 	"auth-api/src/pkg/app_error"
 	"auth-api/src/pkg/validator"
 	"fmt"
@@ -89,6 +90,39 @@ func (input *DeleteAdminInput) Validate() error {
 	return nil
 }
 
+const MaxListLimit = 100
+
+// ListAdminsInput pages through the admins sorted from the newest, Cursor is the NextCursor of the previous page.
+type ListAdminsInput struct {
+	Cursor string
+	Limit  int
+
+	cursor *principal.Cursor
+}
+
+func (input *ListAdminsInput) Validate() error {
+	if input.Limit == 0 {
+		input.Limit = MaxListLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxListLimit {
+		return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", MaxListLimit), fmt.Sprintf("Field: %s", "Limit"))
+	}
+	input.cursor = nil
+	if input.Cursor != "" {
+		cursor, err := principal.ParseCursor(input.Cursor)
+		if err != nil {
+			return err
+		}
+		input.cursor = cursor
+	}
+	return nil
+}
+
+// After returns the decoded cursor, nil for the first page.
+func (input *ListAdminsInput) After() *principal.Cursor {
+	return input.cursor
+}
+
 type GetAdminByEmailInput struct {
 	Email string
 }
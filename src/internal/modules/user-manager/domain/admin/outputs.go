@@ -27,7 +27,7 @@ func (u *UpdateAdminOutput) Rollback(ctx context.Context) error {
 		return err
 	}
 
-	_, err := u.svc.Update(updateAdminInput)
+	_, err := u.svc.Update(ctx, updateAdminInput)
 	if err != nil {
 		return err
 	}
@@ -58,7 +58,7 @@ func (c *CreateAdminOutput) Rollback(ctx context.Context) error {
 		return err
 	}
 
-	_, err := c.svc.Delete(deleteAdminInput)
+	_, err := c.svc.Delete(ctx, deleteAdminInput)
 	if err != nil {
 		return err
 	}
@@ -67,13 +67,13 @@ func (c *CreateAdminOutput) Rollback(ctx context.Context) error {
 
 type DeleteAdminOutput struct {
 	backup *Admin
-	repo   AdminRepository
+	svc    AdminService
 }
 
-func NewDeleteAdminOutput(backup *Admin, repo AdminRepository) *DeleteAdminOutput {
+func NewDeleteAdminOutput(backup *Admin, svc AdminService) *DeleteAdminOutput {
 	return &DeleteAdminOutput{
 		backup: backup,
-		repo:   repo,
+		svc:    svc,
 	}
 }
 
@@ -82,6 +82,7 @@ func (d *DeleteAdminOutput) Rollback(ctx context.Context) error {
 		return nil
 	}
 	createAdminInput := &CreateAdminInput{
+		ID:    d.backup.ID,
 		Email: d.backup.Email,
 		Name:  d.backup.Name,
 	}
@@ -89,7 +90,7 @@ func (d *DeleteAdminOutput) Rollback(ctx context.Context) error {
 		return err
 	}
 
-	err := d.repo.Create(createAdminInput)
+	_, err := d.svc.Create(ctx, createAdminInput)
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,12 @@
 package admin
 
+import "context"
+
 type AdminService interface {
-	GetByID(input *GetAdminInput) (*Admin, error)
-	GetByEmail(input *GetAdminByEmailInput) (*Admin, error)
-	Create(input *CreateAdminInput) (*CreateAdminOutput, error)
-	Update(input *UpdateAdminInput) (*UpdateAdminOutput, error)
-	Delete(input *DeleteAdminInput) (*DeleteAdminOutput, error)
+	GetByID(ctx context.Context, input *GetAdminInput) (*Admin, error)
+	GetByEmail(ctx context.Context, input *GetAdminByEmailInput) (*Admin, error)
+	List(ctx context.Context, input *ListAdminsInput) (*AdminPage, error)
+	Create(ctx context.Context, input *CreateAdminInput) (*CreateAdminOutput, error)
+	Update(ctx context.Context, input *UpdateAdminInput) (*UpdateAdminOutput, error)
+	Delete(ctx context.Context, input *DeleteAdminInput) (*DeleteAdminOutput, error)
 }
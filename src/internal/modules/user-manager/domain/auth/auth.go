@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type UserGroup string
 
 const (
@@ -7,6 +9,12 @@ const (
 	GroupUser  UserGroup = "User"
 )
 
+// IsBuiltin reports whether the group is one of the groups every user pool starts with.
+// Other groups mirror the roles created through the roles API.
+func (g UserGroup) IsBuiltin() bool {
+	return g == GroupAdmin || g == GroupUser
+}
+
 type UserStatus string
 
 const (
@@ -21,13 +29,26 @@ type Claims struct {
 	Email      string   `json:"email"`
 	Id         string   `json:"id"`
 	UserGroups []string `json:"groups"`
+	TokenUse   string   `json:"tokenUse,omitempty"`
+	ClientId   string   `json:"clientId,omitempty"`
+	Scope      string   `json:"scope,omitempty"`
+	Jti        string   `json:"jti,omitempty"`
+	OriginJti  string   `json:"originJti,omitempty"`
+	OrgId      string   `json:"orgId,omitempty"`
+	IssuedAt   int64    `json:"iat,omitempty"`
+	ExpiresAt  int64    `json:"exp,omitempty"`
 }
 
 type User struct {
-	Id     string     `json:"id"`
-	Email  string     `json:"email"`
-	Name   string     `json:"name"`
-	Status UserStatus `json:"status"`
+	Id         string     `json:"id"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	Status     UserStatus `json:"status"`
+	Enabled    bool       `json:"enabled"`
+	MFAEnabled bool       `json:"mfaEnabled"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	// Attributes holds every attribute the auth provider stores for the user.
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
 func (us *UserStatus) Scan(value interface{}) error {
@@ -52,3 +73,14 @@ func (us *UserStatus) Scan(value interface{}) error {
 
 	return nil
 }
+
+// Identifiers of the codes sent by email, a code is stored under "<identifier>#<username>".
+const (
+	ConfirmationCodeIdentifier   = "CONFIRMATION_CODE"
+	ForgotPasswordCodeIdentifier = "FORGOT_PASSWORD_CODE"
+)
+
+var CodeIdentifiers = []string{ConfirmationCodeIdentifier, ForgotPasswordCodeIdentifier}
+
+// EmailChangeCodeIdentifier codes are sent to, and stored under, the new email of the user.
+const EmailChangeCodeIdentifier = "EMAIL_CHANGE_CODE"
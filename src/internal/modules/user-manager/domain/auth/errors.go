@@ -14,9 +14,16 @@ var (
 	ErrInvalidUsernameOrPassword  = app_error.NewApiError(401, "Invalid username or password")
 	ErrPasswordResetRequired      = app_error.NewApiError(401, "Password reset required")
 	ErrUserNotConfirmed           = app_error.NewApiError(401, "User not confirmed")
+	ErrUserDisabled               = app_error.NewApiError(401, "User is disabled")
 	ErrUserAlreadyExists          = app_error.NewApiError(409, "User already exists")
 	ErrInvalidRefreshToken        = app_error.NewApiError(401, "Invalid refresh token")
 	ErrUserNotFound               = app_error.NewApiError(404, "User not found")
 	ErrUserAlreadyConfirmed       = app_error.NewApiError(409, "User already confirmed")
 	ErrInvalidUserStatus          = app_error.NewApiError(400, "Invalid user status")
+	ErrTokenExpired               = app_error.NewApiError(401, "Token expired")
+	ErrTokenNotYetValid           = app_error.NewApiError(401, "Token not valid yet")
+	ErrInvalidTokenIssuer         = app_error.NewApiError(401, "Invalid token issuer")
+	ErrInvalidTokenAudience       = app_error.NewApiError(401, "Token issued to another client")
+	ErrInvalidTokenUse            = app_error.NewApiError(401, "Invalid token use", "An access token is required")
+	ErrTokenTooOld                = app_error.NewApiError(401, "Authentication too old", "Sign in again")
 )
@@ -5,6 +5,7 @@ import (
 	"auth-api/src/pkg/validator"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 )
 
@@ -76,6 +77,8 @@ func (input *GetMeInput) Validate() error {
 
 type RefreshTokenInput struct {
 	RefreshToken string
+	// OrganizationID binds the new access token to the organization through the org_id claim, the caller checks the membership.
+	OrganizationID string
 }
 
 func (input *RefreshTokenInput) Validate() error {
@@ -120,10 +123,7 @@ func (input *AddGroupInput) Validate() error {
 	}
 	input.Username = lowerCaseUsername
 
-	if input.GroupName != GroupAdmin && input.GroupName != GroupUser {
-		return app_error.NewApiError(http.StatusBadRequest, "Invalid user group", fmt.Sprintf("Field: %s", "GroupName"))
-	}
-	return nil
+	return validateGroupName(input.GroupName)
 }
 
 type RemoveGroupInput struct {
@@ -138,10 +138,7 @@ func (input *RemoveGroupInput) Validate() error {
 	}
 	input.Username = lowerCaseUsername
 
-	if input.GroupName != GroupAdmin && input.GroupName != GroupUser {
-		return app_error.NewApiError(http.StatusBadRequest, "Invalid user group", fmt.Sprintf("Field: %s", "GroupName"))
-	}
-	return nil
+	return validateGroupName(input.GroupName)
 }
 
 type AddMFAInput struct {
@@ -242,6 +239,15 @@ func (input *LogoutInput) Validate() error {
 	return nil
 }
 
+var groupNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,49}$`)
+
+func validateGroupName(group UserGroup) error {
+	if !groupNameRegex.MatchString(string(group)) {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user group", fmt.Sprintf("Field: %s", "GroupName"))
+	}
+	return nil
+}
+
 func validateEmail(username string) (string, error) {
 	lowerCaseUsername := strings.ToLower(username)
 	if err := validator.ValidateEmail(lowerCaseUsername); err != nil {
@@ -411,3 +417,192 @@ func (input *ChangePasswordInput) Validate() error {
 	}
 	return nil
 }
+
+type RevokeRefreshTokenInput struct {
+	RefreshToken string
+}
+
+func (input *RevokeRefreshTokenInput) Validate() error {
+	if len(input.RefreshToken) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Refresh token is required", fmt.Sprintf("Field: %s", "RefreshToken"))
+	}
+	return nil
+}
+
+type CreateGroupInput struct {
+	GroupName   UserGroup
+	Description string
+}
+
+func (input *CreateGroupInput) Validate() error {
+	return validateGroupName(input.GroupName)
+}
+
+type DeleteGroupInput struct {
+	GroupName UserGroup
+}
+
+func (input *DeleteGroupInput) Validate() error {
+	return validateGroupName(input.GroupName)
+}
+
+type ListUserGroupsInput struct {
+	Username string
+}
+
+func (input *ListUserGroupsInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+	return nil
+}
+
+// MaxListUsersLimit is the largest page the auth providers return.
+const MaxListUsersLimit = 60
+
+// ListUsersInput pages through every user, Cursor is the NextCursor of the previous page.
+// Group and Status restrict the page, an empty value matches everything.
+type ListUsersInput struct {
+	Cursor string
+	Limit  int
+	Group  UserGroup
+	Status UserStatus
+}
+
+func (input *ListUsersInput) Validate() error {
+	if input.Limit == 0 {
+		input.Limit = MaxListUsersLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxListUsersLimit {
+		return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", MaxListUsersLimit), fmt.Sprintf("Field: %s", "Limit"))
+	}
+	if input.Group != "" {
+		if err := validateGroupName(input.Group); err != nil {
+			return err
+		}
+	}
+	return validateStatusFilter(input.Status)
+}
+
+// validateStatusFilter accepts the statuses a provider account can have. UNKNOWN only describes profiles
+// without an account, which no provider listing can return.
+func validateStatusFilter(status UserStatus) error {
+	switch status {
+	case "", Unconfirmed, Confirmed, ResetRequired, ForceChangePasswd:
+		return nil
+	}
+	return app_error.NewApiError(http.StatusBadRequest, "Invalid user status", fmt.Sprintf("Field: %s", "Status"))
+}
+
+// MaxGetUsers is the largest batch GetUsers accepts.
+const MaxGetUsers = 100
+
+type GetUsersInput struct {
+	Usernames []string
+}
+
+func (input *GetUsersInput) Validate() error {
+	if len(input.Usernames) > MaxGetUsers {
+		return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("At most %d users can be fetched at once", MaxGetUsers), fmt.Sprintf("Field: %s", "Usernames"))
+	}
+	for i, username := range input.Usernames {
+		lowerCaseUsername, err := validateEmail(username)
+		if err != nil {
+			return err
+		}
+		input.Usernames[i] = lowerCaseUsername
+	}
+	return nil
+}
+
+type UpdateNameInput struct {
+	Username string
+	Name     string
+}
+
+func (input *UpdateNameInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+
+	if err := validator.ValidateStringLength(input.Name, 3, 50); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid name length", fmt.Sprintf("Field: %s", "Name"))
+	}
+	return nil
+}
+
+type DisableUserInput struct {
+	Username string
+}
+
+func (input *DisableUserInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+	return nil
+}
+
+type EnableUserInput struct {
+	Username string
+}
+
+func (input *EnableUserInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+	return nil
+}
+
+type ForcePasswordResetInput struct {
+	Username string
+}
+
+func (input *ForcePasswordResetInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+	return nil
+}
+
+type PurgeCodesInput struct {
+	Username string
+}
+
+func (input *PurgeCodesInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+	return nil
+}
+
+type ChangeEmailInput struct {
+	Username string
+	NewEmail string
+}
+
+func (input *ChangeEmailInput) Validate() error {
+	lowerCaseUsername, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseUsername
+
+	lowerCaseEmail, err := validateEmail(input.NewEmail)
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid email format", fmt.Sprintf("Field: %s", "NewEmail"))
+	}
+	input.NewEmail = lowerCaseEmail
+	return nil
+}
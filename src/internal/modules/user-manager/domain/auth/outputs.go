@@ -89,6 +89,12 @@ func (output *CreateAdminOutput) Rollback(ctx context.Context) error {
 	return nil
 }
 
+// UserPage is a page of users, NextCursor is empty on the last page.
+type UserPage struct {
+	Users      []*User
+	NextCursor string
+}
+
 type AddMFAOutput struct {
 	SecretCode string  `json:"secretCode"`
 	Session    *string `json:"session,omitempty"`
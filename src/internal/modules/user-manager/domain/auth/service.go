@@ -8,11 +8,23 @@ type AuthService interface {
 	Login(ctx context.Context, input LoginInput) (*LoginOutput, error)
 	SignUp(ctx context.Context, input SignUpInput) (*SignUpOutput, error)
 	DeleteUser(ctx context.Context, input DeleteUserInput) error
+	// DisableUser blocks sign in and token refresh until EnableUser, the sessions are not revoked.
+	DisableUser(ctx context.Context, input DisableUserInput) error
+	EnableUser(ctx context.Context, input EnableUserInput) error
+	// ForcePasswordReset makes the next sign in fail with ErrPasswordResetRequired until the password is reset.
+	ForcePasswordReset(ctx context.Context, input ForcePasswordResetInput) error
 	ConfirmSignUp(ctx context.Context, input ConfirmSignUpInput) (*ConfirmSignUpOutput, error)
 	GetMe(ctx context.Context, input GetMeInput) (*GetMeOutput, error)
 	ValidateToken(ctx context.Context, token string) (*Claims, error)
 	AddGroup(ctx context.Context, input AddGroupInput) error
 	RemoveGroup(ctx context.Context, input RemoveGroupInput) error
+	// CreateGroup and DeleteGroup are idempotent, they keep the provider groups in sync with the roles.
+	CreateGroup(ctx context.Context, input CreateGroupInput) error
+	DeleteGroup(ctx context.Context, input DeleteGroupInput) error
+	ListUserGroups(ctx context.Context, input ListUserGroupsInput) ([]string, error)
+	// ListUsers pages through the users of the provider, in no particular order. Filtered pages can hold
+	// fewer than Limit users and still have a NextCursor.
+	ListUsers(ctx context.Context, input ListUsersInput) (*UserPage, error)
 	RefreshToken(ctx context.Context, input RefreshTokenInput) (*RefreshTokenOutput, error)
 	CreateAdmin(ctx context.Context, input CreateAdminInput) (*CreateAdminOutput, error)
 	AddMFA(ctx context.Context, input AddMFAInput) (*AddMFAOutput, error)
@@ -23,10 +35,19 @@ type AuthService interface {
 	Logout(ctx context.Context, input LogoutInput) error
 	SetPassword(ctx context.Context, input SetPasswordInput) (*LoginOutput, error)
 	GetUser(ctx context.Context, input GetUserInput) (*User, error)
+	// GetUsers returns the users found among the usernames, the missing ones are left out.
+	GetUsers(ctx context.Context, input GetUsersInput) ([]*User, error)
 	AdminLogout(ctx context.Context, input AdminLogoutInput) error
 	VerifyEmail(ctx context.Context, input VerifyEmailInput) error
+	// ChangeEmail replaces the email the user signs in with, the new email is marked as verified.
+	// It fails with ErrUserAlreadyExists if another user has the new email.
+	ChangeEmail(ctx context.Context, input ChangeEmailInput) error
+	UpdateName(ctx context.Context, input UpdateNameInput) error
 	GenerateAndSendCode(ctx context.Context, input GenerateAndSendCodeInput) (*GenerateAndSendCodeOutput, error)
 	VerifyCode(ctx context.Context, input VerifyCodeInput) error
+	// PurgeCodes deletes the outstanding email codes of the user.
+	PurgeCodes(ctx context.Context, input PurgeCodesInput) error
 	ChangeForgotPassword(ctx context.Context, input ChangeForgotPasswordInput) error
 	ChangePassword(ctx context.Context, input ChangePasswordInput) error
+	RevokeRefreshToken(ctx context.Context, input RevokeRefreshTokenInput) error
 }
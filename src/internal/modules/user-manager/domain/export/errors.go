@@ -0,0 +1,11 @@
+package export
+
+import "auth-api/src/pkg/app_error"
+
+var (
+	ErrExportNotFound = app_error.NewApiError(404, "Export not found")
+	ErrExportPending  = app_error.NewApiError(409, "An export is already being generated")
+	ErrExportNotReady = app_error.NewApiError(409, "Export not ready")
+	ErrExportExpired  = app_error.NewApiError(410, "Export expired")
+	ErrInvalidFormat  = app_error.NewApiError(400, "Invalid export format", "Field: format")
+)
@@ -0,0 +1,24 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/pkg/app_error"
+)
+
+const DataExportRequested events.EventType = "DataExportRequested"
+
+type DataExportRequestedEvent struct {
+	ExportID string
+	UserID   string
+}
+
+func (e *DataExportRequestedEvent) GetType() events.EventType {
+	return DataExportRequested
+}
+
+func (e *DataExportRequestedEvent) Validate() error {
+	if e.ExportID == "" || e.UserID == "" {
+		return app_error.NewApiError(400, "Export ID and user ID are required")
+	}
+	return nil
+}
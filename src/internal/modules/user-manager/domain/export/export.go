@@ -0,0 +1,57 @@
+package export
+
+import (
+	"fmt"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatZIP  Format = "zip"
+)
+
+// DataExport is a personal data export of a user, the archive is generated in the background.
+type DataExport struct {
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	// NotifyEmail receives the ready notification, the user or the admin who requested the export.
+	NotifyEmail string     `json:"-"`
+	Format      Format     `json:"format"`
+	Status      Status     `json:"status"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+func (e *DataExport) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// Archive is the downloadable content of a ready export.
+type Archive struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+func NewArchive(export *DataExport, data []byte) *Archive {
+	contentType := "application/json"
+	if export.Format == FormatZIP {
+		contentType = "application/zip"
+	}
+	return &Archive{
+		FileName:    fmt.Sprintf("personal-data-%s.%s", export.CreatedAt.Format("20060102"), export.Format),
+		ContentType: contentType,
+		Data:        data,
+	}
+}
@@ -0,0 +1,89 @@
+package export
+
+import (
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/validator"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateExportInput struct {
+	ID          string
+	UserID      string
+	NotifyEmail string
+	Format      Format
+	ExpiresAt   time.Time
+}
+
+func (input *CreateExportInput) Validate() error {
+	if err := validateID(input.ID, "ID"); err != nil {
+		return err
+	}
+	if err := validateID(input.UserID, "UserID"); err != nil {
+		return err
+	}
+
+	input.NotifyEmail = strings.ToLower(input.NotifyEmail)
+	if err := validator.ValidateEmail(input.NotifyEmail); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid email format", fmt.Sprintf("Field: %s", "NotifyEmail"))
+	}
+
+	if input.Format == "" {
+		input.Format = FormatJSON
+	}
+	if input.Format != FormatJSON && input.Format != FormatZIP {
+		return ErrInvalidFormat
+	}
+
+	if input.ExpiresAt.IsZero() {
+		return app_error.NewApiError(http.StatusBadRequest, "ExpiresAt is required", fmt.Sprintf("Field: %s", "ExpiresAt"))
+	}
+	return nil
+}
+
+type GetExportInput struct {
+	ID string
+	// UserID scopes the lookup to the exports of a user.
+	UserID string
+}
+
+func (input *GetExportInput) Validate() error {
+	if err := validateID(input.ID, "ID"); err != nil {
+		return ErrExportNotFound
+	}
+	return validateID(input.UserID, "UserID")
+}
+
+type ListExportsInput struct {
+	UserID string
+}
+
+func (input *ListExportsInput) Validate() error {
+	return validateID(input.UserID, "UserID")
+}
+
+type CompleteExportInput struct {
+	ID   string
+	Data []byte
+}
+
+func (input *CompleteExportInput) Validate() error {
+	if err := validateID(input.ID, "ID"); err != nil {
+		return err
+	}
+	if len(input.Data) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Export data is required", fmt.Sprintf("Field: %s", "Data"))
+	}
+	return nil
+}
+
+func validateID(id, field string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid ID", fmt.Sprintf("Field: %s", field))
+	}
+	return nil
+}
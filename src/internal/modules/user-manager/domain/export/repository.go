@@ -0,0 +1,26 @@
+package export
+
+import (
+	"context"
+	"time"
+)
+
+type ExportRepository interface {
+	GetByID(ctx context.Context, input *GetExportInput) (*DataExport, error)
+	// GetData returns the archive content of a ready export.
+	GetData(ctx context.Context, input *GetExportInput) ([]byte, error)
+	List(ctx context.Context, input *ListExportsInput) ([]DataExport, error)
+	// Create fails with ErrExportPending if the user already has an export being generated, it also drops the expired exports.
+	Create(ctx context.Context, input *CreateExportInput) error
+	Complete(ctx context.Context, input *CompleteExportInput) error
+	Fail(ctx context.Context, id string) error
+	// Claim leases a pending export to the caller and counts the attempt. It returns false when the export is
+	// no longer pending, is leased to someone else or ran out of attempts.
+	Claim(ctx context.Context, id string, lease time.Duration, maxAttempts int) (bool, error)
+	// ListStale returns the pending exports left behind: leased ones whose lease expired, and ones never claimed
+	// within unclaimedAfter of their request. Exports out of attempts are not returned.
+	ListStale(ctx context.Context, unclaimedAfter time.Duration, maxAttempts int, limit int) ([]DataExport, error)
+	// FailAbandoned marks as failed the pending exports that ran out of attempts, releasing the user.
+	FailAbandoned(ctx context.Context, maxAttempts int) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,22 @@
+package oauth
+
+import (
+	"auth-api/src/pkg/app_error"
+	"net/http"
+)
+
+var (
+	ErrInvalidClient           = app_error.NewOAuthError(http.StatusUnauthorized, "invalid_client", "Unknown client")
+	ErrInvalidRedirectURI      = app_error.NewOAuthError(http.StatusBadRequest, "invalid_request", "Invalid redirect_uri")
+	ErrInvalidGrant            = app_error.NewOAuthError(http.StatusBadRequest, "invalid_grant", "Invalid or expired authorization grant")
+	ErrUnsupportedGrantType    = app_error.NewOAuthError(http.StatusBadRequest, "unsupported_grant_type")
+	ErrUnsupportedResponseType = app_error.NewOAuthError(http.StatusBadRequest, "unsupported_response_type")
+	ErrInvalidScope            = app_error.NewOAuthError(http.StatusBadRequest, "invalid_scope")
+	ErrInvalidToken            = app_error.NewOAuthError(http.StatusUnauthorized, "invalid_token")
+	ErrUnauthorizedClient      = app_error.NewOAuthError(http.StatusBadRequest, "unauthorized_client", "The client is not allowed to use this grant type")
+	ErrClientNotFound          = app_error.NewApiError(http.StatusNotFound, "Client not found", "Field: clientId")
+)
+
+func NewInvalidRequestError(description string) *app_error.OAuthError {
+	return app_error.NewOAuthError(http.StatusBadRequest, "invalid_request", description)
+}
@@ -0,0 +1,363 @@
+package oauth
+
+import (
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/validator"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	codeVerifierRegex = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+	scopeTokenRegex   = regexp.MustCompile(`^[\x21\x23-\x5B\x5D-\x7E]{1,100}$`)
+)
+
+type AuthorizeInput struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// Validate checks the parameters needed to identify the client. Errors here must not be redirected back to it.
+func (input *AuthorizeInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "client_id"))
+	}
+
+	uri, err := url.Parse(input.RedirectURI)
+	if err != nil || !uri.IsAbs() || uri.Fragment != "" {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid redirect URI", fmt.Sprintf("Field: %s", "redirect_uri"))
+	}
+	return nil
+}
+
+// ValidateRequest checks the remaining authorization request parameters once the client and redirect URI are trusted.
+func (input *AuthorizeInput) ValidateRequest() error {
+	if input.ResponseType != ResponseTypeCode {
+		return ErrUnsupportedResponseType
+	}
+
+	for _, scope := range strings.Fields(input.Scope) {
+		if !HasScope(strings.Join(SupportedScopes, " "), scope) {
+			return app_error.NewOAuthError(http.StatusBadRequest, "invalid_scope", fmt.Sprintf("Unsupported scope %s", scope))
+		}
+	}
+
+	if input.CodeChallengeMethod == "" {
+		input.CodeChallengeMethod = CodeChallengeMethodPlain
+	}
+	if input.CodeChallengeMethod != CodeChallengeMethodS256 && input.CodeChallengeMethod != CodeChallengeMethodPlain {
+		return NewInvalidRequestError("Unsupported code_challenge_method")
+	}
+	if !codeVerifierRegex.MatchString(input.CodeChallenge) {
+		return NewInvalidRequestError("A valid code_challenge is required")
+	}
+	return nil
+}
+
+type GetClientInput struct {
+	ClientID string
+}
+
+func (input *GetClientInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "ClientID"))
+	}
+	return nil
+}
+
+type CreateAuthorizationCodeInput struct {
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Subject             string
+	Email               string
+	Name                string
+	RefreshToken        string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+}
+
+func (input *CreateAuthorizationCodeInput) Validate() error {
+	if len(input.ClientID) == 0 || len(input.RedirectURI) == 0 || len(input.CodeChallenge) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid authorization request")
+	}
+	if len(input.Subject) == 0 || len(input.RefreshToken) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid authorization subject")
+	}
+	return nil
+}
+
+type CreateClientInput struct {
+	ClientID       string
+	Name           string
+	SecretHash     string
+	RedirectURIs   []string
+	Scopes         []string
+	AccessTokenTTL int
+}
+
+func (input *CreateClientInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "ClientID"))
+	}
+
+	if err := validator.ValidateStringLength(input.Name, 3, 100); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid name length", fmt.Sprintf("Field: %s", "Name"))
+	}
+
+	if input.RedirectURIs == nil {
+		input.RedirectURIs = []string{}
+	}
+	if err := validateRedirectURIs(input.RedirectURIs); err != nil {
+		return err
+	}
+
+	if input.Scopes == nil {
+		input.Scopes = []string{}
+	}
+	if err := validateScopes(input.Scopes); err != nil {
+		return err
+	}
+
+	if input.AccessTokenTTL == 0 {
+		input.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+	return validateAccessTokenTTL(input.AccessTokenTTL)
+}
+
+type UpdateClientInput struct {
+	ClientID       string
+	Name           *string
+	RedirectURIs   *[]string
+	Scopes         *[]string
+	AccessTokenTTL *int
+}
+
+func (input *UpdateClientInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "ClientID"))
+	}
+
+	if input.Name != nil {
+		if err := validator.ValidateStringLength(*input.Name, 3, 100); err != nil {
+			return app_error.NewApiError(http.StatusBadRequest, "Invalid name length", fmt.Sprintf("Field: %s", "Name"))
+		}
+	}
+
+	if input.RedirectURIs != nil {
+		if err := validateRedirectURIs(*input.RedirectURIs); err != nil {
+			return err
+		}
+	}
+
+	if input.Scopes != nil {
+		if err := validateScopes(*input.Scopes); err != nil {
+			return err
+		}
+	}
+
+	if input.AccessTokenTTL != nil {
+		return validateAccessTokenTTL(*input.AccessTokenTTL)
+	}
+	return nil
+}
+
+type SetClientSecretInput struct {
+	ClientID   string
+	SecretHash string
+}
+
+func (input *SetClientSecretInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "ClientID"))
+	}
+	if len(input.SecretHash) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client secret is required", fmt.Sprintf("Field: %s", "SecretHash"))
+	}
+	return nil
+}
+
+type DeleteClientInput struct {
+	ClientID string
+}
+
+func (input *DeleteClientInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Client ID is required", fmt.Sprintf("Field: %s", "ClientID"))
+	}
+	return nil
+}
+
+func validateRedirectURIs(uris []string) error {
+	for _, redirectURI := range uris {
+		uri, err := url.Parse(redirectURI)
+		if err != nil || !uri.IsAbs() || uri.Fragment != "" {
+			return app_error.NewApiError(http.StatusBadRequest, "Invalid redirect URI", fmt.Sprintf("Field: %s", "RedirectURIs"))
+		}
+	}
+	return nil
+}
+
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !scopeTokenRegex.MatchString(scope) {
+			return app_error.NewApiError(http.StatusBadRequest, "Invalid scope", fmt.Sprintf("Field: %s", "Scopes"))
+		}
+	}
+	return nil
+}
+
+func validateAccessTokenTTL(ttl int) error {
+	if ttl < 60 || ttl > 86400 {
+		return app_error.NewApiError(http.StatusBadRequest, "Access token TTL must be between 60 and 86400 seconds", fmt.Sprintf("Field: %s", "AccessTokenTTL"))
+	}
+	return nil
+}
+
+type TokenInput struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+func (input *TokenInput) Validate() error {
+	if len(input.ClientID) == 0 {
+		return ErrInvalidClient
+	}
+
+	switch input.GrantType {
+	case GrantTypeAuthorizationCode:
+		if len(input.Code) == 0 {
+			return NewInvalidRequestError("code is required")
+		}
+		if len(input.RedirectURI) == 0 {
+			return NewInvalidRequestError("redirect_uri is required")
+		}
+		if !codeVerifierRegex.MatchString(input.CodeVerifier) {
+			return NewInvalidRequestError("A valid code_verifier is required")
+		}
+	case GrantTypeRefreshToken:
+		if len(input.RefreshToken) == 0 {
+			return NewInvalidRequestError("refresh_token is required")
+		}
+	case GrantTypeClientCredentials:
+		if len(input.ClientSecret) == 0 {
+			return ErrInvalidClient
+		}
+	default:
+		return ErrUnsupportedGrantType
+	}
+	return nil
+}
+
+type IssueIdTokenInput struct {
+	ClientID string
+	Subject  string
+	Email    string
+	Name     string
+	Nonce    string
+	AuthTime time.Time
+}
+
+func (input *IssueIdTokenInput) Validate() error {
+	if len(input.ClientID) == 0 || len(input.Subject) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid ID token subject")
+	}
+	return nil
+}
+
+type IssueClientTokenInput struct {
+	ClientID string
+	Scope    string
+	TTL      time.Duration
+}
+
+func (input *IssueClientTokenInput) Validate() error {
+	if len(input.ClientID) == 0 || input.TTL <= 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid client token request")
+	}
+	return nil
+}
+
+type UserInfoInput struct {
+	AccessToken string
+}
+
+func (input *UserInfoInput) Validate() error {
+	if len(input.AccessToken) == 0 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+type IntrospectInput struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+func (input *IntrospectInput) Validate() error {
+	if len(input.ClientID) == 0 || len(input.ClientSecret) == 0 {
+		return ErrInvalidClient
+	}
+	if len(input.Token) == 0 {
+		return NewInvalidRequestError("token is required")
+	}
+	return nil
+}
+
+type RevokeInput struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+func (input *RevokeInput) Validate() error {
+	if len(input.ClientID) == 0 || len(input.ClientSecret) == 0 {
+		return ErrInvalidClient
+	}
+	if len(input.Token) == 0 {
+		return NewInvalidRequestError("token is required")
+	}
+	return nil
+}
+
+// BindRefreshTokenInput keeps the Scope and AuthTime of the authorization code, refreshing only issues an ID token
+// again for the openid scope.
+type BindRefreshTokenInput struct {
+	RefreshToken string
+	ClientID     string
+	Subject      string
+	Username     string
+	OriginJti    string
+	Scope        string
+	AuthTime     time.Time
+	ExpiresAt    time.Time
+}
+
+func (input *BindRefreshTokenInput) Validate() error {
+	if len(input.RefreshToken) == 0 || len(input.ClientID) == 0 || len(input.Subject) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid refresh token binding")
+	}
+	return nil
+}
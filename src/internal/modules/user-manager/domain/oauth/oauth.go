@@ -0,0 +1,139 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	ResponseTypeCode = "code"
+
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+
+	CodeChallengeMethodS256  = "S256"
+	CodeChallengeMethodPlain = "plain"
+
+	ScopeOpenID  = "openid"
+	ScopeEmail   = "email"
+	ScopeProfile = "profile"
+
+	// Scopes granted to client applications to call the API with client credentials tokens.
+	ScopeGroupsWrite = "groups:write"
+	ScopeMFAWrite    = "mfa:write"
+
+	DefaultAccessTokenTTL = 3600
+)
+
+var SupportedScopes = []string{ScopeOpenID, ScopeEmail, ScopeProfile}
+
+type Client struct {
+	ClientID     string   `json:"clientId"`
+	Name         string   `json:"name"`
+	SecretHash   string   `json:"-"`
+	RedirectURIs []string `json:"redirectUris"`
+	Scopes       []string `json:"scopes"`
+	// AccessTokenTTL is the lifetime in seconds of the client credentials tokens issued to the client.
+	AccessTokenTTL int       `json:"accessTokenTtl"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// IsConfidential reports whether the client has a secret and must authenticate at the token endpoint.
+func (c *Client) IsConfidential() bool {
+	return c.SecretHash != ""
+}
+
+// AllowsScopes reports whether every scope in the space separated list was granted to the client.
+func (c *Client) AllowsScopes(scopes string) bool {
+	for _, scope := range strings.Fields(scopes) {
+		if !HasScope(strings.Join(c.Scopes, " "), scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Client) HasRedirectURI(redirectURI string) bool {
+	for _, uri := range c.RedirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizationCode is the single use grant handed to the client after the user logs in through the hosted UI.
+// It carries the refresh token of the login, from which the tokens are issued when the code is exchanged.
+type AuthorizationCode struct {
+	Code                string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Subject             string
+	Email               string
+	Name                string
+	RefreshToken        string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+}
+
+// VerifyCodeVerifier checks the PKCE code_verifier against the challenge sent to the authorize endpoint (RFC 7636).
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	expected := verifier
+	if c.CodeChallengeMethod == CodeChallengeMethodS256 {
+		sum := sha256.Sum256([]byte(verifier))
+		expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(c.CodeChallenge)) == 1
+}
+
+func (c *AuthorizationCode) HasScope(scope string) bool {
+	return HasScope(c.Scope, scope)
+}
+
+func HasScope(scopes, scope string) bool {
+	for _, s := range strings.Fields(scopes) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshTokenBinding ties a refresh token handed out at the token endpoint to the client it was issued to, only
+// that client can refresh or revoke it.
+type RefreshTokenBinding struct {
+	ClientID  string
+	Subject   string
+	Username  string
+	OriginJti string
+	Scope     string
+	AuthTime  time.Time
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (b *RefreshTokenBinding) HasScope(scope string) bool {
+	return HasScope(b.Scope, scope)
+}
+
+// ClientClaims identifies the client application behind a client credentials token.
+type ClientClaims struct {
+	ClientID  string
+	Scopes    []string
+	Jti       string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func (c *ClientClaims) HasScope(scope string) bool {
+	return HasScope(strings.Join(c.Scopes, " "), scope)
+}
@@ -0,0 +1,32 @@
+package oauth
+
+import "context"
+
+type ClientRepository interface {
+	GetByID(ctx context.Context, input *GetClientInput) (*Client, error)
+	List(ctx context.Context) ([]Client, error)
+	Create(ctx context.Context, input *CreateClientInput) error
+	Update(ctx context.Context, input *UpdateClientInput) error
+	SetSecret(ctx context.Context, input *SetClientSecretInput) error
+	Delete(ctx context.Context, input *DeleteClientInput) error
+}
+
+type AuthorizationCodeRepository interface {
+	// Create stores a new authorization code and returns its raw value. Only a hash of the code is persisted, the
+	// refresh token being sealed with a key derived from the code. Expired codes are deleted along the way.
+	Create(ctx context.Context, input *CreateAuthorizationCodeInput) (string, error)
+	// Consume deletes the code and returns it, failing with ErrInvalidGrant if it does not exist or expired.
+	Consume(ctx context.Context, code string) (*AuthorizationCode, error)
+}
+
+type RefreshTokenRepository interface {
+	// Bind records the client a refresh token was issued to, only a hash of the token is persisted. Expired bindings
+	// are deleted along the way.
+	Bind(ctx context.Context, input *BindRefreshTokenInput) error
+	// Get fails with ErrInvalidGrant if the token was not issued through the token endpoint or its binding expired.
+	Get(ctx context.Context, refreshToken string) (*RefreshTokenBinding, error)
+	// GetByOriginJti finds the binding of the session an access token belongs to, failing with ErrInvalidGrant if the
+	// session was not started at the token endpoint or its binding expired.
+	GetByOriginJti(ctx context.Context, originJti string) (*RefreshTokenBinding, error)
+	Delete(ctx context.Context, refreshToken string) error
+}
@@ -0,0 +1,18 @@
+package oauth
+
+import (
+	"auth-api/src/pkg/jwt_verify"
+	"context"
+)
+
+type OAuthService interface {
+	Issuer() string
+	JWK() *jwt_verify.JWK
+	IssueIdToken(ctx context.Context, input IssueIdTokenInput) (string, error)
+	IssueClientToken(ctx context.Context, input IssueClientTokenInput) (string, error)
+	// IsClientToken cheaply tells client credentials tokens apart from user tokens, without verifying them.
+	IsClientToken(token string) bool
+	ValidateClientToken(ctx context.Context, token string) (*ClientClaims, error)
+	// TokenExpiresIn returns the seconds left before a token issued by the auth provider expires.
+	TokenExpiresIn(token string) int64
+}
@@ -0,0 +1,23 @@
+package organization
+
+import (
+	"auth-api/src/pkg/app_error"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrOrganizationNotFound = app_error.NewApiError(http.StatusNotFound, "Organization not found", fmt.Sprintf("Field: %s", "OrganizationID"))
+	ErrSlugAlreadyTaken     = app_error.NewApiError(http.StatusConflict, "Organization slug already taken", fmt.Sprintf("Field: %s", "Slug"))
+	ErrMemberNotFound       = app_error.NewApiError(http.StatusNotFound, "Member not found", fmt.Sprintf("Field: %s", "UserID"))
+	ErrMemberAlreadyExists  = app_error.NewApiError(http.StatusConflict, "User is already a member", fmt.Sprintf("Field: %s", "Username"))
+	ErrNotAMember           = app_error.NewApiError(http.StatusForbidden, "Not a member of the organization")
+	ErrOrganizationMismatch = app_error.NewApiError(http.StatusForbidden, "Token issued for another organization")
+	ErrOrgClaimNotMinted    = app_error.NewApiError(http.StatusNotImplemented, "The auth provider did not add the org_id claim")
+	ErrRoleNotGrantable     = app_error.NewApiError(http.StatusForbidden, "Cannot grant a role with permissions you do not hold", fmt.Sprintf("Field: %s", "Role"))
+	ErrInvitationNotFound   = app_error.NewApiError(http.StatusNotFound, "Invitation not found")
+	ErrInvitationPending    = app_error.NewApiError(http.StatusConflict, "A pending invitation already exists for this email", fmt.Sprintf("Field: %s", "Email"))
+	ErrInvalidInvitation    = app_error.NewApiError(http.StatusBadRequest, "Invalid or expired invitation")
+	ErrInvitationEmail      = app_error.NewApiError(http.StatusForbidden, "The invitation was sent to another email")
+	ErrLastOrgAdmin         = app_error.NewApiError(http.StatusConflict, "The organization must keep at least one OrgAdmin")
+)
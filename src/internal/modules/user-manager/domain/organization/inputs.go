@@ -0,0 +1,291 @@
+package organization
+
+import (
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/validator"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type GetOrganizationInput struct {
+	ID string
+}
+
+func (input *GetOrganizationInput) Validate() error {
+	return validateID(input.ID)
+}
+
+type CreateOrganizationInput struct {
+	ID   string
+	Name string
+	Slug string
+}
+
+func (input *CreateOrganizationInput) Validate() error {
+	if err := validateID(input.ID); err != nil {
+		return err
+	}
+	if err := validateName(input.Name); err != nil {
+		return err
+	}
+	return validateSlug(&input.Slug)
+}
+
+type UpdateOrganizationInput struct {
+	ID   string
+	Name *string
+	Slug *string
+}
+
+func (input *UpdateOrganizationInput) Validate() error {
+	if err := validateID(input.ID); err != nil {
+		return err
+	}
+	if input.Name != nil {
+		if err := validateName(*input.Name); err != nil {
+			return err
+		}
+	}
+	if input.Slug != nil {
+		return validateSlug(input.Slug)
+	}
+	return nil
+}
+
+type DeleteOrganizationInput struct {
+	ID string
+}
+
+func (input *DeleteOrganizationInput) Validate() error {
+	return validateID(input.ID)
+}
+
+type ListMembershipsInput struct {
+	UserID string
+}
+
+func (input *ListMembershipsInput) Validate() error {
+	if len(input.UserID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "User ID is required", fmt.Sprintf("Field: %s", "UserID"))
+	}
+	return nil
+}
+
+type GetMemberInput struct {
+	OrganizationID string
+	UserID         string
+}
+
+func (input *GetMemberInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	if len(input.UserID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "User ID is required", fmt.Sprintf("Field: %s", "UserID"))
+	}
+	return nil
+}
+
+type ListMembersInput struct {
+	OrganizationID string
+}
+
+func (input *ListMembersInput) Validate() error {
+	return validateID(input.OrganizationID)
+}
+
+type AddMemberInput struct {
+	OrganizationID string
+	UserID         string
+	Email          string
+	Role           string
+}
+
+func (input *AddMemberInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	if len(input.UserID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "User ID is required", fmt.Sprintf("Field: %s", "UserID"))
+	}
+	email := strings.ToLower(input.Email)
+	if err := validator.ValidateEmail(email); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid email", fmt.Sprintf("Field: %s", "Email"))
+	}
+	input.Email = email
+	return validateRole(input.Role)
+}
+
+type UpdateMemberInput struct {
+	OrganizationID string
+	UserID         string
+	Role           string
+}
+
+func (input *UpdateMemberInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	if len(input.UserID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "User ID is required", fmt.Sprintf("Field: %s", "UserID"))
+	}
+	return validateRole(input.Role)
+}
+
+type RemoveMemberInput struct {
+	OrganizationID string
+	UserID         string
+}
+
+func (input *RemoveMemberInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	if len(input.UserID) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "User ID is required", fmt.Sprintf("Field: %s", "UserID"))
+	}
+	return nil
+}
+
+type AuthorizeInput struct {
+	OrganizationID string
+	UserID         string
+	// Groups are the platform roles of the user, from the groups claim.
+	Groups     []string
+	Permission string
+}
+
+func (input *AuthorizeInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	if len(input.UserID) == 0 || len(input.Permission) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid authorization request")
+	}
+	return nil
+}
+
+type GetInvitationInput struct {
+	ID string
+}
+
+func (input *GetInvitationInput) Validate() error {
+	if _, err := uuid.Parse(input.ID); err != nil {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+type ListInvitationsInput struct {
+	OrganizationID string
+	// Status filters the invitations, all of them are returned when empty.
+	Status InvitationStatus
+}
+
+func (input *ListInvitationsInput) Validate() error {
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	switch input.Status {
+	case "", InvitationPending, InvitationAccepted, InvitationRevoked:
+		return nil
+	}
+	return app_error.NewApiError(http.StatusBadRequest, "Invalid status", fmt.Sprintf("Field: %s", "Status"))
+}
+
+type CreateInvitationInput struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Role           string
+	InvitedBy      string
+	ExpiresAt      time.Time
+}
+
+func (input *CreateInvitationInput) Validate() error {
+	if _, err := uuid.Parse(input.ID); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid invitation ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	if err := validateID(input.OrganizationID); err != nil {
+		return err
+	}
+	email := strings.ToLower(input.Email)
+	if err := validator.ValidateEmail(email); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid email", fmt.Sprintf("Field: %s", "Email"))
+	}
+	input.Email = email
+	if err := validateRole(input.Role); err != nil {
+		return err
+	}
+	if input.ExpiresAt.Before(time.Now()) {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid expiration", fmt.Sprintf("Field: %s", "ExpiresAt"))
+	}
+	return nil
+}
+
+type RenewInvitationInput struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+func (input *RenewInvitationInput) Validate() error {
+	if _, err := uuid.Parse(input.ID); err != nil {
+		return ErrInvitationNotFound
+	}
+	if input.ExpiresAt.Before(time.Now()) {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid expiration", fmt.Sprintf("Field: %s", "ExpiresAt"))
+	}
+	return nil
+}
+
+type SetInvitationStatusInput struct {
+	ID     string
+	Status InvitationStatus
+}
+
+func (input *SetInvitationStatusInput) Validate() error {
+	if _, err := uuid.Parse(input.ID); err != nil {
+		return ErrInvitationNotFound
+	}
+	if input.Status != InvitationAccepted && input.Status != InvitationRevoked {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid status", fmt.Sprintf("Field: %s", "Status"))
+	}
+	return nil
+}
+
+func validateID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid organization ID", fmt.Sprintf("Field: %s", "OrganizationID"))
+	}
+	return nil
+}
+
+func validateName(name string) error {
+	if err := validator.ValidateStringLength(strings.TrimSpace(name), 2, 100); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid name length", fmt.Sprintf("Field: %s", "Name"))
+	}
+	return nil
+}
+
+func validateSlug(slug *string) error {
+	lowerCaseSlug := strings.ToLower(*slug)
+	if len(lowerCaseSlug) < 2 || len(lowerCaseSlug) > 50 || !slugRegex.MatchString(lowerCaseSlug) {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid slug", fmt.Sprintf("Field: %s", "Slug"))
+	}
+	*slug = lowerCaseSlug
+	return nil
+}
+
+func validateRole(role string) error {
+	if len(role) == 0 || len(role) > 50 {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid role", fmt.Sprintf("Field: %s", "Role"))
+	}
+	return nil
+}
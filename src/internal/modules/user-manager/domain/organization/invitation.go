@@ -0,0 +1,37 @@
+package organization
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+type Invitation struct {
+	ID             string           `json:"id"`
+	OrganizationID string           `json:"organizationId"`
+	Email          string           `json:"email"`
+	Role           string           `json:"role"`
+	Status         InvitationStatus `json:"status"`
+	InvitedBy      string           `json:"invitedBy"`
+	// Version is bumped on every resend so that only the latest link works.
+	Version    int        `json:"-"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// IsUsable reports whether the invitation can still be accepted.
+func (i *Invitation) IsUsable() bool {
+	return i.Status == InvitationPending && time.Now().Before(i.ExpiresAt)
+}
+
+// InvitationToken is what the signed link carries.
+type InvitationToken struct {
+	InvitationID string
+	Version      int
+}
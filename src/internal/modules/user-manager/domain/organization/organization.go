@@ -0,0 +1,39 @@
+package organization
+
+import "time"
+
+const (
+	// Permissions checked inside an organization, granted by the role of the membership.
+	PermissionMembersRead  = "members:read"
+	PermissionMembersWrite = "members:write"
+	PermissionOrgWrite     = "org:write"
+
+	// PermissionOrgsManage is a platform permission: its holders manage every organization.
+	PermissionOrgsManage = "orgs:manage"
+
+	RoleOrgAdmin  = "OrgAdmin"
+	RoleOrgMember = "OrgMember"
+)
+
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Member struct {
+	OrganizationID string    `json:"organizationId"`
+	UserID         string    `json:"userId"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Membership is an organization seen from one of its members.
+type Membership struct {
+	Organization
+	Role string `json:"role"`
+}
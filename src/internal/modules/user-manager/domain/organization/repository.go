@@ -0,0 +1,36 @@
+package organization
+
+import "context"
+
+type OrganizationRepository interface {
+	GetByID(ctx context.Context, input *GetOrganizationInput) (*Organization, error)
+	List(ctx context.Context) ([]Organization, error)
+	Create(ctx context.Context, input *CreateOrganizationInput) error
+	Update(ctx context.Context, input *UpdateOrganizationInput) error
+	// Delete removes the organization together with its memberships.
+	Delete(ctx context.Context, input *DeleteOrganizationInput) error
+}
+
+type MemberRepository interface {
+	Get(ctx context.Context, input *GetMemberInput) (*Member, error)
+	List(ctx context.Context, input *ListMembersInput) ([]Member, error)
+	ListMemberships(ctx context.Context, input *ListMembershipsInput) ([]Membership, error)
+	// CountWithRole is used to keep at least one OrgAdmin in every organization. It locks the counted members
+	// until the end of the transaction of the context, so concurrent demotions cannot both pass the check.
+	CountWithRole(ctx context.Context, organizationID string, role string) (int, error)
+	Add(ctx context.Context, input *AddMemberInput) error
+	UpdateRole(ctx context.Context, input *UpdateMemberInput) error
+	// UpdateEmail follows an email change of the user in all of their memberships.
+	UpdateEmail(ctx context.Context, userID string, email string) error
+	Remove(ctx context.Context, input *RemoveMemberInput) error
+}
+
+type InvitationRepository interface {
+	GetByID(ctx context.Context, input *GetInvitationInput) (*Invitation, error)
+	List(ctx context.Context, input *ListInvitationsInput) ([]Invitation, error)
+	// Create fails with ErrInvitationPending if the email already has a pending invitation to the organization.
+	Create(ctx context.Context, input *CreateInvitationInput) error
+	// Renew bumps the version of a pending invitation and sets its new expiration.
+	Renew(ctx context.Context, input *RenewInvitationInput) error
+	SetStatus(ctx context.Context, input *SetInvitationStatusInput) error
+}
@@ -0,0 +1,16 @@
+package organization
+
+import "context"
+
+type OrganizationService interface {
+	// Authorize checks that the user may use the permission inside the organization, either through the role
+	// of their membership or through a platform role granting orgs:manage. The membership is nil in that case.
+	Authorize(ctx context.Context, input AuthorizeInput) (*Member, error)
+}
+
+type InvitationSigner interface {
+	// Sign returns the token put in the invitation link, valid until the invitation expires.
+	Sign(invitation *Invitation) (string, error)
+	// Verify fails with ErrInvalidInvitation if the token was tampered with or expired.
+	Verify(token string) (*InvitationToken, error)
+}
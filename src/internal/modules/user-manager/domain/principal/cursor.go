@@ -0,0 +1,47 @@
+package principal
+
+import (
+	"auth-api/src/pkg/app_error"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cursor points at the last principal of a page, the next page starts right after it.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func NewCursor(p *Principal) Cursor {
+	return Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+}
+
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%s|%s", c.CreatedAt.UTC().Format(time.RFC3339Nano), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCursor(cursor string) (*Cursor, error) {
+	errInvalid := app_error.NewApiError(http.StatusBadRequest, "Invalid cursor", fmt.Sprintf("Field: %s", "Cursor"))
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalid
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errInvalid
+	}
+
+	parsedCreatedAt, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errInvalid
+	}
+	if err := validateID(id); err != nil {
+		return nil, errInvalid
+	}
+	return &Cursor{CreatedAt: parsedCreatedAt, ID: id}, nil
+}
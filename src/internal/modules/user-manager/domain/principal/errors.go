@@ -0,0 +1,8 @@
+package principal
+
+import "auth-api/src/pkg/app_error"
+
+var (
+	ErrPrincipalNotFound = app_error.NewApiError(404, "Principal not found", "Field: id")
+	ErrInvalidRole       = app_error.NewApiError(400, "Invalid role", "Field: role")
+)
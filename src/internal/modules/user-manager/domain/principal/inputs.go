@@ -0,0 +1,110 @@
+package principal
+
+import (
+	"auth-api/src/pkg/app_error"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetPrincipalInput finds a principal by ID, or by email when ID is empty. With a Role, principals not holding it
+// are not found.
+type GetPrincipalInput struct {
+	ID    string
+	Email string
+	Role  string
+}
+
+func (input *GetPrincipalInput) Validate() error {
+	if input.ID != "" {
+		return validateID(input.ID)
+	}
+	if input.Email == "" {
+		return app_error.NewApiError(http.StatusBadRequest, "An ID or an email is required", fmt.Sprintf("Field: %s", "ID"))
+	}
+	return nil
+}
+
+// ListPrincipalsInput pages through the principals holding Role, the filters being those of the user directory.
+type ListPrincipalsInput struct {
+	Role          string
+	After         *Cursor
+	Search        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// Emails restricts the page to these principals when not nil.
+	Emails []string
+	Limit  int
+}
+
+func (input *ListPrincipalsInput) Validate() error {
+	if input.Role == "" {
+		return ErrInvalidRole
+	}
+	if input.Limit <= 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Limit must be positive", fmt.Sprintf("Field: %s", "Limit"))
+	}
+	return nil
+}
+
+// GrantRoleInput gives Role to the principal, creating it from the profile fields when it does not exist yet.
+type GrantRoleInput struct {
+	Principal *Principal
+	Role      string
+}
+
+func (input *GrantRoleInput) Validate() error {
+	if input.Principal == nil {
+		return app_error.NewApiError(http.StatusBadRequest, "A principal is required", fmt.Sprintf("Field: %s", "Principal"))
+	}
+	if input.Role == "" {
+		return ErrInvalidRole
+	}
+	return validateID(input.Principal.ID)
+}
+
+// RevokeRoleInput takes Role back from the principal, which is deleted once it holds no role.
+type RevokeRoleInput struct {
+	ID   string
+	Role string
+}
+
+func (input *RevokeRoleInput) Validate() error {
+	if input.Role == "" {
+		return ErrInvalidRole
+	}
+	return validateID(input.ID)
+}
+
+// UpdatePrincipalInput changes the non nil fields, Attributes are merged into the current ones and a nil value
+// removes an attribute.
+type UpdatePrincipalInput struct {
+	ID         string
+	Name       *string
+	Email      *string
+	Phone      *string
+	Attributes map[string]interface{}
+}
+
+func (input *UpdatePrincipalInput) Validate() error {
+	return validateID(input.ID)
+}
+
+// MarkPhoneVerifiedInput marks Phone as verified, as long as it is still the phone of the principal.
+type MarkPhoneVerifiedInput struct {
+	ID    string
+	Phone string
+}
+
+func (input *MarkPhoneVerifiedInput) Validate() error {
+	return validateID(input.ID)
+}
+
+func validateID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid principal ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package principal
+
+import "time"
+
+// Principal is a person known to the auth provider, keyed by its subject. There is a single profile per principal,
+// the user and admin profiles being the principals holding the User and Admin roles.
+type Principal struct {
+	ID            string
+	Name          string
+	Email         string
+	Phone         *string
+	PhoneVerified bool
+	Attributes    map[string]interface{}
+	CreatedAt     time.Time
+	LastLoginAt   *time.Time
+	Roles         []string
+}
+
+// PrincipalPage is a page of principals sorted from the newest, NextCursor is empty on the last page.
+type PrincipalPage struct {
+	Principals []*Principal
+	NextCursor string
+}
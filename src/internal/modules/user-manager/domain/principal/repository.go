@@ -0,0 +1,16 @@
+package principal
+
+import "context"
+
+type PrincipalRepository interface {
+	Get(ctx context.Context, input *GetPrincipalInput) (*Principal, error)
+	List(ctx context.Context, input *ListPrincipalsInput) (*PrincipalPage, error)
+	// Grant keeps the profile of an existing principal, only filling in its missing phone and attributes.
+	Grant(ctx context.Context, input *GrantRoleInput) error
+	Revoke(ctx context.Context, input *RevokeRoleInput) error
+	Update(ctx context.Context, input *UpdatePrincipalInput) error
+	// MarkPhoneVerified reports false when the phone of the principal is no longer input.Phone.
+	MarkPhoneVerified(ctx context.Context, input *MarkPhoneVerifiedInput) (bool, error)
+	// RecordLogin does nothing when no principal has the email.
+	RecordLogin(ctx context.Context, email string) error
+}
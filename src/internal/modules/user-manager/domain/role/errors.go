@@ -0,0 +1,14 @@
+package role
+
+import (
+	"auth-api/src/pkg/app_error"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrRoleNotFound      = app_error.NewApiError(http.StatusNotFound, "Role not found", fmt.Sprintf("Field: %s", "Name"))
+	ErrRoleAlreadyExists = app_error.NewApiError(http.StatusConflict, "Role already exists", fmt.Sprintf("Field: %s", "Name"))
+	ErrRoleInUse         = app_error.NewApiError(http.StatusConflict, "Role is held by organization members", fmt.Sprintf("Field: %s", "Name"))
+	ErrBuiltinRole       = app_error.NewApiError(http.StatusBadRequest, "Built-in roles cannot be deleted or assigned here", "Use the /auth/groups endpoints for the Admin and User groups and the organization members endpoints for OrgAdmin and OrgMember")
+)
@@ -0,0 +1,133 @@
+package role
+
+import (
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/validator"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Role names double as auth provider group names, so they follow the Cognito group name rules we accept.
+	roleNameRegex   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,49}$`)
+	permissionRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*:[a-z][a-z0-9_-]*$`)
+)
+
+type GetRoleInput struct {
+	Name string
+}
+
+func (input *GetRoleInput) Validate() error {
+	return validateName(input.Name)
+}
+
+type CreateRoleInput struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+func (input *CreateRoleInput) Validate() error {
+	if err := validateName(input.Name); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateStringLength(input.Description, 0, 255); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid description length", fmt.Sprintf("Field: %s", "Description"))
+	}
+
+	if input.Permissions == nil {
+		input.Permissions = []string{}
+	}
+	return validatePermissions(input.Permissions)
+}
+
+type UpdateRoleInput struct {
+	Name        string
+	Description *string
+	Permissions *[]string
+}
+
+func (input *UpdateRoleInput) Validate() error {
+	if err := validateName(input.Name); err != nil {
+		return err
+	}
+
+	if input.Description != nil {
+		if err := validator.ValidateStringLength(*input.Description, 0, 255); err != nil {
+			return app_error.NewApiError(http.StatusBadRequest, "Invalid description length", fmt.Sprintf("Field: %s", "Description"))
+		}
+	}
+
+	if input.Permissions != nil {
+		return validatePermissions(*input.Permissions)
+	}
+	return nil
+}
+
+type DeleteRoleInput struct {
+	Name string
+}
+
+func (input *DeleteRoleInput) Validate() error {
+	return validateName(input.Name)
+}
+
+type AssignRoleInput struct {
+	Name     string
+	Username string
+}
+
+func (input *AssignRoleInput) Validate() error {
+	if err := validateName(input.Name); err != nil {
+		return err
+	}
+	return validateUsername(&input.Username)
+}
+
+type UnassignRoleInput struct {
+	Name     string
+	Username string
+}
+
+func (input *UnassignRoleInput) Validate() error {
+	if err := validateName(input.Name); err != nil {
+		return err
+	}
+	return validateUsername(&input.Username)
+}
+
+type ListUserRolesInput struct {
+	Username string
+}
+
+func (input *ListUserRolesInput) Validate() error {
+	return validateUsername(&input.Username)
+}
+
+func validateName(name string) error {
+	if !roleNameRegex.MatchString(name) {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid role name", fmt.Sprintf("Field: %s", "Name"))
+	}
+	return nil
+}
+
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if len(permission) > 100 || !permissionRegex.MatchString(permission) {
+			return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("Invalid permission %s", permission), fmt.Sprintf("Field: %s", "Permissions"))
+		}
+	}
+	return nil
+}
+
+func validateUsername(username *string) error {
+	lowerCaseUsername := strings.ToLower(*username)
+	if err := validator.ValidateEmail(lowerCaseUsername); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid username", fmt.Sprintf("Field: %s", "Username"))
+	}
+	*username = lowerCaseUsername
+	return nil
+}
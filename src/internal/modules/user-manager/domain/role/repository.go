@@ -0,0 +1,13 @@
+package role
+
+import "context"
+
+type RoleRepository interface {
+	GetByName(ctx context.Context, input *GetRoleInput) (*Role, error)
+	List(ctx context.Context) ([]Role, error)
+	// ListByNames returns the roles among names that exist, unknown names are skipped.
+	ListByNames(ctx context.Context, names []string) ([]Role, error)
+	Create(ctx context.Context, input *CreateRoleInput) error
+	Update(ctx context.Context, input *UpdateRoleInput) error
+	Delete(ctx context.Context, input *DeleteRoleInput) error
+}
@@ -0,0 +1,48 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"slices"
+	"time"
+)
+
+const (
+	PermissionUsersRead      = "users:read"
+	PermissionUsersWrite     = "users:write"
+	PermissionAdminsWrite    = "admins:write"
+	PermissionGroupsWrite    = "groups:write"
+	PermissionMFAAdminRemove = "mfa:admin-remove"
+	PermissionClientsManage  = "clients:manage"
+	PermissionRolesManage    = "roles:manage"
+	PermissionSagasManage    = "sagas:manage"
+
+	// RoleAdmin and RoleUser back the built-in Admin and User groups, they can be edited but not deleted.
+	RoleAdmin = "Admin"
+	RoleUser  = "User"
+)
+
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// IsBuiltin reports whether the role backs one of the groups every user pool starts with, or is one of the
+// organization roles every membership check relies on.
+func (r *Role) IsBuiltin() bool {
+	return IsBuiltin(r.Name)
+}
+
+func (r *Role) HasPermission(permission string) bool {
+	return slices.Contains(r.Permissions, permission)
+}
+
+func IsBuiltin(name string) bool {
+	switch name {
+	case RoleAdmin, RoleUser, organization.RoleOrgAdmin, organization.RoleOrgMember:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,11 @@
+package role
+
+import "context"
+
+type RoleService interface {
+	// HasPermission reports whether any of the roles grants the permission. Role names usually come
+	// from the groups claim of an access token.
+	HasPermission(ctx context.Context, roles []string, permission string) (bool, error)
+	// Invalidate drops the cached permissions after a role changed.
+	Invalidate()
+}
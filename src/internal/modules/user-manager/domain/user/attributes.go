@@ -0,0 +1,157 @@
+package user
+
+import (
+	"auth-api/src/pkg/app_error"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+var attributeNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,49}$`)
+
+// Attributes are the custom profile attributes of a user, stored as JSON. A nil value in an update removes the attribute.
+type Attributes map[string]interface{}
+
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *Attributes) Scan(value interface{}) error {
+	*a = Attributes{}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("unsupported attributes type %T", value)
+	}
+}
+
+// AttributeDefinition describes a custom profile attribute. Pattern only applies to string attributes.
+type AttributeDefinition struct {
+	Name     string        `json:"name"`
+	Type     AttributeType `json:"type"`
+	Required bool          `json:"required"`
+	Pattern  string        `json:"pattern,omitempty"`
+	// AdminOnly attributes can be read by their user but only set by admins.
+	AdminOnly bool `json:"adminOnly"`
+
+	pattern *regexp.Regexp
+}
+
+// AttributeSchema lists the custom attributes users can have, attributes outside of it are rejected.
+// A nil schema has no attributes.
+type AttributeSchema struct {
+	definitions map[string]AttributeDefinition
+}
+
+func NewAttributeSchema(definitions []AttributeDefinition) (*AttributeSchema, error) {
+	schema := &AttributeSchema{definitions: make(map[string]AttributeDefinition, len(definitions))}
+	for _, definition := range definitions {
+		if !attributeNameRegex.MatchString(definition.Name) {
+			return nil, fmt.Errorf("invalid attribute name %q", definition.Name)
+		}
+		if _, exists := schema.definitions[definition.Name]; exists {
+			return nil, fmt.Errorf("attribute %q is defined twice", definition.Name)
+		}
+		switch definition.Type {
+		case AttributeString, AttributeNumber, AttributeBoolean:
+		default:
+			return nil, fmt.Errorf("invalid type %q for attribute %q", definition.Type, definition.Name)
+		}
+		if definition.Pattern != "" {
+			if definition.Type != AttributeString {
+				return nil, fmt.Errorf("attribute %q has a pattern but is not a string", definition.Name)
+			}
+			pattern, err := regexp.Compile(definition.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for attribute %q: %w", definition.Name, err)
+			}
+			definition.pattern = pattern
+		}
+		schema.definitions[definition.Name] = definition
+	}
+	return schema, nil
+}
+
+// ValidateUpdate checks the attributes being set or removed, admin tells whether admin-only attributes can be changed.
+func (s *AttributeSchema) ValidateUpdate(attributes Attributes, admin bool) error {
+	for name, value := range attributes {
+		definition, ok := s.definition(name)
+		if !ok {
+			return app_error.NewApiError(http.StatusBadRequest, "Unknown attribute", fmt.Sprintf("Field: attributes.%s", name))
+		}
+		if definition.AdminOnly && !admin {
+			return app_error.NewApiError(http.StatusForbidden, "Attribute can only be set by admins", fmt.Sprintf("Field: attributes.%s", name))
+		}
+		if value == nil {
+			if definition.Required {
+				return app_error.NewApiError(http.StatusBadRequest, "Attribute is required", fmt.Sprintf("Field: attributes.%s", name))
+			}
+			continue
+		}
+		if err := definition.validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateNew checks the attributes of a new user, the required ones that users can set must be present.
+func (s *AttributeSchema) ValidateNew(attributes Attributes, admin bool) error {
+	if s != nil {
+		for name, definition := range s.definitions {
+			if definition.Required && (admin || !definition.AdminOnly) && attributes[name] == nil {
+				return app_error.NewApiError(http.StatusBadRequest, "Attribute is required", fmt.Sprintf("Field: attributes.%s", name))
+			}
+		}
+	}
+	return s.ValidateUpdate(attributes, admin)
+}
+
+func (s *AttributeSchema) definition(name string) (AttributeDefinition, bool) {
+	if s == nil {
+		return AttributeDefinition{}, false
+	}
+	definition, ok := s.definitions[name]
+	return definition, ok
+}
+
+func (d AttributeDefinition) validate(value interface{}) error {
+	field := fmt.Sprintf("Field: attributes.%s", d.Name)
+	switch d.Type {
+	case AttributeString:
+		str, ok := value.(string)
+		if !ok {
+			return app_error.NewApiError(http.StatusBadRequest, "Attribute must be a string", field)
+		}
+		if d.pattern != nil && !d.pattern.MatchString(str) {
+			return app_error.NewApiError(http.StatusBadRequest, "Invalid attribute format", field)
+		}
+	case AttributeNumber:
+		if _, ok := value.(float64); !ok {
+			return app_error.NewApiError(http.StatusBadRequest, "Attribute must be a number", field)
+		}
+	case AttributeBoolean:
+		if _, ok := value.(bool); !ok {
+			return app_error.NewApiError(http.StatusBadRequest, "Attribute must be a boolean", field)
+		}
+	}
+	return nil
+}
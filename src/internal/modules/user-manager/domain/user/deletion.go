@@ -0,0 +1,24 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// PendingDeletion is an account deletion requested by its owner, run once ScheduledFor is reached unless cancelled.
+type PendingDeletion struct {
+	UserID       UserID    `json:"userId"`
+	Email        string    `json:"email"`
+	RequestedAt  time.Time `json:"requestedAt"`
+	ScheduledFor time.Time `json:"scheduledFor"`
+}
+
+type DeletionRepository interface {
+	Get(ctx context.Context, userID UserID) (*PendingDeletion, error)
+	// Create fails with ErrDeletionAlreadyRequested if the user already has a pending deletion.
+	Create(ctx context.Context, deletion *PendingDeletion) error
+	Delete(ctx context.Context, userID UserID) error
+	// ClaimDue leases and returns the oldest deletions scheduled before now that no one holds, a lease left
+	// by a stopped instance expires after lease.
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*PendingDeletion, error)
+}
@@ -0,0 +1,20 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// EmailChange is a new email requested by a user, it replaces the current one once the code sent to it is confirmed.
+type EmailChange struct {
+	UserID      UserID    `json:"userId"`
+	NewEmail    string    `json:"newEmail"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+type EmailChangeRepository interface {
+	Get(ctx context.Context, userID UserID) (*EmailChange, error)
+	// Save replaces the pending change of the user, if any.
+	Save(ctx context.Context, change *EmailChange) error
+	Delete(ctx context.Context, userID UserID) error
+}
@@ -3,7 +3,28 @@ package user
 import "auth-api/src/pkg/app_error"
 
 var (
-	ErrUserNotFound      = app_error.NewApiError(404, "User not found", "Field: id")
-	ErrUserAlreadyExists = app_error.NewApiError(409, "User already exists", "Field: email")
-	ErrInvalidEmail      = app_error.NewApiError(400, "Invalid email", "Field: email")
+	ErrUserNotFound              = app_error.NewApiError(404, "User not found", "Field: id")
+	ErrUserAlreadyExists         = app_error.NewApiError(409, "User already exists", "Field: email")
+	ErrInvalidEmail              = app_error.NewApiError(400, "Invalid email", "Field: email")
+	ErrDeletionNotFound          = app_error.NewApiError(404, "No account deletion pending")
+	ErrDeletionAlreadyRequested  = app_error.NewApiError(409, "Account deletion already requested")
+	ErrMFACodeRequired           = app_error.NewApiError(401, "MFA code required", "Field: mfaCode")
+	ErrReauthenticationFailed    = app_error.NewApiError(401, "Re-authentication failed")
+	ErrOwnAccount                = app_error.NewApiError(400, "Admins cannot run this action on their own account", "Field: id")
+	ErrAdminAccount              = app_error.NewApiError(403, "Acting on an admin account requires the admins:write permission", "Field: id")
+	ErrEmailChangeNotFound       = app_error.NewApiError(404, "No email change pending")
+	ErrEmailUnchanged            = app_error.NewApiError(400, "The new email is the current email", "Field: email")
+	ErrEmailChangeNotAllowed     = app_error.NewApiError(400, "The email can only be changed through the email change flow", "Field: email")
+	ErrPhoneMissing              = app_error.NewApiError(400, "No phone number set", "Field: phone")
+	ErrPhoneAlreadyVerified      = app_error.NewApiError(409, "Phone number already verified", "Field: phone")
+	ErrPhoneChanged              = app_error.NewApiError(409, "The phone number changed, request a new code", "Field: phone")
+	ErrPhoneNotE164              = app_error.NewApiError(400, "The phone number is not in the E.164 format, update it first", "Field: phone")
+	ErrTooManyPhoneVerifications = app_error.NewApiError(429, "Too many verification codes sent, try again later", "Field: phone")
+	ErrInvalidImportFormat       = app_error.NewApiError(400, "Invalid import format, use csv or jsonl", "Field: format")
+	ErrInvalidImportFile         = app_error.NewApiError(400, "Invalid import file, a CSV file needs a header with the email and name columns")
+	ErrEmptyImport               = app_error.NewApiError(400, "The import file has no users")
+	ErrImportTooLarge            = app_error.NewApiError(413, "Too many users in the import file")
+	ErrImportFileTooLarge        = app_error.NewApiError(413, "The import file is too large")
+	ErrInvalidExportFormat       = app_error.NewApiError(400, "Invalid export format, use csv or ndjson", "Field: format")
+	ErrInvalidExportField        = app_error.NewApiError(400, "Invalid export field", "Field: fields")
 )
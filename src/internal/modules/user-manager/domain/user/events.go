@@ -3,10 +3,34 @@ package user
 import "auth-api/src/internal/events"
 
 const (
-	UserRegistered events.EventType = "UserRegistered"
+	UserRegistered   events.EventType = "UserRegistered"
+	UserEmailChanged events.EventType = "UserEmailChanged"
 	// UserConfirmed  events.EventType = "UserConfirmed"
 )
 
+const (
+	UserDisabled            events.EventType = "UserDisabled"
+	UserEnabled             events.EventType = "UserEnabled"
+	UserPasswordResetForced events.EventType = "UserPasswordResetForced"
+	UserConfirmedByAdmin    events.EventType = "UserConfirmedByAdmin"
+	UserConfirmationResent  events.EventType = "UserConfirmationResent"
+	UserDeleted             events.EventType = "UserDeleted"
+	UserDeletionRequested   events.EventType = "UserDeletionRequested"
+	UserDeletionCancelled   events.EventType = "UserDeletionCancelled"
+)
+
+// AccountEventTypes are the account lifecycle events, all carried by UserAccountEvent.
+var AccountEventTypes = []events.EventType{
+	UserDisabled,
+	UserEnabled,
+	UserPasswordResetForced,
+	UserConfirmedByAdmin,
+	UserConfirmationResent,
+	UserDeleted,
+	UserDeletionRequested,
+	UserDeletionCancelled,
+}
+
 type UserRegisteredEvent struct {
 	Email             string
 	NeedsVerification bool
@@ -22,3 +46,40 @@ func (e *UserRegisteredEvent) Validate() error {
 	}
 	return nil
 }
+
+// UserEmailChangedEvent is dispatched once a user confirmed a new email, OldEmail is notified of the change.
+type UserEmailChangedEvent struct {
+	UserID   string
+	OldEmail string
+	NewEmail string
+}
+
+func (e *UserEmailChangedEvent) GetType() events.EventType {
+	return UserEmailChanged
+}
+
+func (e *UserEmailChangedEvent) Validate() error {
+	if e.OldEmail == "" || e.NewEmail == "" {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// UserAccountEvent records a change made to an account, PerformedBy is the ID of the acting user.
+type UserAccountEvent struct {
+	Type        events.EventType
+	UserID      string
+	Email       string
+	PerformedBy string
+}
+
+func (e *UserAccountEvent) GetType() events.EventType {
+	return e.Type
+}
+
+func (e *UserAccountEvent) Validate() error {
+	if e.Email == "" {
+		return ErrInvalidEmail
+	}
+	return nil
+}
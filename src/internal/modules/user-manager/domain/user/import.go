@@ -0,0 +1,181 @@
+package user
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV   ImportFormat = "csv"
+	ImportJSONL ImportFormat = "jsonl"
+)
+
+type ImportRowStatus string
+
+const (
+	ImportRowCreated ImportRowStatus = "created"
+	// ImportRowValid rows passed the checks of a dry run.
+	ImportRowValid  ImportRowStatus = "valid"
+	ImportRowFailed ImportRowStatus = "failed"
+)
+
+// ImportRow is a user to import, Line is its line in the file. Rows that could not be read carry Err.
+type ImportRow struct {
+	Line       int        `json:"-"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	Phone      *string    `json:"phone,omitempty"`
+	Password   string     `json:"password,omitempty"`
+	Attributes Attributes `json:"attributes,omitempty"`
+	Err        error      `json:"-"`
+}
+
+type ImportRowResult struct {
+	Line   int             `json:"line"`
+	Email  string          `json:"email"`
+	Status ImportRowStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dryRun"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Valid   int               `json:"valid"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// ParseImport reads the users of a CSV file, with a header row naming the email, name, phone, password and
+// attributes (a JSON object) columns, or of a JSON lines file. Rows that cannot be read are returned with Err.
+// Reading stops with ErrImportTooLarge as soon as the file has more than maxRows rows, 0 or less reads them all.
+func ParseImport(r io.Reader, format ImportFormat, maxRows int) ([]ImportRow, error) {
+	switch format {
+	case ImportCSV:
+		return parseImportCSV(r, maxRows)
+	case ImportJSONL:
+		return parseImportJSONL(r, maxRows)
+	default:
+		return nil, ErrInvalidImportFormat
+	}
+}
+
+func parseImportCSV(r io.Reader, maxRows int) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ErrEmptyImport
+		}
+		return nil, ErrInvalidImportFile
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, ErrInvalidImportFile
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, ErrInvalidImportFile
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, ImportRow{Line: parseErr.Line, Err: err})
+			if tooMany(rows, maxRows) {
+				return nil, ErrImportTooLarge
+			}
+			continue
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := ImportRow{
+			Line:     line,
+			Email:    get("email"),
+			Name:     get("name"),
+			Password: get("password"),
+		}
+		if phone := get("phone"); phone != "" {
+			row.Phone = &phone
+		}
+		if attributes := get("attributes"); attributes != "" {
+			if err := json.Unmarshal([]byte(attributes), &row.Attributes); err != nil {
+				row.Err = fmt.Errorf("invalid attributes: %w", err)
+			}
+		}
+		rows = append(rows, row)
+		if tooMany(rows, maxRows) {
+			return nil, ErrImportTooLarge
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	return rows, nil
+}
+
+// tooMany reports whether the rows read so far exceed maxRows, 0 or less being no limit.
+func tooMany(rows []ImportRow, maxRows int) bool {
+	return maxRows > 0 && len(rows) > maxRows
+}
+
+func parseImportJSONL(r io.Reader, maxRows int) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := ImportRow{Line: line}
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			row.Err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		row.Line = line
+		rows = append(rows, row)
+		if tooMany(rows, maxRows) {
+			return nil, ErrImportTooLarge
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			return nil, ErrInvalidImportFile
+		}
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	return rows, nil
+}
@@ -1,18 +1,24 @@
 package user
 
 import (
+	"auth-api/src/internal/modules/user-manager/domain/principal"
 	"auth-api/src/pkg/app_error"
 	"auth-api/src/pkg/validator"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type CreateUserInput struct {
-	ID    UserID
-	Name  string
-	Email string
-	Phone *string
+	ID         UserID
+	Name       string
+	Email      string
+	Phone      *string
+	Attributes Attributes
+	// Schema validates Attributes when set, Admin allows setting the admin-only attributes.
+	Schema *AttributeSchema
+	Admin  bool
 }
 
 func (input *CreateUserInput) Validate() error {
@@ -33,8 +39,16 @@ func (input *CreateUserInput) Validate() error {
 	}
 
 	if input.Phone != nil {
-		if err := validator.ValidateStringLength(*input.Phone, 10, 15); err != nil {
-			return app_error.NewApiError(http.StatusBadRequest, "Invalid phone number length", fmt.Sprintf("Field: %s", "Phone"))
+		phone, err := validatePhone(*input.Phone)
+		if err != nil {
+			return err
+		}
+		input.Phone = &phone
+	}
+
+	if input.Schema != nil {
+		if err := input.Schema.ValidateNew(input.Attributes, input.Admin); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -45,6 +59,11 @@ type UpdateUserInput struct {
 	Name  *string
 	Email *string
 	Phone *string
+	// Attributes are merged into the current ones, a nil value removes the attribute.
+	Attributes Attributes
+	// Schema validates Attributes, Admin allows changing the admin-only attributes.
+	Schema *AttributeSchema
+	Admin  bool
 }
 
 func (input *UpdateUserInput) Validate() error {
@@ -69,8 +88,16 @@ func (input *UpdateUserInput) Validate() error {
 	}
 
 	if input.Phone != nil {
-		if err := validator.ValidateStringLength(*input.Phone, 10, 15); err != nil {
-			return app_error.NewApiError(http.StatusBadRequest, "Invalid phone number length", fmt.Sprintf("Field: %s", "Phone"))
+		phone, err := validatePhone(*input.Phone)
+		if err != nil {
+			return err
+		}
+		input.Phone = &phone
+	}
+
+	if input.Attributes != nil {
+		if err := input.Schema.ValidateUpdate(input.Attributes, input.Admin); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -114,6 +141,56 @@ func (input *GetUserByEmailInput) Validate() error {
 	return nil
 }
 
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+type ListUsersInput struct {
+	Cursor        string
+	Limit         int
+	Search        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// Emails restricts the page to these users when not nil.
+	Emails []string
+
+	cursor *principal.Cursor
+}
+
+func (input *ListUsersInput) Validate() error {
+	if input.Limit == 0 {
+		input.Limit = DefaultListLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxListLimit {
+		return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", MaxListLimit), fmt.Sprintf("Field: %s", "Limit"))
+	}
+
+	input.cursor = nil
+	if input.Cursor != "" {
+		cursor, err := principal.ParseCursor(input.Cursor)
+		if err != nil {
+			return err
+		}
+		input.cursor = cursor
+	}
+
+	input.Search = strings.TrimSpace(input.Search)
+	if err := validator.ValidateStringLength(input.Search, 0, 100); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid search length", fmt.Sprintf("Field: %s", "Search"))
+	}
+
+	if input.CreatedAfter != nil && input.CreatedBefore != nil && !input.CreatedAfter.Before(*input.CreatedBefore) {
+		return app_error.NewApiError(http.StatusBadRequest, "createdAfter must be before createdBefore", fmt.Sprintf("Field: %s", "CreatedAfter"))
+	}
+	return nil
+}
+
+// After returns the decoded cursor, nil for the first page.
+func (input *ListUsersInput) After() *principal.Cursor {
+	return input.cursor
+}
+
 func validateEmail(email string) (string, error) {
 	lowerCaseEmail := strings.ToLower(email)
 	if err := validator.ValidateEmail(lowerCaseEmail); err != nil {
@@ -121,3 +198,142 @@ func validateEmail(email string) (string, error) {
 	}
 	return lowerCaseEmail, nil
 }
+
+func validatePhone(phone string) (string, error) {
+	normalizedPhone, err := validator.NormalizePhone(phone)
+	if err != nil {
+		return "", app_error.NewApiError(http.StatusBadRequest, "Invalid phone number, use the E.164 format", fmt.Sprintf("Field: %s", "Phone"))
+	}
+	return normalizedPhone, nil
+}
+
+type DeleteAccountInput struct {
+	ID       UserID
+	Username string
+	Password string
+	MFACode  string
+}
+
+func (input *DeleteAccountInput) Validate() error {
+	userID, err := ParseUserID(input.ID.String())
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	input.ID = userID
+
+	lowerCaseEmail, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = lowerCaseEmail
+
+	if input.Password == "" {
+		return app_error.NewApiError(http.StatusBadRequest, "Password is required", fmt.Sprintf("Field: %s", "Password"))
+	}
+	return nil
+}
+
+// RequestEmailChangeInput re-authenticates the user with Username, Password and MFACode before the change.
+type RequestEmailChangeInput struct {
+	ID       UserID
+	NewEmail string
+	Username string
+	Password string
+	MFACode  string
+}
+
+func (input *RequestEmailChangeInput) Validate() error {
+	userID, err := ParseUserID(input.ID.String())
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	input.ID = userID
+
+	lowerCaseEmail, err := validateEmail(input.NewEmail)
+	if err != nil {
+		return err
+	}
+	input.NewEmail = lowerCaseEmail
+
+	username, err := validateEmail(input.Username)
+	if err != nil {
+		return err
+	}
+	input.Username = username
+
+	if input.Password == "" {
+		return app_error.NewApiError(http.StatusBadRequest, "Password is required", fmt.Sprintf("Field: %s", "Password"))
+	}
+	return nil
+}
+
+type ConfirmEmailChangeInput struct {
+	ID   UserID
+	Code string
+}
+
+func (input *ConfirmEmailChangeInput) Validate() error {
+	userID, err := ParseUserID(input.ID.String())
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	input.ID = userID
+
+	if err := validator.ValidateNumeric(input.Code); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid code", fmt.Sprintf("Field: %s", "Code"))
+	}
+	return nil
+}
+
+// RecordLoginInput sets the last login of the user with Email to now.
+type RecordLoginInput struct {
+	Email string
+}
+
+func (input *RecordLoginInput) Validate() error {
+	email, err := validateEmail(input.Email)
+	if err != nil {
+		return err
+	}
+	input.Email = email
+	return nil
+}
+
+// MarkPhoneVerifiedInput marks Phone as verified, as long as it is still the phone of the user.
+type MarkPhoneVerifiedInput struct {
+	ID    UserID
+	Phone string
+}
+
+func (input *MarkPhoneVerifiedInput) Validate() error {
+	userID, err := ParseUserID(input.ID.String())
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	input.ID = userID
+
+	phone, err := validatePhone(input.Phone)
+	if err != nil {
+		return err
+	}
+	input.Phone = phone
+	return nil
+}
+
+type VerifyPhoneInput struct {
+	ID   UserID
+	Code string
+}
+
+func (input *VerifyPhoneInput) Validate() error {
+	userID, err := ParseUserID(input.ID.String())
+	if err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid user ID", fmt.Sprintf("Field: %s", "ID"))
+	}
+	input.ID = userID
+
+	if err := validator.ValidateNumeric(input.Code); err != nil {
+		return app_error.NewApiError(http.StatusBadRequest, "Invalid code", fmt.Sprintf("Field: %s", "Code"))
+	}
+	return nil
+}
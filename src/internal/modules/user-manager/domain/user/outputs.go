@@ -28,7 +28,7 @@ func (u *UpdateUserOutput) Rollback(ctx context.Context) error {
 		return err
 	}
 
-	_, err := u.svc.Update(updateUserInput)
+	_, err := u.svc.Update(ctx, updateUserInput)
 	if err != nil {
 		return err
 	}
@@ -59,7 +59,7 @@ func (c *CreateUserOutput) Rollback(ctx context.Context) error {
 		return err
 	}
 
-	_, err := c.svc.Delete(deleteUserInput)
+	_, err := c.svc.Delete(ctx, deleteUserInput)
 	if err != nil {
 		return err
 	}
@@ -68,13 +68,13 @@ func (c *CreateUserOutput) Rollback(ctx context.Context) error {
 
 type DeleteUserOutput struct {
 	backup *User
-	repo   UserRepository
+	svc    UserService
 }
 
-func NewDeleteUserOutput(backup *User, repo UserRepository) *DeleteUserOutput {
+func NewDeleteUserOutput(backup *User, svc UserService) *DeleteUserOutput {
 	return &DeleteUserOutput{
 		backup: backup,
-		repo:   repo,
+		svc:    svc,
 	}
 }
 
@@ -83,15 +83,17 @@ func (d *DeleteUserOutput) Rollback(ctx context.Context) error {
 		return nil
 	}
 	createUserInput := &CreateUserInput{
-		Email: d.backup.Email,
-		Name:  d.backup.Name,
-		Phone: d.backup.Phone,
+		ID:         d.backup.ID,
+		Email:      d.backup.Email,
+		Name:       d.backup.Name,
+		Phone:      d.backup.Phone,
+		Attributes: d.backup.Attributes,
 	}
 	if err := createUserInput.Validate(); err != nil {
 		return err
 	}
 
-	err := d.repo.Create(createUserInput)
+	_, err := d.svc.Create(ctx, createUserInput)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,12 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+type PhoneVerificationRepository interface {
+	// RecordSend records a verification text to the phone of the user, unless the user or the phone already got
+	// maxSends within window, in which case it returns false. The sends older than window are dropped.
+	RecordSend(ctx context.Context, userID UserID, phone string, window time.Duration, maxSends int) (bool, error)
+}
@@ -1,9 +1,14 @@
 package user
 
+import "context"
+
 type UserService interface {
-	GetByID(input *GetUserInput) (*User, error)
-	GetByEmail(input *GetUserByEmailInput) (*User, error)
-	Create(input *CreateUserInput) (*CreateUserOutput, error)
-	Update(input *UpdateUserInput) (*UpdateUserOutput, error)
-	Delete(input *DeleteUserInput) (*DeleteUserOutput, error)
+	GetByID(ctx context.Context, input *GetUserInput) (*User, error)
+	List(ctx context.Context, input *ListUsersInput) (*UserPage, error)
+	GetByEmail(ctx context.Context, input *GetUserByEmailInput) (*User, error)
+	Create(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error)
+	Update(ctx context.Context, input *UpdateUserInput) (*UpdateUserOutput, error)
+	MarkPhoneVerified(ctx context.Context, input *MarkPhoneVerifiedInput) error
+	RecordLogin(ctx context.Context, input *RecordLoginInput) error
+	Delete(ctx context.Context, input *DeleteUserInput) (*DeleteUserOutput, error)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -28,6 +29,17 @@ type User struct {
 	Name  string  `json:"name"`
 	Email string  `json:"email"`
 	Phone *string `json:"phone,omitempty"`
+	// PhoneVerified is reset whenever the phone changes.
+	PhoneVerified bool       `json:"phoneVerified"`
+	Attributes    Attributes `json:"attributes"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	LastLoginAt   *time.Time `json:"lastLoginAt,omitempty"`
+}
+
+// UserPage is a page of users sorted from the newest, NextCursor is empty on the last page.
+type UserPage struct {
+	Users      []*User
+	NextCursor string
 }
 
 func (id *UserID) Scan(value interface{}) error {
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	export_usecases "auth-api/src/internal/modules/user-manager/usecases/export"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+// GenerateExportHandler generates the requested data exports in the background.
+type GenerateExportHandler struct {
+	logger         logger.Logger
+	generateExport *export_usecases.GenerateExportUseCase
+}
+
+func NewGenerateExportHandler(logger logger.Logger, exportUseCases export_usecases.UseCases) events.EventHandler {
+	return &GenerateExportHandler{
+		logger:         logger,
+		generateExport: exportUseCases.GenerateExport,
+	}
+}
+
+func (h *GenerateExportHandler) Handle(event events.Event) error {
+	requested, ok := event.(*export.DataExportRequestedEvent)
+	if !ok {
+		return nil
+	}
+
+	if err := h.generateExport.Execute(context.Background(), export_usecases.GenerateExportInput{
+		ExportID: requested.ExportID,
+		UserID:   requested.UserID,
+	}); err != nil {
+		h.logger.Error("failed to generate data export %s: %v", requested.ExportID, err)
+		return err
+	}
+	return nil
+}
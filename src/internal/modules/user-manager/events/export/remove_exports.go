@@ -0,0 +1,35 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+// RemoveExportsHandler removes the data exports of deleted users.
+type RemoveExportsHandler struct {
+	logger  logger.Logger
+	exports export.ExportRepository
+}
+
+func NewRemoveExportsHandler(logger logger.Logger, exports export.ExportRepository) events.EventHandler {
+	return &RemoveExportsHandler{
+		logger:  logger,
+		exports: exports,
+	}
+}
+
+func (h *RemoveExportsHandler) Handle(event events.Event) error {
+	accountEvent, ok := event.(*user.UserAccountEvent)
+	if !ok || accountEvent.Type != user.UserDeleted {
+		return nil
+	}
+
+	if err := h.exports.DeleteByUser(context.TODO(), accountEvent.UserID); err != nil {
+		h.logger.Error("failed to remove data exports of deleted user %s: %v", accountEvent.UserID, err)
+		return err
+	}
+	return nil
+}
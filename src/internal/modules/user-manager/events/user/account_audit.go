@@ -0,0 +1,32 @@
+package user
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+)
+
+// AccountAuditHandler writes the account lifecycle events to the logs.
+type AccountAuditHandler struct {
+	logger logger.Logger
+}
+
+func NewAccountAuditHandler(logger logger.Logger) events.EventHandler {
+	return &AccountAuditHandler{
+		logger: logger,
+	}
+}
+
+func (h *AccountAuditHandler) Handle(event events.Event) error {
+	accountEvent, ok := event.(*user.UserAccountEvent)
+	if !ok {
+		return nil
+	}
+
+	if err := accountEvent.Validate(); err != nil {
+		return err
+	}
+
+	h.logger.Info("%s: user %s (%s) by %s", accountEvent.Type, accountEvent.UserID, accountEvent.Email, accountEvent.PerformedBy)
+	return nil
+}
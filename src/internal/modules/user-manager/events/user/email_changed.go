@@ -0,0 +1,46 @@
+package user
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/pkg/logger"
+	"context"
+	"fmt"
+)
+
+// EmailChangedHandler tells the previous email of a user that it is no longer attached to the account.
+type EmailChangedHandler struct {
+	logger       logger.Logger
+	emailService email.EmailService
+}
+
+func NewEmailChangedHandler(logger logger.Logger, emailService email.EmailService) events.EventHandler {
+	return &EmailChangedHandler{
+		logger:       logger,
+		emailService: emailService,
+	}
+}
+
+func (h *EmailChangedHandler) Handle(event events.Event) error {
+	emailChangedEvent, ok := event.(*user.UserEmailChangedEvent)
+	if !ok {
+		return nil
+	}
+
+	if err := emailChangedEvent.Validate(); err != nil {
+		return err
+	}
+
+	h.logger.Info("%s: user %s (%s -> %s)", user.UserEmailChanged, emailChangedEvent.UserID, emailChangedEvent.OldEmail, emailChangedEvent.NewEmail)
+
+	if err := h.emailService.SendEmail(context.TODO(), email.Email{
+		To:      emailChangedEvent.OldEmail,
+		Subject: "Your email was changed",
+		Body:    fmt.Sprintf("The email of your account was changed to %s. If you did not make this change, contact support right away.", emailChangedEvent.NewEmail),
+	}); err != nil {
+		h.logger.Error("failed to notify the previous email of user %s: %v", emailChangedEvent.UserID, err)
+		return err
+	}
+	return nil
+}
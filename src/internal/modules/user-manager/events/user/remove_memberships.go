@@ -0,0 +1,53 @@
+package user
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"context"
+	"time"
+)
+
+// removeMembershipsTimeout bounds the removal, the handler runs outside of any request.
+const removeMembershipsTimeout = 30 * time.Second
+
+// RemoveMembershipsHandler removes the organization memberships of deleted users. The last OrgAdmin of
+// an organization is refused before the deletion, so no organization is left without one.
+type RemoveMembershipsHandler struct {
+	logger  logger.Logger
+	members organization.MemberRepository
+}
+
+func NewRemoveMembershipsHandler(logger logger.Logger, members organization.MemberRepository) events.EventHandler {
+	return &RemoveMembershipsHandler{
+		logger:  logger,
+		members: members,
+	}
+}
+
+func (h *RemoveMembershipsHandler) Handle(event events.Event) error {
+	accountEvent, ok := event.(*user.UserAccountEvent)
+	if !ok || accountEvent.Type != user.UserDeleted {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), removeMembershipsTimeout)
+	defer cancel()
+
+	memberships, err := h.members.ListMemberships(ctx, &organization.ListMembershipsInput{UserID: accountEvent.UserID})
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if err := h.members.Remove(ctx, &organization.RemoveMemberInput{
+			OrganizationID: membership.Organization.ID,
+			UserID:         accountEvent.UserID,
+		}); err != nil && err != organization.ErrMemberNotFound {
+			h.logger.Error("failed to remove membership of deleted user %s: %v", accountEvent.UserID, err)
+			return err
+		}
+	}
+	return nil
+}
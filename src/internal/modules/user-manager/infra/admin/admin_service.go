@@ -2,115 +2,157 @@ package admin
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/principal"
+	"auth-api/src/internal/modules/user-manager/domain/role"
 	"auth-api/src/pkg/logger"
+	"context"
+	"slices"
 )
 
+// AdminService stores the admin profiles as the principals holding the Admin role.
 type AdminService struct {
-	repo   admin.AdminRepository
-	logger logger.Logger
+	principals principal.PrincipalRepository
+	logger     logger.Logger
 }
 
-func NewAdminService(repo admin.AdminRepository, logger logger.Logger) admin.AdminService {
+func NewAdminService(principals principal.PrincipalRepository, logger logger.Logger) admin.AdminService {
 	return &AdminService{
-		repo:   repo,
-		logger: logger,
+		principals: principals,
+		logger:     logger,
 	}
 }
 
-func (a *AdminService) GetByID(input *admin.GetAdminInput) (*admin.Admin, error) {
+func (a *AdminService) GetByID(ctx context.Context, input *admin.GetAdminInput) (*admin.Admin, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	return a.repo.GetByID(input)
+	return a.get(ctx, &principal.GetPrincipalInput{ID: input.ID, Role: role.RoleAdmin})
 }
 
-func (a *AdminService) GetByEmail(email *admin.GetAdminByEmailInput) (*admin.Admin, error) {
+func (a *AdminService) GetByEmail(ctx context.Context, email *admin.GetAdminByEmailInput) (*admin.Admin, error) {
 	if err := email.Validate(); err != nil {
 		return nil, err
 	}
 
-	return a.repo.GetByEmail(email)
+	return a.get(ctx, &principal.GetPrincipalInput{Email: email.Email, Role: role.RoleAdmin})
 }
 
-func (a *AdminService) Create(input *admin.CreateAdminInput) (*admin.CreateAdminOutput, error) {
+func (a *AdminService) List(ctx context.Context, input *admin.ListAdminsInput) (*admin.AdminPage, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	getAdminByEmailInput := admin.GetAdminByEmailInput{Email: input.Email}
-	if err := getAdminByEmailInput.Validate(); err != nil {
+	page, err := a.principals.List(ctx, &principal.ListPrincipalsInput{Role: role.RoleAdmin, After: input.After(), Limit: input.Limit})
+	if err != nil {
+		return nil, err
+	}
+
+	admins := &admin.AdminPage{Admins: make([]*admin.Admin, len(page.Principals)), NextCursor: page.NextCursor}
+	for i, p := range page.Principals {
+		adm, err := toAdmin(p)
+		if err != nil {
+			return nil, err
+		}
+		admins.Admins[i] = adm
+	}
+	return admins, nil
+}
+
+// Create gives the Admin role to the principal, a user keeping its profile. The email can only belong to the
+// principal itself.
+func (a *AdminService) Create(ctx context.Context, input *admin.CreateAdminInput) (*admin.CreateAdminOutput, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	adminExists, err := a.repo.GetByEmail(&getAdminByEmailInput)
+	existing, err := a.principals.Get(ctx, &principal.GetPrincipalInput{Email: input.Email})
 	if err != nil {
-		if err != admin.ErrAdminNotFound {
+		if err != principal.ErrPrincipalNotFound {
 			return nil, err
 		}
 	}
 
-	if adminExists != nil {
+	if existing != nil && (existing.ID != input.ID.String() || slices.Contains(existing.Roles, role.RoleAdmin)) {
 		return nil, admin.ErrAdminAlreadyExists
 	}
 
 	out := admin.NewCreateAdminOutput(&input.ID, a)
 
-	if err := a.repo.Create(input); err != nil {
+	grantRoleInput := &principal.GrantRoleInput{
+		Principal: &principal.Principal{
+			ID:    input.ID.String(),
+			Name:  input.Name,
+			Email: input.Email,
+		},
+		Role: role.RoleAdmin,
+	}
+	if err := a.principals.Grant(ctx, grantRoleInput); err != nil {
 		return nil, err
 	}
 
 	return out, nil
 }
 
-func (a *AdminService) Update(input *admin.UpdateAdminInput) (*admin.UpdateAdminOutput, error) {
+func (a *AdminService) Update(ctx context.Context, input *admin.UpdateAdminInput) (*admin.UpdateAdminOutput, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	getAdminInput := admin.GetAdminInput{ID: input.ID.String()}
-	if err := getAdminInput.Validate(); err != nil {
-		return nil, err
-	}
-
-	adminOut, err := a.repo.GetByID(&getAdminInput)
+	adminOut, err := a.get(ctx, &principal.GetPrincipalInput{ID: input.ID.String(), Role: role.RoleAdmin})
 	if err != nil {
 		return nil, err
 	}
-	if adminOut == nil {
-		return nil, admin.ErrAdminNotFound
-	}
 
 	out := admin.NewUpdateAdminOutput(adminOut, a)
 
-	return out, a.repo.Update(input)
+	updatePrincipalInput := &principal.UpdatePrincipalInput{
+		ID:    input.ID.String(),
+		Name:  input.Name,
+		Email: input.Email,
+	}
+	return out, a.principals.Update(ctx, updatePrincipalInput)
 }
 
-func (a *AdminService) Delete(id *admin.DeleteAdminInput) (*admin.DeleteAdminOutput, error) {
+// Delete takes the Admin role back, the profile is only deleted when the principal is not a user.
+func (a *AdminService) Delete(ctx context.Context, id *admin.DeleteAdminInput) (*admin.DeleteAdminOutput, error) {
 	if err := id.Validate(); err != nil {
 		return nil, err
 	}
 
-	getAdminInput := admin.GetAdminInput{ID: id.ID.String()}
-	if err := getAdminInput.Validate(); err != nil {
-		return nil, err
-	}
-
-	adminOut, err := a.repo.GetByID(&getAdminInput)
+	adminOut, err := a.get(ctx, &principal.GetPrincipalInput{ID: id.ID.String(), Role: role.RoleAdmin})
 	if err != nil {
 		return nil, err
 	}
 
-	if adminOut == nil {
-		return nil, admin.ErrAdminNotFound
-	}
-
-	out := admin.NewDeleteAdminOutput(adminOut, a.repo)
+	out := admin.NewDeleteAdminOutput(adminOut, a)
 
-	if err := a.repo.Delete(id); err != nil {
+	if err := a.principals.Revoke(ctx, &principal.RevokeRoleInput{ID: id.ID.String(), Role: role.RoleAdmin}); err != nil {
 		return nil, err
 	}
 
 	return out, nil
+}
 
+func (a *AdminService) get(ctx context.Context, input *principal.GetPrincipalInput) (*admin.Admin, error) {
+	p, err := a.principals.Get(ctx, input)
+	if err != nil {
+		if err == principal.ErrPrincipalNotFound {
+			return nil, admin.ErrAdminNotFound
+		}
+		return nil, err
+	}
+	return toAdmin(p)
+}
+
+func toAdmin(p *principal.Principal) (*admin.Admin, error) {
+	id, err := admin.ParseAdminID(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.Admin{
+		ID:    id,
+		Name:  p.Name,
+		Email: p.Email,
+	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -18,8 +19,43 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 )
 
+// maxGetUsersCalls bounds the concurrent AdminGetUser calls of GetUsers.
+const maxGetUsersCalls = 8
+
+// CognitoAPI is the subset of the Cognito identity provider client used by the auth service.
+type CognitoAPI interface {
+	InitiateAuth(ctx context.Context, params *cognito.InitiateAuthInput, optFns ...func(*cognito.Options)) (*cognito.InitiateAuthOutput, error)
+	RespondToAuthChallenge(ctx context.Context, params *cognito.RespondToAuthChallengeInput, optFns ...func(*cognito.Options)) (*cognito.RespondToAuthChallengeOutput, error)
+	SignUp(ctx context.Context, params *cognito.SignUpInput, optFns ...func(*cognito.Options)) (*cognito.SignUpOutput, error)
+	AdminConfirmSignUp(ctx context.Context, params *cognito.AdminConfirmSignUpInput, optFns ...func(*cognito.Options)) (*cognito.AdminConfirmSignUpOutput, error)
+	AdminCreateUser(ctx context.Context, params *cognito.AdminCreateUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminCreateUserOutput, error)
+	AdminDisableUser(ctx context.Context, params *cognito.AdminDisableUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminDisableUserOutput, error)
+	AdminEnableUser(ctx context.Context, params *cognito.AdminEnableUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminEnableUserOutput, error)
+	AdminResetUserPassword(ctx context.Context, params *cognito.AdminResetUserPasswordInput, optFns ...func(*cognito.Options)) (*cognito.AdminResetUserPasswordOutput, error)
+	AdminDeleteUser(ctx context.Context, params *cognito.AdminDeleteUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminDeleteUserOutput, error)
+	GetUser(ctx context.Context, params *cognito.GetUserInput, optFns ...func(*cognito.Options)) (*cognito.GetUserOutput, error)
+	AdminGetUser(ctx context.Context, params *cognito.AdminGetUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminGetUserOutput, error)
+	AdminAddUserToGroup(ctx context.Context, params *cognito.AdminAddUserToGroupInput, optFns ...func(*cognito.Options)) (*cognito.AdminAddUserToGroupOutput, error)
+	AdminRemoveUserFromGroup(ctx context.Context, params *cognito.AdminRemoveUserFromGroupInput, optFns ...func(*cognito.Options)) (*cognito.AdminRemoveUserFromGroupOutput, error)
+	ListUsers(ctx context.Context, params *cognito.ListUsersInput, optFns ...func(*cognito.Options)) (*cognito.ListUsersOutput, error)
+	ListUsersInGroup(ctx context.Context, params *cognito.ListUsersInGroupInput, optFns ...func(*cognito.Options)) (*cognito.ListUsersInGroupOutput, error)
+	AdminListGroupsForUser(ctx context.Context, params *cognito.AdminListGroupsForUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminListGroupsForUserOutput, error)
+	CreateGroup(ctx context.Context, params *cognito.CreateGroupInput, optFns ...func(*cognito.Options)) (*cognito.CreateGroupOutput, error)
+	DeleteGroup(ctx context.Context, params *cognito.DeleteGroupInput, optFns ...func(*cognito.Options)) (*cognito.DeleteGroupOutput, error)
+	AdminUpdateUserAttributes(ctx context.Context, params *cognito.AdminUpdateUserAttributesInput, optFns ...func(*cognito.Options)) (*cognito.AdminUpdateUserAttributesOutput, error)
+	AdminSetUserPassword(ctx context.Context, params *cognito.AdminSetUserPasswordInput, optFns ...func(*cognito.Options)) (*cognito.AdminSetUserPasswordOutput, error)
+	ChangePassword(ctx context.Context, params *cognito.ChangePasswordInput, optFns ...func(*cognito.Options)) (*cognito.ChangePasswordOutput, error)
+	GlobalSignOut(ctx context.Context, params *cognito.GlobalSignOutInput, optFns ...func(*cognito.Options)) (*cognito.GlobalSignOutOutput, error)
+	AdminUserGlobalSignOut(ctx context.Context, params *cognito.AdminUserGlobalSignOutInput, optFns ...func(*cognito.Options)) (*cognito.AdminUserGlobalSignOutOutput, error)
+	AssociateSoftwareToken(ctx context.Context, params *cognito.AssociateSoftwareTokenInput, optFns ...func(*cognito.Options)) (*cognito.AssociateSoftwareTokenOutput, error)
+	VerifySoftwareToken(ctx context.Context, params *cognito.VerifySoftwareTokenInput, optFns ...func(*cognito.Options)) (*cognito.VerifySoftwareTokenOutput, error)
+	SetUserMFAPreference(ctx context.Context, params *cognito.SetUserMFAPreferenceInput, optFns ...func(*cognito.Options)) (*cognito.SetUserMFAPreferenceOutput, error)
+	AdminSetUserMFAPreference(ctx context.Context, params *cognito.AdminSetUserMFAPreferenceInput, optFns ...func(*cognito.Options)) (*cognito.AdminSetUserMFAPreferenceOutput, error)
+	RevokeToken(ctx context.Context, params *cognito.RevokeTokenInput, optFns ...func(*cognito.Options)) (*cognito.RevokeTokenOutput, error)
+}
+
 type cognitoClient struct {
-	client     *cognito.Client
+	client     CognitoAPI
 	clientId   string
 	userPoolId string
 	jwtVerify  jwt_verify.JWTVerify
@@ -28,7 +64,7 @@ type cognitoClient struct {
 	code       code.CodeService
 }
 
-func NewAuthService(cognito *cognito.Client, clientId string, jwtVerify jwt_verify.JWTVerify, userPoolId string, logger logger.Logger, email email.EmailService, code code.CodeService) auth.AuthService {
+func NewAuthService(cognito CognitoAPI, clientId string, jwtVerify jwt_verify.JWTVerify, userPoolId string, logger logger.Logger, email email.EmailService, code code.CodeService) auth.AuthService {
 	return &cognitoClient{
 		client:     cognito,
 		clientId:   clientId,
@@ -208,6 +244,9 @@ func (c *cognitoClient) Login(ctx context.Context, input auth.LoginInput) (*auth
 	cognitoOut, err := c.client.InitiateAuth(ctx, initiateAuthInput)
 	if err != nil {
 		errorType := err.Error()
+		if strings.Contains(errorType, "User is disabled") {
+			return nil, auth.ErrUserDisabled
+		}
 		if strings.Contains(errorType, "NotAuthorizedException") {
 			return nil, auth.ErrInvalidUsernameOrPassword
 		}
@@ -348,7 +387,12 @@ func (c *cognitoClient) GetMe(ctx context.Context, input auth.GetMeInput) (*auth
 
 	out := &auth.GetMeOutput{
 		Username: *cognitoOut.Username,
-		Name:     *cognitoOut.UserAttributes[0].Value, //TODO: handle this better
+	}
+	for _, attr := range cognitoOut.UserAttributes {
+		if *attr.Name == "name" {
+			out.Name = *attr.Value
+			break
+		}
 	}
 
 	return out, nil
@@ -358,14 +402,10 @@ func (c *cognitoClient) ValidateToken(ctx context.Context, token string) (*auth.
 
 	_, claims, err := c.jwtVerify.ParseJWT(token)
 	if err != nil {
-		return nil, err
+		return nil, tokenError(err)
 	}
 
-	return &auth.Claims{
-		Email:      claims.Email,
-		Id:         claims.Sub,
-		UserGroups: claims.UserGroups,
-	}, nil
+	return claimsFromJWT(claims), nil
 }
 
 func (c *cognitoClient) AddGroup(ctx context.Context, input auth.AddGroupInput) error {
@@ -424,6 +464,93 @@ func (c *cognitoClient) RemoveGroup(ctx context.Context, input auth.RemoveGroupI
 	return nil
 }
 
+func (c *cognitoClient) CreateGroup(ctx context.Context, input auth.CreateGroupInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	createGroupInput := &cognito.CreateGroupInput{
+		UserPoolId: aws.String(c.userPoolId),
+		GroupName:  aws.String(string(input.GroupName)),
+	}
+	if input.Description != "" {
+		createGroupInput.Description = aws.String(input.Description)
+	}
+
+	_, err := c.client.CreateGroup(ctx, createGroupInput)
+	if err != nil {
+		if strings.Contains(err.Error(), "GroupExistsException") {
+			return nil
+		}
+		c.logger.Error("Cognito create group error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cognitoClient) DeleteGroup(ctx context.Context, input auth.DeleteGroupInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	deleteGroupInput := &cognito.DeleteGroupInput{
+		UserPoolId: aws.String(c.userPoolId),
+		GroupName:  aws.String(string(input.GroupName)),
+	}
+
+	_, err := c.client.DeleteGroup(ctx, deleteGroupInput)
+	if err != nil {
+		if strings.Contains(err.Error(), "ResourceNotFoundException") {
+			return nil
+		}
+		c.logger.Error("Cognito delete group error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cognitoClient) ListUserGroups(ctx context.Context, input auth.ListUserGroupsInput) ([]string, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	groups := []string{}
+	var nextToken *string
+	for {
+		out, err := c.client.AdminListGroupsForUser(ctx, &cognito.AdminListGroupsForUserInput{
+			UserPoolId: aws.String(c.userPoolId),
+			Username:   aws.String(input.Username),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), "UserNotFoundException") {
+				return nil, auth.ErrUserNotFound
+			}
+			c.logger.Error("Cognito list groups for user error", err)
+			return nil, err
+		}
+
+		for _, group := range out.Groups {
+			groups = append(groups, aws.ToString(group.GroupName))
+		}
+		if out.NextToken == nil {
+			return groups, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
 func (c *cognitoClient) RefreshToken(ctx context.Context, input auth.RefreshTokenInput) (*auth.RefreshTokenOutput, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
@@ -439,6 +566,10 @@ func (c *cognitoClient) RefreshToken(ctx context.Context, input auth.RefreshToke
 		},
 		ClientId: aws.String(c.clientId),
 	}
+	if input.OrganizationID != "" {
+		// Cognito only mints the org_id claim through a pre token generation trigger reading this metadata
+		refreshTokenInput.ClientMetadata = map[string]string{"org_id": input.OrganizationID}
+	}
 	cognitoOut, err := c.client.InitiateAuth(ctx, refreshTokenInput)
 	if err != nil {
 		errorType := err.Error()
@@ -553,6 +684,79 @@ func (c *cognitoClient) DeleteUser(ctx context.Context, input auth.DeleteUserInp
 	return nil
 }
 
+func (c *cognitoClient) DisableUser(ctx context.Context, input auth.DisableUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := c.client.AdminDisableUser(ctx, &cognito.AdminDisableUserInput{
+		UserPoolId: aws.String(c.userPoolId),
+		Username:   aws.String(input.Username),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UserNotFoundException") {
+			return auth.ErrUserNotFound
+		}
+		c.logger.Error("Cognito disable user error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cognitoClient) EnableUser(ctx context.Context, input auth.EnableUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := c.client.AdminEnableUser(ctx, &cognito.AdminEnableUserInput{
+		UserPoolId: aws.String(c.userPoolId),
+		Username:   aws.String(input.Username),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UserNotFoundException") {
+			return auth.ErrUserNotFound
+		}
+		c.logger.Error("Cognito enable user error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cognitoClient) ForcePasswordReset(ctx context.Context, input auth.ForcePasswordResetInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := c.client.AdminResetUserPassword(ctx, &cognito.AdminResetUserPasswordInput{
+		UserPoolId: aws.String(c.userPoolId),
+		Username:   aws.String(input.Username),
+	})
+	if err != nil {
+		errorType := err.Error()
+		if strings.Contains(errorType, "UserNotFoundException") {
+			return auth.ErrUserNotFound
+		}
+		if strings.Contains(errorType, "NotAuthorizedException") {
+			return auth.ErrUserNotConfirmed
+		}
+		c.logger.Error("Cognito reset user password error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *cognitoClient) Logout(ctx context.Context, input auth.LogoutInput) error {
 	if err := input.Validate(); err != nil {
 		return err
@@ -637,24 +841,10 @@ func (c *cognitoClient) GetUser(ctx context.Context, input auth.GetUserInput) (*
 	}
 
 	var username, name, id string
-	var status auth.UserStatus
-
-	switch cognitoOut.UserStatus {
-	case types.UserStatusTypeUnconfirmed:
-		status = auth.Unconfirmed
-	case types.UserStatusTypeConfirmed, types.UserStatusTypeArchived, types.UserStatusTypeCompromised, types.UserStatusTypeExternalProvider:
-		status = auth.Confirmed
-	case types.UserStatusTypeUnknown:
-		status = auth.Unknown
-	case types.UserStatusTypeResetRequired:
-		status = auth.ResetRequired
-	case types.UserStatusTypeForceChangePassword:
-		status = auth.ForceChangePasswd
-	default:
-		status = auth.Unknown
-	}
+	attributes := make(map[string]string, len(cognitoOut.UserAttributes))
 
 	for _, attr := range cognitoOut.UserAttributes {
+		attributes[aws.ToString(attr.Name)] = aws.ToString(attr.Value)
 		switch *attr.Name {
 		case "email":
 			username = *attr.Value
@@ -666,15 +856,153 @@ func (c *cognitoClient) GetUser(ctx context.Context, input auth.GetUserInput) (*
 	}
 
 	out := &auth.User{
-		Email:  username,
-		Name:   name,
-		Id:     id,
-		Status: status,
+		Email:      username,
+		Name:       name,
+		Id:         id,
+		Status:     userStatus(cognitoOut.UserStatus),
+		Enabled:    cognitoOut.Enabled,
+		MFAEnabled: len(cognitoOut.UserMFASettingList) > 0,
+		CreatedAt:  aws.ToTime(cognitoOut.UserCreateDate),
+		Attributes: attributes,
 	}
 
 	return out, nil
 }
 
+func userStatus(status types.UserStatusType) auth.UserStatus {
+	switch status {
+	case types.UserStatusTypeUnconfirmed:
+		return auth.Unconfirmed
+	case types.UserStatusTypeConfirmed, types.UserStatusTypeArchived, types.UserStatusTypeCompromised, types.UserStatusTypeExternalProvider:
+		return auth.Confirmed
+	case types.UserStatusTypeResetRequired:
+		return auth.ResetRequired
+	case types.UserStatusTypeForceChangePassword:
+		return auth.ForceChangePasswd
+	default:
+		return auth.Unknown
+	}
+}
+
+// ListUsers lists the members of the group when one is given, the status is then filtered on the page as
+// ListUsersInGroup takes no filter. Otherwise the status is sent as a ListUsers filter.
+func (c *cognitoClient) ListUsers(ctx context.Context, input auth.ListUsersInput) (*auth.UserPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var users []types.UserType
+	var nextCursor *string
+	if input.Group != "" {
+		listUsersInGroupInput := &cognito.ListUsersInGroupInput{
+			UserPoolId: aws.String(c.userPoolId),
+			GroupName:  aws.String(string(input.Group)),
+			Limit:      aws.Int32(int32(input.Limit)),
+		}
+		if input.Cursor != "" {
+			listUsersInGroupInput.NextToken = aws.String(input.Cursor)
+		}
+
+		out, err := c.client.ListUsersInGroup(ctx, listUsersInGroupInput)
+		if err != nil {
+			if strings.Contains(err.Error(), "ResourceNotFoundException") {
+				return &auth.UserPage{Users: []*auth.User{}}, nil
+			}
+			c.logger.Error("Cognito list users in group error", err)
+			return nil, err
+		}
+		users, nextCursor = out.Users, out.NextToken
+	} else {
+		listUsersInput := &cognito.ListUsersInput{
+			UserPoolId: aws.String(c.userPoolId),
+			Limit:      aws.Int32(int32(input.Limit)),
+		}
+		if input.Cursor != "" {
+			listUsersInput.PaginationToken = aws.String(input.Cursor)
+		}
+		if input.Status != "" {
+			listUsersInput.Filter = aws.String(fmt.Sprintf("cognito:user_status = %q", string(input.Status)))
+		}
+
+		out, err := c.client.ListUsers(ctx, listUsersInput)
+		if err != nil {
+			c.logger.Error("Cognito list users error", err)
+			return nil, err
+		}
+		users, nextCursor = out.Users, out.PaginationToken
+	}
+
+	// ListUsers does not return the MFA settings, MFAEnabled is left false.
+	page := &auth.UserPage{Users: make([]*auth.User, 0, len(users)), NextCursor: aws.ToString(nextCursor)}
+	for _, usr := range users {
+		if input.Status != "" && userStatus(usr.UserStatus) != input.Status {
+			continue
+		}
+
+		authUser := &auth.User{
+			Status:     userStatus(usr.UserStatus),
+			Enabled:    usr.Enabled,
+			CreatedAt:  aws.ToTime(usr.UserCreateDate),
+			Attributes: make(map[string]string, len(usr.Attributes)),
+		}
+		for _, attr := range usr.Attributes {
+			authUser.Attributes[aws.ToString(attr.Name)] = aws.ToString(attr.Value)
+			switch aws.ToString(attr.Name) {
+			case "email":
+				authUser.Email = aws.ToString(attr.Value)
+			case "name":
+				authUser.Name = aws.ToString(attr.Value)
+			case "sub":
+				authUser.Id = aws.ToString(attr.Value)
+			}
+		}
+		page.Users = append(page.Users, authUser)
+	}
+	return page, nil
+}
+
+// GetUsers fans out to AdminGetUser, Cognito has no batch lookup. maxGetUsersCalls bounds the calls in flight.
+func (c *cognitoClient) GetUsers(ctx context.Context, input auth.GetUsersInput) ([]*auth.User, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := make([]*auth.User, len(input.Usernames))
+	errs := make([]error, len(input.Usernames))
+	sem := make(chan struct{}, maxGetUsersCalls)
+	var wg sync.WaitGroup
+	for i, username := range input.Usernames {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, username string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			usr, err := c.GetUser(ctx, auth.GetUserInput{Username: username})
+			if err != nil && err != auth.ErrUserNotFound {
+				errs[i] = err
+				return
+			}
+			found[i] = usr
+		}(i, username)
+	}
+	wg.Wait()
+
+	users := make([]*auth.User, 0, len(found))
+	for i, usr := range found {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if usr != nil {
+			users = append(users, usr)
+		}
+	}
+	return users, nil
+}
+
 func (c *cognitoClient) AdminLogout(ctx context.Context, input auth.AdminLogoutInput) error {
 	if err := input.Validate(); err != nil {
 		return err
@@ -733,44 +1061,47 @@ func (c *cognitoClient) VerifyEmail(ctx context.Context, input auth.VerifyEmailI
 	return nil
 }
 
-func (c *cognitoClient) GenerateAndSendCode(ctx context.Context, input auth.GenerateAndSendCodeInput) (*auth.GenerateAndSendCodeOutput, error) {
+func (c *cognitoClient) ChangeEmail(ctx context.Context, input auth.ChangeEmailInput) error {
 	if err := input.Validate(); err != nil {
-		return nil, err
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	expiresAt := time.Now().Add(10 * time.Minute)
-
-	generateAndSendInput := &code.GenerateAndSaveInput{
-		Identifier:        fmt.Sprintf("%s#%s", input.Identifier, input.Username),
-		ExpiresAt:         expiresAt,
-		Length:            6,
-		CanContainLetters: false,
+	// The email and email_verified attributes are updated together so the pool never holds an unverified address.
+	changeEmailInput := &cognito.AdminUpdateUserAttributesInput{
+		UserPoolId: aws.String(c.userPoolId),
+		Username:   aws.String(input.Username),
+		UserAttributes: []types.AttributeType{
+			{
+				Name:  aws.String("email"),
+				Value: aws.String(input.NewEmail),
+			},
+			{
+				Name:  aws.String("email_verified"),
+				Value: aws.String("true"),
+			},
+		},
 	}
 
-	code, err := c.code.GenerateAndSave(ctx, *generateAndSendInput)
+	_, err := c.client.AdminUpdateUserAttributes(ctx, changeEmailInput)
 	if err != nil {
-		return nil, err
-	}
-
-	sendEmailInput := email.Email{
-		To:      input.Username,
-		Subject: input.Subject,
-		Body:    fmt.Sprintf(input.Body, code.Value),
-	}
-	if err := c.email.SendEmail(ctx, sendEmailInput); err != nil {
-		return nil, err
+		errorType := err.Error()
+		if strings.Contains(errorType, "UserNotFoundException") {
+			return auth.ErrUserNotFound
+		}
+		if strings.Contains(errorType, "AliasExistsException") {
+			return auth.ErrUserAlreadyExists
+		}
+		c.logger.Error("Cognito change email error", err)
+		return err
 	}
 
-	return &auth.GenerateAndSendCodeOutput{
-		Code: code.Value,
-	}, nil
-
+	return nil
 }
 
-func (c *cognitoClient) VerifyCode(ctx context.Context, input auth.VerifyCodeInput) error {
+func (c *cognitoClient) UpdateName(ctx context.Context, input auth.UpdateNameInput) error {
 	if err := input.Validate(); err != nil {
 		return err
 	}
@@ -778,20 +1109,42 @@ func (c *cognitoClient) VerifyCode(ctx context.Context, input auth.VerifyCodeInp
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	identifier := fmt.Sprintf("%s#%s", input.Identifier, input.Username)
-
-	verifyInput := code.VerifyCodeInput{
-		Identifier: identifier,
-		Code:       input.Code,
+	updateNameInput := &cognito.AdminUpdateUserAttributesInput{
+		UserPoolId: aws.String(c.userPoolId),
+		Username:   aws.String(input.Username),
+		UserAttributes: []types.AttributeType{
+			{
+				Name:  aws.String("name"),
+				Value: aws.String(input.Name),
+			},
+		},
 	}
 
-	if err := c.code.VerifyCode(ctx, verifyInput); err != nil {
+	_, err := c.client.AdminUpdateUserAttributes(ctx, updateNameInput)
+	if err != nil {
+		errorType := err.Error()
+		if strings.Contains(errorType, "UserNotFoundException") {
+			return auth.ErrUserNotFound
+		}
+		c.logger.Error("Cognito update name error", err)
 		return err
 	}
 
 	return nil
 }
 
+func (c *cognitoClient) GenerateAndSendCode(ctx context.Context, input auth.GenerateAndSendCodeInput) (*auth.GenerateAndSendCodeOutput, error) {
+	return generateAndSendCode(ctx, c.code, c.email, input)
+}
+
+func (c *cognitoClient) VerifyCode(ctx context.Context, input auth.VerifyCodeInput) error {
+	return verifyCode(ctx, c.code, input)
+}
+
+func (c *cognitoClient) PurgeCodes(ctx context.Context, input auth.PurgeCodesInput) error {
+	return purgeCodes(ctx, c.code, input)
+}
+
 func (c *cognitoClient) ChangeForgotPassword(ctx context.Context, input auth.ChangeForgotPasswordInput) error {
 	if err := input.Validate(); err != nil {
 		return err
@@ -846,3 +1199,29 @@ func (c *cognitoClient) ChangePassword(ctx context.Context, input auth.ChangePas
 
 	return nil
 }
+
+func (c *cognitoClient) RevokeRefreshToken(ctx context.Context, input auth.RevokeRefreshTokenInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	revokeTokenInput := &cognito.RevokeTokenInput{
+		ClientId: aws.String(c.clientId),
+		Token:    aws.String(input.RefreshToken),
+	}
+
+	_, err := c.client.RevokeToken(ctx, revokeTokenInput)
+	if err != nil {
+		errorType := err.Error()
+		if strings.Contains(errorType, "UnsupportedTokenTypeException") || strings.Contains(errorType, "UnauthorizedException") {
+			return auth.ErrInvalidRefreshToken
+		}
+		c.logger.Error("Cognito revoke token error", err)
+		return err
+	}
+
+	return nil
+}
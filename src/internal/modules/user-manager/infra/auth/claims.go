@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/pkg/jwt_verify"
+	"errors"
+)
+
+// claimsFromJWT maps provider claims to domain claims. Access tokens carry the username instead of the email.
+func claimsFromJWT(claims *jwt_verify.Claims) *auth.Claims {
+	email := claims.Email
+	if email == "" {
+		email = claims.Username
+	}
+
+	return &auth.Claims{
+		Email:      email,
+		Id:         claims.Sub,
+		UserGroups: claims.UserGroups,
+		TokenUse:   claims.TokenUse,
+		ClientId:   claims.ClientId,
+		Scope:      claims.Scope,
+		Jti:        claims.Jti,
+		OriginJti:  claims.OriginJti,
+		OrgId:      claims.OrgId,
+		IssuedAt:   claims.Iat,
+		ExpiresAt:  claims.Exp,
+	}
+}
+
+// tokenError maps token validation failures to the 401 reasons returned by the API.
+func tokenError(err error) error {
+	switch {
+	case errors.Is(err, jwt_verify.ErrTokenExpired):
+		return auth.ErrTokenExpired
+	case errors.Is(err, jwt_verify.ErrTokenNotYetValid):
+		return auth.ErrTokenNotYetValid
+	case errors.Is(err, jwt_verify.ErrInvalidIssuer):
+		return auth.ErrInvalidTokenIssuer
+	case errors.Is(err, jwt_verify.ErrInvalidAudience):
+		return auth.ErrInvalidTokenAudience
+	case errors.Is(err, jwt_verify.ErrInvalidTokenUse):
+		return auth.ErrInvalidTokenUse
+	case errors.Is(err, jwt_verify.ErrTokenTooOld):
+		return auth.ErrTokenTooOld
+	default:
+		return auth.ErrInvalidAccessCode
+	}
+}
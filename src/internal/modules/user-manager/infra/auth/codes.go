@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/code/domain/code"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"context"
+	"fmt"
+	"time"
+)
+
+func generateAndSendCode(ctx context.Context, codeService code.CodeService, emailService email.EmailService, input auth.GenerateAndSendCodeInput) (*auth.GenerateAndSendCodeOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	generateAndSendInput := &code.GenerateAndSaveInput{
+		Identifier:        fmt.Sprintf("%s#%s", input.Identifier, input.Username),
+		ExpiresAt:         expiresAt,
+		Length:            6,
+		CanContainLetters: false,
+	}
+
+	code, err := codeService.GenerateAndSave(ctx, *generateAndSendInput)
+	if err != nil {
+		return nil, err
+	}
+
+	sendEmailInput := email.Email{
+		To:      input.Username,
+		Subject: input.Subject,
+		Body:    fmt.Sprintf(input.Body, code.Value),
+	}
+	if err := emailService.SendEmail(ctx, sendEmailInput); err != nil {
+		return nil, err
+	}
+
+	return &auth.GenerateAndSendCodeOutput{
+		Code: code.Value,
+	}, nil
+}
+
+func verifyCode(ctx context.Context, codeService code.CodeService, input auth.VerifyCodeInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	identifier := fmt.Sprintf("%s#%s", input.Identifier, input.Username)
+
+	verifyInput := code.VerifyCodeInput{
+		Identifier: identifier,
+		Code:       input.Code,
+	}
+
+	if err := codeService.VerifyCode(ctx, verifyInput); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func purgeCodes(ctx context.Context, codeService code.CodeService, input auth.PurgeCodesInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	for _, identifier := range auth.CodeIdentifiers {
+		if err := codeService.Purge(ctx, code.PurgeInput{
+			Identifier: fmt.Sprintf("%s#%s", identifier, input.Username),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
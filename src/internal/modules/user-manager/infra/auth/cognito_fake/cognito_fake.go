@@ -0,0 +1,353 @@
+package cognito_fake
+
+import (
+	"auth-api/src/pkg/jwt_issuer"
+	"auth-api/src/pkg/jwt_verify"
+	"auth-api/src/pkg/logger"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	accessTokenTTL = time.Hour
+	sessionTTL     = 3 * time.Minute
+)
+
+type fakeUser struct {
+	username      string
+	password      string
+	attributes    map[string]string
+	status        types.UserStatusType
+	enabled       bool
+	groups        map[string]struct{}
+	mfaEnabled    bool
+	mfaSecret     string
+	pendingSecret string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+type fakeSession struct {
+	username  string
+	challenge types.ChallengeNameType
+	expiresAt time.Time
+}
+
+type fakeRefreshToken struct {
+	username  string
+	originJti string
+	revoked   bool
+}
+
+// FakeCognito is an in-memory stand-in for the Cognito user pool operations used by the auth service.
+// Tokens are RS256 signed with a key exposed through JWK so jwt_verify can validate them.
+type FakeCognito struct {
+	mu             sync.Mutex
+	users          map[string]*fakeUser
+	groups         map[string]struct{}
+	sessions       map[string]fakeSession
+	refreshTokens  map[string]*fakeRefreshToken
+	revokedOrigins map[string]struct{}
+	issuer         jwt_issuer.JWTIssuer
+	jwtVerify      jwt_verify.JWTVerify
+	clientID       string
+	now            func() time.Time
+}
+
+// New creates a fake user pool with the Admin and User groups already present.
+func New(region, userPoolID, clientID string, logger logger.Logger) (*FakeCognito, error) {
+	key, err := jwt_issuer.LoadPrivateKey("")
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := jwt_issuer.NewIssuer(fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", region, userPoolID), key)
+
+	jwtVerify := jwt_verify.NewAuthWithJWK(issuer.JWK(), jwt_verify.ValidationRules{
+		Issuer:   issuer.Issuer(),
+		TokenUse: "access",
+	}, logger)
+
+	f := &FakeCognito{
+		users:          make(map[string]*fakeUser),
+		groups:         make(map[string]struct{}),
+		sessions:       make(map[string]fakeSession),
+		refreshTokens:  make(map[string]*fakeRefreshToken),
+		revokedOrigins: make(map[string]struct{}),
+		issuer:         issuer,
+		jwtVerify:      jwtVerify,
+		clientID:       clientID,
+		now:            time.Now,
+	}
+	f.SeedGroup("Admin")
+	f.SeedGroup("User")
+
+	return f, nil
+}
+
+// JWK returns the key set tokens minted by the fake are signed with.
+func (f *FakeCognito) JWK() *jwt_verify.JWK {
+	return f.issuer.JWK()
+}
+
+// SeedGroup adds a group to the pool so users can be added to it.
+func (f *FakeCognito) SeedGroup(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.groups[name] = struct{}{}
+}
+
+// SetClock overrides the time source, useful to exercise TOTP windows and token expiry.
+func (f *FakeCognito) SetClock(now func() time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// MFASecret returns the active TOTP secret of a user so callers can generate valid codes.
+func (f *FakeCognito) MFASecret(username string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	usr, ok := f.users[username]
+	if !ok || !usr.mfaEnabled {
+		return "", false
+	}
+	return usr.mfaSecret, true
+}
+
+func (f *FakeCognito) newUser(username, password string, attrs []types.AttributeType, status types.UserStatusType) *fakeUser {
+	now := f.now()
+	usr := &fakeUser{
+		username:   username,
+		password:   password,
+		attributes: map[string]string{"sub": uuid.NewString()},
+		status:     status,
+		enabled:    true,
+		groups:     make(map[string]struct{}),
+		createdAt:  now,
+		updatedAt:  now,
+	}
+	for _, attr := range attrs {
+		usr.attributes[aws.ToString(attr.Name)] = aws.ToString(attr.Value)
+	}
+	f.users[username] = usr
+	return usr
+}
+
+func (f *FakeCognito) userFromAccessToken(accessToken *string) (*fakeUser, error) {
+	_, claims, err := f.jwtVerify.ParseJWT(aws.ToString(accessToken))
+	if err != nil || claims.TokenUse != "access" {
+		return nil, notAuthorized("Invalid Access Token")
+	}
+	if _, revoked := f.revokedOrigins[claims.OriginJti]; revoked {
+		return nil, notAuthorized("Access Token has been revoked")
+	}
+
+	usr, ok := f.users[claims.Username]
+	if !ok {
+		return nil, notAuthorized("Invalid Access Token")
+	}
+	return usr, nil
+}
+
+func (f *FakeCognito) newSession(username string, challenge types.ChallengeNameType) string {
+	session := randomString()
+	f.sessions[session] = fakeSession{
+		username:  username,
+		challenge: challenge,
+		expiresAt: f.now().Add(sessionTTL),
+	}
+	return session
+}
+
+func (f *FakeCognito) consumeSession(session *string, username string, challenge types.ChallengeNameType) error {
+	s, ok := f.sessions[aws.ToString(session)]
+	if !ok || s.challenge != challenge || s.username != username {
+		return notAuthorized("Invalid session for the user.")
+	}
+	delete(f.sessions, aws.ToString(session))
+	if s.expiresAt.Before(f.now()) {
+		return notAuthorized("Invalid session for the user, session is expired.")
+	}
+	return nil
+}
+
+// authenticate mints a token set for a user that completed every challenge.
+func (f *FakeCognito) authenticate(usr *fakeUser) (*types.AuthenticationResultType, error) {
+	originJti := uuid.NewString()
+	accessToken, idToken, err := f.signTokens(usr, originJti, "")
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken := randomString()
+	f.refreshTokens[refreshToken] = &fakeRefreshToken{
+		username:  usr.username,
+		originJti: originJti,
+	}
+
+	return &types.AuthenticationResultType{
+		AccessToken:  aws.String(accessToken),
+		IdToken:      aws.String(idToken),
+		RefreshToken: aws.String(refreshToken),
+		ExpiresIn:    int32(accessTokenTTL.Seconds()),
+		TokenType:    aws.String("Bearer"),
+	}, nil
+}
+
+func (f *FakeCognito) signTokens(usr *fakeUser, originJti, orgID string) (string, string, error) {
+	now := f.now()
+	groups := make([]string, 0, len(usr.groups))
+	for group := range usr.groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	common := jwt.MapClaims{
+		"sub":        usr.attributes["sub"],
+		"iss":        f.issuer.Issuer(),
+		"origin_jti": originJti,
+		"event_id":   uuid.NewString(),
+		"auth_time":  now.Unix(),
+		"iat":        now.Unix(),
+		"exp":        now.Add(accessTokenTTL).Unix(),
+	}
+	if len(groups) > 0 {
+		common["cognito:groups"] = groups
+	}
+
+	access := jwt.MapClaims{
+		"token_use": "access",
+		"client_id": f.clientID,
+		"scope":     "aws.cognito.signin.user.admin",
+		"username":  usr.username,
+		"jti":       uuid.NewString(),
+	}
+	if orgID != "" {
+		access["org_id"] = orgID
+	}
+	id := jwt.MapClaims{
+		"token_use":        "id",
+		"aud":              f.clientID,
+		"cognito:username": usr.username,
+		"email":            usr.attributes["email"],
+		"email_verified":   usr.attributes["email_verified"] == "true",
+		"name":             usr.attributes["name"],
+		"jti":              uuid.NewString(),
+	}
+	for k, v := range common {
+		access[k] = v
+		id[k] = v
+	}
+
+	accessToken, err := f.issuer.Sign(access)
+	if err != nil {
+		return "", "", err
+	}
+	idToken, err := f.issuer.Sign(id)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, idToken, nil
+}
+
+func (f *FakeCognito) signOut(username string) {
+	for _, rt := range f.refreshTokens {
+		if rt.username == username && !rt.revoked {
+			rt.revoked = true
+			f.revokedOrigins[rt.originJti] = struct{}{}
+		}
+	}
+}
+
+// rename moves the user, and its sessions and refresh tokens, to a new username. Callers must hold the lock.
+func (f *FakeCognito) rename(usr *fakeUser, username string) {
+	for _, rt := range f.refreshTokens {
+		if rt.username == usr.username {
+			rt.username = username
+		}
+	}
+	for key, session := range f.sessions {
+		if session.username == usr.username {
+			session.username = username
+			f.sessions[key] = session
+		}
+	}
+	delete(f.users, usr.username)
+	usr.username = username
+	f.users[username] = usr
+}
+
+// userTypes lists the matching users sorted by username. Callers must hold the lock.
+func (f *FakeCognito) userTypes(match func(usr *fakeUser) bool) []types.UserType {
+	usernames := make([]string, 0, len(f.users))
+	for username, usr := range f.users {
+		if match(usr) {
+			usernames = append(usernames, username)
+		}
+	}
+	sort.Strings(usernames)
+
+	users := make([]types.UserType, 0, len(usernames))
+	for _, username := range usernames {
+		usr := f.users[username]
+		users = append(users, types.UserType{
+			Username:             aws.String(usr.username),
+			Attributes:           attributeList(usr),
+			UserStatus:           usr.status,
+			Enabled:              usr.enabled,
+			UserCreateDate:       aws.Time(usr.createdAt),
+			UserLastModifiedDate: aws.Time(usr.updatedAt),
+		})
+	}
+	return users
+}
+
+func attributeList(usr *fakeUser) []types.AttributeType {
+	names := make([]string, 0, len(usr.attributes))
+	for name := range usr.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := make([]types.AttributeType, 0, len(names))
+	for _, name := range names {
+		attrs = append(attrs, types.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(usr.attributes[name]),
+		})
+	}
+	return attrs
+}
+
+func mfaSettings(usr *fakeUser) ([]string, *string) {
+	if !usr.mfaEnabled {
+		return nil, nil
+	}
+	return []string{"SOFTWARE_TOKEN_MFA"}, aws.String("SOFTWARE_TOKEN_MFA")
+}
+
+func randomString() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func notAuthorized(message string) error {
+	return &types.NotAuthorizedException{Message: aws.String(message)}
+}
+
+func userNotFound() error {
+	return &types.UserNotFoundException{Message: aws.String("User does not exist.")}
+}
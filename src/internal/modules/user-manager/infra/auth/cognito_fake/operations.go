@@ -0,0 +1,597 @@
+package cognito_fake
+
+import (
+	"auth-api/src/pkg/totp"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cognito "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func (f *FakeCognito) InitiateAuth(ctx context.Context, params *cognito.InitiateAuthInput, optFns ...func(*cognito.Options)) (*cognito.InitiateAuthOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if aws.ToString(params.ClientId) != f.clientID {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("User pool client does not exist.")}
+	}
+
+	switch params.AuthFlow {
+	case types.AuthFlowTypeUserPasswordAuth:
+		username := params.AuthParameters["USERNAME"]
+		usr, ok := f.users[username]
+		if !ok || usr.password != params.AuthParameters["PASSWORD"] {
+			return nil, notAuthorized("Incorrect username or password.")
+		}
+		if !usr.enabled {
+			return nil, notAuthorized("User is disabled.")
+		}
+
+		switch usr.status {
+		case types.UserStatusTypeUnconfirmed:
+			return nil, &types.UserNotConfirmedException{Message: aws.String("User is not confirmed.")}
+		case types.UserStatusTypeResetRequired:
+			return nil, &types.PasswordResetRequiredException{Message: aws.String("Password reset required for the user")}
+		case types.UserStatusTypeForceChangePassword:
+			return &cognito.InitiateAuthOutput{
+				ChallengeName: types.ChallengeNameTypeNewPasswordRequired,
+				Session:       aws.String(f.newSession(username, types.ChallengeNameTypeNewPasswordRequired)),
+			}, nil
+		}
+
+		if usr.mfaEnabled {
+			return &cognito.InitiateAuthOutput{
+				ChallengeName: types.ChallengeNameTypeSoftwareTokenMfa,
+				Session:       aws.String(f.newSession(username, types.ChallengeNameTypeSoftwareTokenMfa)),
+			}, nil
+		}
+
+		result, err := f.authenticate(usr)
+		if err != nil {
+			return nil, err
+		}
+		return &cognito.InitiateAuthOutput{AuthenticationResult: result}, nil
+
+	case types.AuthFlowTypeRefreshTokenAuth, types.AuthFlowTypeRefreshToken:
+		rt, ok := f.refreshTokens[params.AuthParameters["REFRESH_TOKEN"]]
+		if !ok || rt.revoked {
+			return nil, notAuthorized("Invalid Refresh Token")
+		}
+		usr, ok := f.users[rt.username]
+		if !ok || !usr.enabled {
+			return nil, notAuthorized("Invalid Refresh Token")
+		}
+
+		// Stands in for a pre token generation trigger copying the org_id client metadata
+		accessToken, idToken, err := f.signTokens(usr, rt.originJti, params.ClientMetadata["org_id"])
+		if err != nil {
+			return nil, err
+		}
+		return &cognito.InitiateAuthOutput{
+			AuthenticationResult: &types.AuthenticationResultType{
+				AccessToken: aws.String(accessToken),
+				IdToken:     aws.String(idToken),
+				ExpiresIn:   int32(accessTokenTTL.Seconds()),
+				TokenType:   aws.String("Bearer"),
+			},
+		}, nil
+
+	default:
+		return nil, &types.InvalidParameterException{Message: aws.String("Unsupported auth flow")}
+	}
+}
+
+func (f *FakeCognito) RespondToAuthChallenge(ctx context.Context, params *cognito.RespondToAuthChallengeInput, optFns ...func(*cognito.Options)) (*cognito.RespondToAuthChallengeOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	username := params.ChallengeResponses["USERNAME"]
+	usr, ok := f.users[username]
+	if !ok {
+		return nil, userNotFound()
+	}
+
+	if err := f.consumeSession(params.Session, username, params.ChallengeName); err != nil {
+		return nil, err
+	}
+
+	switch params.ChallengeName {
+	case types.ChallengeNameTypeSoftwareTokenMfa:
+		if !totp.Validate(usr.mfaSecret, params.ChallengeResponses["SOFTWARE_TOKEN_MFA_CODE"], f.now()) {
+			return nil, &types.CodeMismatchException{Message: aws.String("Invalid code received for user")}
+		}
+	case types.ChallengeNameTypeNewPasswordRequired:
+		usr.password = params.ChallengeResponses["NEW_PASSWORD"]
+		usr.status = types.UserStatusTypeConfirmed
+		usr.updatedAt = f.now()
+	default:
+		return nil, &types.InvalidParameterException{Message: aws.String("Unsupported challenge")}
+	}
+
+	result, err := f.authenticate(usr)
+	if err != nil {
+		return nil, err
+	}
+	return &cognito.RespondToAuthChallengeOutput{AuthenticationResult: result}, nil
+}
+
+func (f *FakeCognito) SignUp(ctx context.Context, params *cognito.SignUpInput, optFns ...func(*cognito.Options)) (*cognito.SignUpOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, exists := f.users[username]; exists {
+		return nil, &types.UsernameExistsException{Message: aws.String("User already exists")}
+	}
+
+	usr := f.newUser(username, aws.ToString(params.Password), params.UserAttributes, types.UserStatusTypeUnconfirmed)
+
+	return &cognito.SignUpOutput{
+		UserConfirmed: false,
+		UserSub:       aws.String(usr.attributes["sub"]),
+	}, nil
+}
+
+func (f *FakeCognito) AdminConfirmSignUp(ctx context.Context, params *cognito.AdminConfirmSignUpInput, optFns ...func(*cognito.Options)) (*cognito.AdminConfirmSignUpOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	if usr.status != types.UserStatusTypeUnconfirmed {
+		return nil, notAuthorized("User cannot be confirmed. Current status is " + string(usr.status))
+	}
+	usr.status = types.UserStatusTypeConfirmed
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminConfirmSignUpOutput{}, nil
+}
+
+func (f *FakeCognito) AdminCreateUser(ctx context.Context, params *cognito.AdminCreateUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminCreateUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, exists := f.users[username]; exists {
+		return nil, &types.UsernameExistsException{Message: aws.String("User account already exists")}
+	}
+
+	usr := f.newUser(username, aws.ToString(params.TemporaryPassword), params.UserAttributes, types.UserStatusTypeForceChangePassword)
+
+	return &cognito.AdminCreateUserOutput{
+		User: &types.UserType{
+			Username:             aws.String(usr.username),
+			Attributes:           attributeList(usr),
+			Enabled:              usr.enabled,
+			UserStatus:           usr.status,
+			UserCreateDate:       aws.Time(usr.createdAt),
+			UserLastModifiedDate: aws.Time(usr.updatedAt),
+		},
+	}, nil
+}
+
+func (f *FakeCognito) AdminDeleteUser(ctx context.Context, params *cognito.AdminDeleteUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminDeleteUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, ok := f.users[username]; !ok {
+		return nil, userNotFound()
+	}
+	f.signOut(username)
+	delete(f.users, username)
+
+	return &cognito.AdminDeleteUserOutput{}, nil
+}
+
+func (f *FakeCognito) AdminDisableUser(ctx context.Context, params *cognito.AdminDisableUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminDisableUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	usr.enabled = false
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminDisableUserOutput{}, nil
+}
+
+func (f *FakeCognito) AdminEnableUser(ctx context.Context, params *cognito.AdminEnableUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminEnableUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	usr.enabled = true
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminEnableUserOutput{}, nil
+}
+
+// AdminResetUserPassword only moves the user to RESET_REQUIRED, the fake does not send the Cognito reset code.
+func (f *FakeCognito) AdminResetUserPassword(ctx context.Context, params *cognito.AdminResetUserPasswordInput, optFns ...func(*cognito.Options)) (*cognito.AdminResetUserPasswordOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	if usr.status == types.UserStatusTypeUnconfirmed {
+		return nil, notAuthorized("User password cannot be reset in the current state.")
+	}
+	usr.status = types.UserStatusTypeResetRequired
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminResetUserPasswordOutput{}, nil
+}
+
+func (f *FakeCognito) GetUser(ctx context.Context, params *cognito.GetUserInput, optFns ...func(*cognito.Options)) (*cognito.GetUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaList, preferred := mfaSettings(usr)
+	return &cognito.GetUserOutput{
+		Username:            aws.String(usr.username),
+		UserAttributes:      attributeList(usr),
+		UserMFASettingList:  mfaList,
+		PreferredMfaSetting: preferred,
+	}, nil
+}
+
+func (f *FakeCognito) AdminGetUser(ctx context.Context, params *cognito.AdminGetUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminGetUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+
+	mfaList, preferred := mfaSettings(usr)
+	return &cognito.AdminGetUserOutput{
+		Username:             aws.String(usr.username),
+		UserAttributes:       attributeList(usr),
+		UserStatus:           usr.status,
+		Enabled:              usr.enabled,
+		UserCreateDate:       aws.Time(usr.createdAt),
+		UserLastModifiedDate: aws.Time(usr.updatedAt),
+		UserMFASettingList:   mfaList,
+		PreferredMfaSetting:  preferred,
+	}, nil
+}
+
+func (f *FakeCognito) AdminAddUserToGroup(ctx context.Context, params *cognito.AdminAddUserToGroupInput, optFns ...func(*cognito.Options)) (*cognito.AdminAddUserToGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	group := aws.ToString(params.GroupName)
+	if _, ok := f.groups[group]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Group not found.")}
+	}
+	usr.groups[group] = struct{}{}
+
+	return &cognito.AdminAddUserToGroupOutput{}, nil
+}
+
+func (f *FakeCognito) AdminRemoveUserFromGroup(ctx context.Context, params *cognito.AdminRemoveUserFromGroupInput, optFns ...func(*cognito.Options)) (*cognito.AdminRemoveUserFromGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	group := aws.ToString(params.GroupName)
+	if _, ok := f.groups[group]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Group not found.")}
+	}
+	delete(usr.groups, group)
+
+	return &cognito.AdminRemoveUserFromGroupOutput{}, nil
+}
+
+// ListUsers returns every user in a single page. Only the cognito:user_status equality filter is supported.
+func (f *FakeCognito) ListUsers(ctx context.Context, params *cognito.ListUsersInput, optFns ...func(*cognito.Options)) (*cognito.ListUsersOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := ""
+	if filter := aws.ToString(params.Filter); filter != "" {
+		if _, err := fmt.Sscanf(filter, "cognito:user_status = %q", &status); err != nil {
+			return nil, &types.InvalidParameterException{Message: aws.String("Unsupported filter")}
+		}
+	}
+
+	return &cognito.ListUsersOutput{Users: f.userTypes(func(usr *fakeUser) bool {
+		return status == "" || string(usr.status) == status
+	})}, nil
+}
+
+func (f *FakeCognito) ListUsersInGroup(ctx context.Context, params *cognito.ListUsersInGroupInput, optFns ...func(*cognito.Options)) (*cognito.ListUsersInGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	group := aws.ToString(params.GroupName)
+	if _, ok := f.groups[group]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Group not found.")}
+	}
+
+	users := f.userTypes(func(usr *fakeUser) bool {
+		_, ok := usr.groups[group]
+		return ok
+	})
+	return &cognito.ListUsersInGroupOutput{Users: users}, nil
+}
+
+func (f *FakeCognito) AdminListGroupsForUser(ctx context.Context, params *cognito.AdminListGroupsForUserInput, optFns ...func(*cognito.Options)) (*cognito.AdminListGroupsForUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+
+	names := make([]string, 0, len(usr.groups))
+	for group := range usr.groups {
+		names = append(names, group)
+	}
+	sort.Strings(names)
+
+	groups := make([]types.GroupType, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, types.GroupType{
+			GroupName: aws.String(name),
+		})
+	}
+	return &cognito.AdminListGroupsForUserOutput{Groups: groups}, nil
+}
+
+func (f *FakeCognito) CreateGroup(ctx context.Context, params *cognito.CreateGroupInput, optFns ...func(*cognito.Options)) (*cognito.CreateGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	group := aws.ToString(params.GroupName)
+	if _, ok := f.groups[group]; ok {
+		return nil, &types.GroupExistsException{Message: aws.String("A group with the name already exists.")}
+	}
+	f.groups[group] = struct{}{}
+
+	return &cognito.CreateGroupOutput{
+		Group: &types.GroupType{
+			GroupName:   aws.String(group),
+			Description: params.Description,
+		},
+	}, nil
+}
+
+func (f *FakeCognito) DeleteGroup(ctx context.Context, params *cognito.DeleteGroupInput, optFns ...func(*cognito.Options)) (*cognito.DeleteGroupOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	group := aws.ToString(params.GroupName)
+	if _, ok := f.groups[group]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Group not found.")}
+	}
+	delete(f.groups, group)
+	for _, usr := range f.users {
+		delete(usr.groups, group)
+	}
+
+	return &cognito.DeleteGroupOutput{}, nil
+}
+
+func (f *FakeCognito) AdminUpdateUserAttributes(ctx context.Context, params *cognito.AdminUpdateUserAttributesInput, optFns ...func(*cognito.Options)) (*cognito.AdminUpdateUserAttributesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	for _, attr := range params.UserAttributes {
+		if aws.ToString(attr.Name) == "sub" {
+			return nil, &types.InvalidParameterException{Message: aws.String("Cannot modify the non-mutable attribute sub")}
+		}
+		if aws.ToString(attr.Name) == "email" && aws.ToString(attr.Value) != usr.username {
+			if _, exists := f.users[aws.ToString(attr.Value)]; exists {
+				return nil, &types.AliasExistsException{Message: aws.String("An account with the given email already exists.")}
+			}
+		}
+	}
+	for _, attr := range params.UserAttributes {
+		usr.attributes[aws.ToString(attr.Name)] = aws.ToString(attr.Value)
+	}
+	// The pool signs in with the email, changing it renames the user.
+	if email := usr.attributes["email"]; email != "" && email != usr.username {
+		f.rename(usr, email)
+	}
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminUpdateUserAttributesOutput{}, nil
+}
+
+func (f *FakeCognito) AdminSetUserPassword(ctx context.Context, params *cognito.AdminSetUserPasswordInput, optFns ...func(*cognito.Options)) (*cognito.AdminSetUserPasswordOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	usr.password = aws.ToString(params.Password)
+	if params.Permanent {
+		usr.status = types.UserStatusTypeConfirmed
+	} else {
+		usr.status = types.UserStatusTypeForceChangePassword
+	}
+	usr.updatedAt = f.now()
+
+	return &cognito.AdminSetUserPasswordOutput{}, nil
+}
+
+func (f *FakeCognito) ChangePassword(ctx context.Context, params *cognito.ChangePasswordInput, optFns ...func(*cognito.Options)) (*cognito.ChangePasswordOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if usr.password != aws.ToString(params.PreviousPassword) {
+		return nil, notAuthorized("Incorrect username or password.")
+	}
+	usr.password = aws.ToString(params.ProposedPassword)
+	usr.updatedAt = f.now()
+
+	return &cognito.ChangePasswordOutput{}, nil
+}
+
+func (f *FakeCognito) GlobalSignOut(ctx context.Context, params *cognito.GlobalSignOutInput, optFns ...func(*cognito.Options)) (*cognito.GlobalSignOutOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	f.signOut(usr.username)
+
+	return &cognito.GlobalSignOutOutput{}, nil
+}
+
+func (f *FakeCognito) AdminUserGlobalSignOut(ctx context.Context, params *cognito.AdminUserGlobalSignOutInput, optFns ...func(*cognito.Options)) (*cognito.AdminUserGlobalSignOutOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, ok := f.users[username]; !ok {
+		return nil, userNotFound()
+	}
+	f.signOut(username)
+
+	return &cognito.AdminUserGlobalSignOutOutput{}, nil
+}
+
+func (f *FakeCognito) AssociateSoftwareToken(ctx context.Context, params *cognito.AssociateSoftwareTokenInput, optFns ...func(*cognito.Options)) (*cognito.AssociateSoftwareTokenOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	usr.pendingSecret = secret
+
+	return &cognito.AssociateSoftwareTokenOutput{
+		SecretCode: aws.String(secret),
+	}, nil
+}
+
+func (f *FakeCognito) VerifySoftwareToken(ctx context.Context, params *cognito.VerifySoftwareTokenInput, optFns ...func(*cognito.Options)) (*cognito.VerifySoftwareTokenOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if usr.pendingSecret == "" {
+		return nil, &types.EnableSoftwareTokenMFAException{Message: aws.String("Software token has not been associated")}
+	}
+	if !totp.Validate(usr.pendingSecret, aws.ToString(params.UserCode), f.now()) {
+		return nil, &types.CodeMismatchException{Message: aws.String("Code mismatch")}
+	}
+	usr.mfaSecret = usr.pendingSecret
+	usr.pendingSecret = ""
+
+	return &cognito.VerifySoftwareTokenOutput{
+		Status: types.VerifySoftwareTokenResponseTypeSuccess,
+	}, nil
+}
+
+func (f *FakeCognito) SetUserMFAPreference(ctx context.Context, params *cognito.SetUserMFAPreferenceInput, optFns ...func(*cognito.Options)) (*cognito.SetUserMFAPreferenceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, err := f.userFromAccessToken(params.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSoftwareTokenPreference(usr, params.SoftwareTokenMfaSettings); err != nil {
+		return nil, err
+	}
+
+	return &cognito.SetUserMFAPreferenceOutput{}, nil
+}
+
+func (f *FakeCognito) AdminSetUserMFAPreference(ctx context.Context, params *cognito.AdminSetUserMFAPreferenceInput, optFns ...func(*cognito.Options)) (*cognito.AdminSetUserMFAPreferenceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	usr, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, userNotFound()
+	}
+	if err := setSoftwareTokenPreference(usr, params.SoftwareTokenMfaSettings); err != nil {
+		return nil, err
+	}
+
+	return &cognito.AdminSetUserMFAPreferenceOutput{}, nil
+}
+
+func (f *FakeCognito) RevokeToken(ctx context.Context, params *cognito.RevokeTokenInput, optFns ...func(*cognito.Options)) (*cognito.RevokeTokenOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if aws.ToString(params.ClientId) != f.clientID {
+		return nil, &types.UnauthorizedException{Message: aws.String("Invalid client")}
+	}
+
+	rt, ok := f.refreshTokens[aws.ToString(params.Token)]
+	if !ok {
+		return nil, &types.UnsupportedTokenTypeException{Message: aws.String("Unsupported token type")}
+	}
+	rt.revoked = true
+	f.revokedOrigins[rt.originJti] = struct{}{}
+
+	return &cognito.RevokeTokenOutput{}, nil
+}
+
+func setSoftwareTokenPreference(usr *fakeUser, settings *types.SoftwareTokenMfaSettingsType) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Enabled && usr.mfaSecret == "" {
+		return &types.InvalidParameterException{Message: aws.String("User has not verified software token mfa")}
+	}
+	usr.mfaEnabled = settings.Enabled
+	if !settings.Enabled {
+		usr.mfaSecret = ""
+	}
+	return nil
+}
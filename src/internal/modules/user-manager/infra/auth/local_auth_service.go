@@ -0,0 +1,775 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/code/domain/code"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/jwt_issuer"
+	"auth-api/src/pkg/jwt_verify"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/password"
+	"auth-api/src/pkg/totp"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	challengeSoftwareTokenMFA  = "SOFTWARE_TOKEN_MFA"
+	challengeNewPasswordNeeded = "NEW_PASSWORD_REQUIRED"
+	challengeTTL               = 3 * time.Minute
+)
+
+type LocalAuthOptions struct {
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type localAuth struct {
+	store     *localAuthStore
+	issuer    jwt_issuer.JWTIssuer
+	jwtVerify jwt_verify.JWTVerify
+	options   LocalAuthOptions
+	logger    logger.Logger
+	email     email.EmailService
+	code      code.CodeService
+}
+
+// NewLocalAuthService returns an AuthService that keeps credentials in Postgres and signs its own tokens.
+func NewLocalAuthService(db *sql.DB, issuer jwt_issuer.JWTIssuer, jwtVerify jwt_verify.JWTVerify, options LocalAuthOptions, logger logger.Logger, email email.EmailService, code code.CodeService) auth.AuthService {
+	return &localAuth{
+		store:     newLocalAuthStore(db, logger),
+		issuer:    issuer,
+		jwtVerify: jwtVerify,
+		options:   options,
+		logger:    logger,
+		email:     email,
+		code:      code,
+	}
+}
+
+func (c *localAuth) Login(ctx context.Context, input auth.LoginInput) (*auth.LoginOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := c.store.GetUserByUsername(ctx, input.Username)
+	if err != nil {
+		if err == auth.ErrUserNotFound {
+			password.VerifyDummy(input.Password)
+			return nil, auth.ErrInvalidUsernameOrPassword
+		}
+		return nil, err
+	}
+
+	ok, err := password.Verify(input.Password, usr.PasswordHash)
+	if err != nil {
+		c.logger.Error("Local login password verification error", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, auth.ErrInvalidUsernameOrPassword
+	}
+	if !usr.Enabled {
+		return nil, auth.ErrUserDisabled
+	}
+
+	switch usr.Status {
+	case auth.Unconfirmed:
+		return nil, auth.ErrUserNotConfirmed
+	case auth.ResetRequired:
+		return nil, auth.ErrPasswordResetRequired
+	case auth.ForceChangePasswd:
+		return c.challenge(ctx, usr, challengeNewPasswordNeeded)
+	}
+
+	if usr.MFAEnabled {
+		return c.challenge(ctx, usr, challengeSoftwareTokenMFA)
+	}
+
+	return c.issueTokens(ctx, usr)
+}
+
+func (c *localAuth) SignUp(ctx context.Context, input auth.SignUpInput) (o *auth.SignUpOutput, execErr error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	userID, err := c.createUser(ctx, input.Username, input.Name, input.Password, auth.Unconfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	out := auth.NewSignUpOutput(userID, input.Username, false, c)
+
+	defer func() {
+		if execErr != nil {
+			c.logger.Info("Rollback signup")
+			if err := out.Rollback(ctx); err != nil {
+				c.logger.Error("Rollback signup error", err)
+			}
+		}
+	}()
+
+	err = c.AddGroup(ctx, auth.AddGroupInput{
+		Username:  input.Username,
+		GroupName: auth.GroupUser,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *localAuth) DeleteUser(ctx context.Context, input auth.DeleteUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.DeleteUser(ctx, input.Username)
+}
+
+func (c *localAuth) DisableUser(ctx context.Context, input auth.DisableUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.SetEnabled(ctx, input.Username, false)
+}
+
+func (c *localAuth) EnableUser(ctx context.Context, input auth.EnableUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.SetEnabled(ctx, input.Username, true)
+}
+
+func (c *localAuth) ForcePasswordReset(ctx context.Context, input auth.ForcePasswordResetInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.store.GetUserByUsername(ctx, input.Username)
+	if err != nil {
+		return err
+	}
+	if usr.Status == auth.Unconfirmed {
+		return auth.ErrUserNotConfirmed
+	}
+
+	return c.store.SetStatus(ctx, input.Username, auth.ResetRequired)
+}
+
+func (c *localAuth) ConfirmSignUp(ctx context.Context, input auth.ConfirmSignUpInput) (*auth.ConfirmSignUpOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := c.store.SetStatus(ctx, input.Username, auth.Confirmed); err != nil {
+		return nil, err
+	}
+
+	return &auth.ConfirmSignUpOutput{}, nil
+}
+
+func (c *localAuth) GetMe(ctx context.Context, input auth.GetMeInput) (*auth.GetMeOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.GetMeOutput{
+		Username: usr.Username,
+		Name:     usr.Name,
+	}, nil
+}
+
+func (c *localAuth) ValidateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	_, claims, err := c.jwtVerify.ParseJWT(token)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	return claimsFromJWT(claims), nil
+}
+
+func (c *localAuth) AddGroup(ctx context.Context, input auth.AddGroupInput) error {
+	if _, err := c.store.GetUserByUsername(ctx, input.Username); err != nil {
+		return err
+	}
+
+	return c.store.AddGroup(ctx, input.Username, input.GroupName)
+}
+
+func (c *localAuth) RemoveGroup(ctx context.Context, input auth.RemoveGroupInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := c.store.GetUserByUsername(ctx, input.Username); err != nil {
+		return err
+	}
+
+	return c.store.RemoveGroup(ctx, input.Username, input.GroupName)
+}
+
+// CreateGroup is a no-op, local groups exist as soon as a user is added to them.
+func (c *localAuth) CreateGroup(ctx context.Context, input auth.CreateGroupInput) error {
+	return input.Validate()
+}
+
+func (c *localAuth) DeleteGroup(ctx context.Context, input auth.DeleteGroupInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.DeleteGroup(ctx, input.GroupName)
+}
+
+func (c *localAuth) ListUserGroups(ctx context.Context, input auth.ListUserGroupsInput) ([]string, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := c.store.GetUserByUsername(ctx, input.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.store.ListGroups(ctx, usr.ID)
+}
+
+func (c *localAuth) RefreshToken(ctx context.Context, input auth.RefreshTokenInput) (*auth.RefreshTokenOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	rt, err := c.store.GetRefreshToken(ctx, input.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if rt.RevokedAt.Valid || rt.ExpiresAt.Before(time.Now()) {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	usr, err := c.store.GetUserByID(ctx, rt.UserID)
+	if err != nil {
+		if err == auth.ErrUserNotFound {
+			return nil, auth.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !usr.Enabled {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	accessToken, idToken, err := c.signTokens(ctx, usr, rt.OriginJti, rt.AuthTime, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.RefreshTokenOutput{
+		AccessToken: accessToken,
+		IdToken:     idToken,
+	}, nil
+}
+
+func (c *localAuth) CreateAdmin(ctx context.Context, input auth.CreateAdminInput) (o *auth.CreateAdminOutput, execErr error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	userID, err := c.createUser(ctx, input.Username, input.Name, input.Password, auth.ForceChangePasswd)
+	if err != nil {
+		return nil, err
+	}
+
+	out := auth.NewCreateAdminOutput(userID, input.Username, c)
+	defer func() {
+		if execErr != nil {
+			if err := out.Rollback(ctx); err != nil {
+				c.logger.Error("Rollback create admin error", err)
+			}
+		}
+	}()
+
+	if err := c.store.SetEmailVerified(ctx, input.Username); err != nil {
+		return nil, err
+	}
+
+	err = c.AddGroup(ctx, auth.AddGroupInput{
+		Username:  input.Username,
+		GroupName: auth.GroupAdmin,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := email.Email{
+		To:      input.Username,
+		Subject: "Your temporary password",
+		Body:    fmt.Sprintf("Your username is %s and your temporary password is %s", input.Username, input.Password),
+	}
+	if err := c.email.SendEmail(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *localAuth) AddMFA(ctx context.Context, input auth.AddMFAInput) (*auth.AddMFAOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store.SetPendingMFASecret(ctx, usr.ID, secret); err != nil {
+		return nil, app_error.NewApiError(500, "Failed to associate software token")
+	}
+
+	return &auth.AddMFAOutput{
+		SecretCode: secret,
+	}, nil
+}
+
+func (c *localAuth) ActivateMFA(ctx context.Context, input auth.ActivateMFAInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	if !usr.MFAPendingSecret.Valid {
+		return auth.ErrFailedToVerifySoftwareMfa
+	}
+
+	if !totp.Validate(usr.MFAPendingSecret.String, input.Code, time.Now()) {
+		return auth.ErrInvalidMfaCode
+	}
+
+	if err := c.store.EnableMFA(ctx, usr.ID, usr.MFAPendingSecret.String); err != nil {
+		return app_error.NewApiError(500, "Failed to set user MFA preference")
+	}
+
+	return nil
+}
+
+func (c *localAuth) VerifyMFA(ctx context.Context, input auth.VerifyMFAInput) (*auth.LoginOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	userID, err := c.store.ConsumeChallenge(ctx, input.Session, challengeSoftwareTokenMFA)
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := c.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if usr.Username != input.Username || !usr.MFASecret.Valid {
+		return nil, auth.ErrFailedToRespondToChallenge
+	}
+
+	if !totp.Validate(usr.MFASecret.String, input.Code, time.Now()) {
+		return nil, auth.ErrFailedToRespondToChallenge
+	}
+
+	return c.issueTokens(ctx, usr)
+}
+
+func (c *localAuth) AdminRemoveMFA(ctx context.Context, input auth.AdminRemoveMFAInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.store.DisableMFA(ctx, input.Username); err != nil {
+		if err == auth.ErrUserNotFound {
+			return err
+		}
+		return app_error.NewApiError(500, "Failed to remove MFA")
+	}
+
+	return nil
+}
+
+func (c *localAuth) RemoveMFA(ctx context.Context, input auth.RemoveMFAInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store.DisableMFA(ctx, usr.Username); err != nil {
+		return app_error.NewApiError(500, "Failed to remove MFA")
+	}
+
+	return nil
+}
+
+func (c *localAuth) Logout(ctx context.Context, input auth.LogoutInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	return c.store.RevokeUserRefreshTokens(ctx, usr.ID)
+}
+
+func (c *localAuth) SetPassword(ctx context.Context, input auth.SetPasswordInput) (*auth.LoginOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	userID, err := c.store.ConsumeChallenge(ctx, input.Session, challengeNewPasswordNeeded)
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := c.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if usr.Username != input.Username {
+		return nil, auth.ErrUserNotFound
+	}
+
+	hash, err := password.Hash(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store.SetPassword(ctx, usr.Username, hash, auth.Confirmed); err != nil {
+		return nil, err
+	}
+	usr.Status = auth.Confirmed
+
+	return c.issueTokens(ctx, usr)
+}
+
+func (c *localAuth) GetUser(ctx context.Context, input auth.GetUserInput) (*auth.User, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := c.store.GetUserByUsername(ctx, input.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return usr.toAuthUser(), nil
+}
+
+func (c *localAuth) GetUsers(ctx context.Context, input auth.GetUsersInput) ([]*auth.User, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	found, err := c.store.GetUsersByUsername(ctx, input.Usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*auth.User, 0, len(found))
+	for _, usr := range found {
+		users = append(users, usr.toAuthUser())
+	}
+	return users, nil
+}
+
+func (c *localAuth) AdminLogout(ctx context.Context, input auth.AdminLogoutInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.store.GetUserByUsername(ctx, input.Username)
+	if err != nil {
+		return err
+	}
+
+	return c.store.RevokeUserRefreshTokens(ctx, usr.ID)
+}
+
+func (c *localAuth) VerifyEmail(ctx context.Context, input auth.VerifyEmailInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.SetEmailVerified(ctx, input.Username)
+}
+
+func (c *localAuth) ListUsers(ctx context.Context, input auth.ListUsersInput) (*auth.UserPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	users, err := c.store.ListUsers(ctx, input.Cursor, input.Limit+1, input.Group, input.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &auth.UserPage{Users: make([]*auth.User, 0, len(users))}
+	if len(users) > input.Limit {
+		users = users[:input.Limit]
+		page.NextCursor = users[input.Limit-1].Username
+	}
+	for _, usr := range users {
+		page.Users = append(page.Users, &auth.User{
+			Id:         usr.ID,
+			Email:      usr.Username,
+			Name:       usr.Name,
+			Status:     usr.Status,
+			Enabled:    usr.Enabled,
+			MFAEnabled: usr.MFAEnabled,
+			CreatedAt:  usr.CreatedAt,
+		})
+	}
+	return page, nil
+}
+
+func (c *localAuth) UpdateName(ctx context.Context, input auth.UpdateNameInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.SetName(ctx, input.Username, input.Name)
+}
+
+func (c *localAuth) ChangeEmail(ctx context.Context, input auth.ChangeEmailInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := c.store.GetUserByUsername(ctx, input.NewEmail); err == nil {
+		return auth.ErrUserAlreadyExists
+	} else if err != auth.ErrUserNotFound {
+		return err
+	}
+
+	return c.store.ChangeUsername(ctx, input.Username, input.NewEmail)
+}
+
+func (c *localAuth) GenerateAndSendCode(ctx context.Context, input auth.GenerateAndSendCodeInput) (*auth.GenerateAndSendCodeOutput, error) {
+	return generateAndSendCode(ctx, c.code, c.email, input)
+}
+
+func (c *localAuth) VerifyCode(ctx context.Context, input auth.VerifyCodeInput) error {
+	return verifyCode(ctx, c.code, input)
+}
+
+func (c *localAuth) PurgeCodes(ctx context.Context, input auth.PurgeCodesInput) error {
+	return purgeCodes(ctx, c.code, input)
+}
+
+func (c *localAuth) ChangeForgotPassword(ctx context.Context, input auth.ChangeForgotPasswordInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	hash, err := password.Hash(input.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return c.store.SetPassword(ctx, input.Username, hash, auth.Confirmed)
+}
+
+func (c *localAuth) ChangePassword(ctx context.Context, input auth.ChangePasswordInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := c.userFromAccessToken(ctx, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	ok, err := password.Verify(input.OldPassword, usr.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return auth.ErrInvalidUsernameOrPassword
+	}
+
+	hash, err := password.Hash(input.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return c.store.SetPassword(ctx, usr.Username, hash, usr.Status)
+}
+
+func (c *localAuth) RevokeRefreshToken(ctx context.Context, input auth.RevokeRefreshTokenInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return c.store.RevokeRefreshToken(ctx, input.RefreshToken)
+}
+
+func (c *localAuth) createUser(ctx context.Context, username, name, plainPassword string, status auth.UserStatus) (string, error) {
+	if _, err := c.store.GetUserByUsername(ctx, username); err == nil {
+		return "", auth.ErrUserAlreadyExists
+	} else if err != auth.ErrUserNotFound {
+		return "", err
+	}
+
+	hash, err := password.Hash(plainPassword)
+	if err != nil {
+		return "", err
+	}
+
+	usr := &localUser{
+		ID:           uuid.NewString(),
+		Username:     username,
+		Name:         name,
+		PasswordHash: hash,
+		Status:       status,
+	}
+	if err := c.store.CreateUser(ctx, usr); err != nil {
+		return "", err
+	}
+	return usr.ID, nil
+}
+
+// userFromAccessToken mirrors Cognito's server side access token checks, including global sign out.
+func (c *localAuth) userFromAccessToken(ctx context.Context, accessToken string) (*localUser, error) {
+	_, claims, err := c.jwtVerify.ParseJWT(accessToken)
+	if err != nil || claims.TokenUse != "access" {
+		return nil, auth.ErrInvalidAccessCode
+	}
+
+	revoked, err := c.store.IsSessionRevoked(ctx, claims.OriginJti)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, auth.ErrInvalidAccessCode
+	}
+
+	usr, err := c.store.GetUserByID(ctx, claims.Sub)
+	if err != nil {
+		if err == auth.ErrUserNotFound {
+			return nil, auth.ErrInvalidAccessCode
+		}
+		return nil, err
+	}
+	return usr, nil
+}
+
+func (c *localAuth) challenge(ctx context.Context, usr *localUser, name string) (*auth.LoginOutput, error) {
+	session, err := c.store.CreateChallenge(ctx, usr.ID, name, time.Now().Add(challengeTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginOutput{
+		Session:  &session,
+		NextStep: &name,
+	}, nil
+}
+
+func (c *localAuth) issueTokens(ctx context.Context, usr *localUser) (*auth.LoginOutput, error) {
+	originJti := uuid.NewString()
+	authTime := time.Now()
+
+	refreshToken, err := c.store.CreateRefreshToken(ctx, usr.ID, originJti, authTime, authTime.Add(c.options.RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, idToken, err := c.signTokens(ctx, usr, originJti, authTime, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginOutput{
+		AccessToken:  &accessToken,
+		IdToken:      &idToken,
+		RefreshToken: &refreshToken,
+	}, nil
+}
+
+func (c *localAuth) signTokens(ctx context.Context, usr *localUser, originJti string, authTime time.Time, orgID string) (string, string, error) {
+	groups, err := c.store.ListGroups(ctx, usr.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	exp := now.Add(c.options.AccessTokenTTL).Unix()
+
+	accessToken, err := c.issuer.Sign(&jwt_verify.Claims{
+		Sub:        usr.ID,
+		Iss:        c.issuer.Issuer(),
+		TokenUse:   "access",
+		Username:   usr.Username,
+		UserGroups: groups,
+		AuthTime:   authTime.Unix(),
+		Iat:        now.Unix(),
+		Exp:        exp,
+		Jti:        uuid.NewString(),
+		OriginJti:  originJti,
+		OrgId:      orgID,
+	})
+	if err != nil {
+		c.logger.Error("Error signing access token %v", err)
+		return "", "", err
+	}
+
+	idToken, err := c.issuer.Sign(&jwt_verify.Claims{
+		Sub:             usr.ID,
+		Iss:             c.issuer.Issuer(),
+		Aud:             c.options.Audience,
+		TokenUse:        "id",
+		CognitoUsername: usr.Username,
+		UserGroups:      groups,
+		Email:           usr.Username,
+		EmailVerified:   usr.EmailVerified,
+		Name:            usr.Name,
+		AuthTime:        authTime.Unix(),
+		Iat:             now.Unix(),
+		Exp:             exp,
+		Jti:             uuid.NewString(),
+		OriginJti:       originJti,
+	})
+	if err != nil {
+		c.logger.Error("Error signing id token %v", err)
+		return "", "", err
+	}
+
+	return accessToken, idToken, nil
+}
@@ -0,0 +1,375 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/pkg/logger"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type localUser struct {
+	ID               string
+	Username         string
+	Name             string
+	PasswordHash     string
+	Status           auth.UserStatus
+	EmailVerified    bool
+	MFAEnabled       bool
+	Enabled          bool
+	MFASecret        sql.NullString
+	MFAPendingSecret sql.NullString
+	CreatedAt        time.Time
+}
+
+type localRefreshToken struct {
+	UserID    string
+	OriginJti string
+	// AuthTime is when the user signed in, kept across refreshes for the auth_time claim.
+	AuthTime  time.Time
+	ExpiresAt time.Time
+	RevokedAt sql.NullTime
+}
+
+type localAuthStore struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func newLocalAuthStore(db *sql.DB, logger logger.Logger) *localAuthStore {
+	return &localAuthStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (usr *localUser) toAuthUser() *auth.User {
+	return &auth.User{
+		Id:         usr.ID,
+		Email:      usr.Username,
+		Name:       usr.Name,
+		Status:     usr.Status,
+		Enabled:    usr.Enabled,
+		MFAEnabled: usr.MFAEnabled,
+		CreatedAt:  usr.CreatedAt,
+		Attributes: map[string]string{
+			"sub":            usr.ID,
+			"email":          usr.Username,
+			"name":           usr.Name,
+			"email_verified": strconv.FormatBool(usr.EmailVerified),
+		},
+	}
+}
+
+const localUserColumns = `id, username, name, password_hash, status, email_verified, mfa_enabled, enabled, mfa_secret, mfa_pending_secret, created_at`
+
+func scanLocalUser(row *sql.Row) (*localUser, error) {
+	var usr localUser
+	err := row.Scan(&usr.ID, &usr.Username, &usr.Name, &usr.PasswordHash, &usr.Status, &usr.EmailVerified, &usr.MFAEnabled, &usr.Enabled, &usr.MFASecret, &usr.MFAPendingSecret, &usr.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &usr, nil
+}
+
+func (s *localAuthStore) GetUserByUsername(ctx context.Context, username string) (*localUser, error) {
+	query := `SELECT ` + localUserColumns + ` FROM auth_users WHERE username = $1`
+	usr, err := scanLocalUser(s.db.QueryRowContext(ctx, query, username))
+	if err != nil && err != auth.ErrUserNotFound {
+		s.logger.Error("Error getting auth user by username: %v", err)
+	}
+	return usr, err
+}
+
+func (s *localAuthStore) GetUserByID(ctx context.Context, id string) (*localUser, error) {
+	query := `SELECT ` + localUserColumns + ` FROM auth_users WHERE id = $1`
+	usr, err := scanLocalUser(s.db.QueryRowContext(ctx, query, id))
+	if err != nil && err != auth.ErrUserNotFound {
+		s.logger.Error("Error getting auth user by ID: %v", err)
+	}
+	return usr, err
+}
+
+func (s *localAuthStore) CreateUser(ctx context.Context, usr *localUser) error {
+	query := `INSERT INTO auth_users (id, username, name, password_hash, status) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := s.db.ExecContext(ctx, query, usr.ID, usr.Username, usr.Name, usr.PasswordHash, usr.Status); err != nil {
+		s.logger.Error("Error creating auth user: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *localAuthStore) DeleteUser(ctx context.Context, username string) error {
+	query := `DELETE FROM auth_users WHERE username = $1`
+	return s.execAffectingUser(ctx, query, username)
+}
+
+func (s *localAuthStore) SetStatus(ctx context.Context, username string, status auth.UserStatus) error {
+	query := `UPDATE auth_users SET status = $1, updated_at = NOW() WHERE username = $2`
+	return s.execAffectingUser(ctx, query, status, username)
+}
+
+func (s *localAuthStore) SetEnabled(ctx context.Context, username string, enabled bool) error {
+	query := `UPDATE auth_users SET enabled = $1, updated_at = NOW() WHERE username = $2`
+	return s.execAffectingUser(ctx, query, enabled, username)
+}
+
+func (s *localAuthStore) SetPassword(ctx context.Context, username, passwordHash string, status auth.UserStatus) error {
+	query := `UPDATE auth_users SET password_hash = $1, status = $2, updated_at = NOW() WHERE username = $3`
+	return s.execAffectingUser(ctx, query, passwordHash, status, username)
+}
+
+func (s *localAuthStore) SetEmailVerified(ctx context.Context, username string) error {
+	query := `UPDATE auth_users SET email_verified = TRUE, updated_at = NOW() WHERE username = $1`
+	return s.execAffectingUser(ctx, query, username)
+}
+
+// ChangeUsername moves the user to a new email, which is verified by the caller.
+func (s *localAuthStore) ChangeUsername(ctx context.Context, username, newUsername string) error {
+	query := `UPDATE auth_users SET username = $1, email_verified = TRUE, updated_at = NOW() WHERE username = $2`
+	return s.execAffectingUser(ctx, query, newUsername, username)
+}
+
+func (s *localAuthStore) SetName(ctx context.Context, username, name string) error {
+	query := `UPDATE auth_users SET name = $1, updated_at = NOW() WHERE username = $2`
+	return s.execAffectingUser(ctx, query, name, username)
+}
+
+func (s *localAuthStore) SetPendingMFASecret(ctx context.Context, id, secret string) error {
+	query := `UPDATE auth_users SET mfa_pending_secret = $1, updated_at = NOW() WHERE id = $2`
+	return s.execAffectingUser(ctx, query, secret, id)
+}
+
+func (s *localAuthStore) EnableMFA(ctx context.Context, id, secret string) error {
+	query := `UPDATE auth_users SET mfa_enabled = TRUE, mfa_secret = $1, mfa_pending_secret = NULL, updated_at = NOW() WHERE id = $2`
+	return s.execAffectingUser(ctx, query, secret, id)
+}
+
+func (s *localAuthStore) DisableMFA(ctx context.Context, username string) error {
+	query := `UPDATE auth_users SET mfa_enabled = FALSE, mfa_secret = NULL, mfa_pending_secret = NULL, updated_at = NOW() WHERE username = $1`
+	return s.execAffectingUser(ctx, query, username)
+}
+
+func (s *localAuthStore) ListGroups(ctx context.Context, userID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT group_name FROM auth_user_groups WHERE user_id = $1 ORDER BY group_name`, userID)
+	if err != nil {
+		s.logger.Error("Error listing auth user groups: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []string{}
+	for rows.Next() {
+		var group string
+		if err := rows.Scan(&group); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// ListUsers returns up to limit users sorted by username, starting after the username after. An empty
+// group or status matches everything.
+func (s *localAuthStore) ListUsers(ctx context.Context, after string, limit int, group auth.UserGroup, status auth.UserStatus) ([]*localUser, error) {
+	query := `SELECT ` + localUserColumns + ` FROM auth_users u WHERE username > $1 AND ($2 = '' OR status = $2)
+		AND ($3 = '' OR EXISTS (SELECT 1 FROM auth_user_groups g WHERE g.user_id = u.id AND g.group_name = $3))
+		ORDER BY username LIMIT $4`
+	rows, err := s.db.QueryContext(ctx, query, after, string(status), string(group), limit)
+	if err != nil {
+		s.logger.Error("Error listing auth users: %v", err)
+		return nil, err
+	}
+	return scanLocalUsers(rows)
+}
+
+func (s *localAuthStore) GetUsersByUsername(ctx context.Context, usernames []string) ([]*localUser, error) {
+	query := `SELECT ` + localUserColumns + ` FROM auth_users WHERE username = ANY($1) ORDER BY username`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(usernames))
+	if err != nil {
+		s.logger.Error("Error getting auth users by username: %v", err)
+		return nil, err
+	}
+	return scanLocalUsers(rows)
+}
+
+func scanLocalUsers(rows *sql.Rows) ([]*localUser, error) {
+	defer rows.Close()
+
+	users := []*localUser{}
+	for rows.Next() {
+		var usr localUser
+		if err := rows.Scan(&usr.ID, &usr.Username, &usr.Name, &usr.PasswordHash, &usr.Status, &usr.EmailVerified, &usr.MFAEnabled, &usr.Enabled, &usr.MFASecret, &usr.MFAPendingSecret, &usr.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, &usr)
+	}
+	return users, rows.Err()
+}
+
+func (s *localAuthStore) AddGroup(ctx context.Context, username string, group auth.UserGroup) error {
+	query := `INSERT INTO auth_user_groups (user_id, group_name) SELECT id, $1 FROM auth_users WHERE username = $2 ON CONFLICT DO NOTHING`
+	if _, err := s.db.ExecContext(ctx, query, string(group), username); err != nil {
+		s.logger.Error("Error adding auth user group: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *localAuthStore) RemoveGroup(ctx context.Context, username string, group auth.UserGroup) error {
+	query := `DELETE FROM auth_user_groups WHERE group_name = $1 AND user_id = (SELECT id FROM auth_users WHERE username = $2)`
+	if _, err := s.db.ExecContext(ctx, query, string(group), username); err != nil {
+		s.logger.Error("Error removing auth user group: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *localAuthStore) DeleteGroup(ctx context.Context, group auth.UserGroup) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM auth_user_groups WHERE group_name = $1`, string(group)); err != nil {
+		s.logger.Error("Error deleting auth group: %v", err)
+		return err
+	}
+	return nil
+}
+
+// CreateRefreshToken stores the hash of a new opaque refresh token and returns the raw value.
+func (s *localAuthStore) CreateRefreshToken(ctx context.Context, userID, originJti string, authTime, expiresAt time.Time) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	query := `INSERT INTO auth_refresh_tokens (token_hash, user_id, origin_jti, auth_time, expires_at) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := s.db.ExecContext(ctx, query, hashToken(token), userID, originJti, authTime, expiresAt); err != nil {
+		s.logger.Error("Error creating refresh token: %v", err)
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *localAuthStore) GetRefreshToken(ctx context.Context, token string) (*localRefreshToken, error) {
+	var rt localRefreshToken
+	query := `SELECT user_id, origin_jti, auth_time, expires_at, revoked_at FROM auth_refresh_tokens WHERE token_hash = $1`
+	if err := s.db.QueryRowContext(ctx, query, hashToken(token)).Scan(&rt.UserID, &rt.OriginJti, &rt.AuthTime, &rt.ExpiresAt, &rt.RevokedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, auth.ErrInvalidRefreshToken
+		}
+		s.logger.Error("Error getting refresh token: %v", err)
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// IsSessionRevoked reports whether the refresh token that originated a token family was revoked.
+func (s *localAuthStore) IsSessionRevoked(ctx context.Context, originJti string) (bool, error) {
+	var revoked bool
+	query := `SELECT revoked_at IS NOT NULL FROM auth_refresh_tokens WHERE origin_jti = $1`
+	if err := s.db.QueryRowContext(ctx, query, originJti).Scan(&revoked); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		s.logger.Error("Error checking session revocation: %v", err)
+		return false, err
+	}
+	return revoked, nil
+}
+
+func (s *localAuthStore) RevokeRefreshToken(ctx context.Context, token string) error {
+	query := `UPDATE auth_refresh_tokens SET revoked_at = NOW() WHERE token_hash = $1 AND revoked_at IS NULL`
+	res, err := s.db.ExecContext(ctx, query, hashToken(token))
+	if err != nil {
+		s.logger.Error("Error revoking refresh token: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return auth.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+func (s *localAuthStore) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	query := `UPDATE auth_refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		s.logger.Error("Error revoking refresh tokens: %v", err)
+		return err
+	}
+	return nil
+}
+
+// CreateChallenge stores a pending login challenge and returns the session handed to the client.
+func (s *localAuthStore) CreateChallenge(ctx context.Context, userID, challengeName string, expiresAt time.Time) (string, error) {
+	session, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	query := `INSERT INTO auth_challenges (session_hash, user_id, challenge_name, expires_at) VALUES ($1, $2, $3, $4)`
+	if _, err := s.db.ExecContext(ctx, query, hashToken(session), userID, challengeName, expiresAt); err != nil {
+		s.logger.Error("Error creating auth challenge: %v", err)
+		return "", err
+	}
+	return session, nil
+}
+
+// ConsumeChallenge deletes the challenge and returns the user it belongs to if it is still valid.
+func (s *localAuthStore) ConsumeChallenge(ctx context.Context, session, challengeName string) (string, error) {
+	var userID string
+	var expiresAt time.Time
+	query := `DELETE FROM auth_challenges WHERE session_hash = $1 AND challenge_name = $2 RETURNING user_id, expires_at`
+	if err := s.db.QueryRowContext(ctx, query, hashToken(session), challengeName).Scan(&userID, &expiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return "", auth.ErrFailedToRespondToChallenge
+		}
+		s.logger.Error("Error consuming auth challenge: %v", err)
+		return "", err
+	}
+
+	if expiresAt.Before(time.Now()) {
+		return "", auth.ErrFailedToRespondToChallenge
+	}
+	return userID, nil
+}
+
+func (s *localAuthStore) execAffectingUser(ctx context.Context, query string, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		s.logger.Error("Error updating auth user: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return auth.ErrUserNotFound
+	}
+	return nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
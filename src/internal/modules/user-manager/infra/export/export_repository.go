@@ -0,0 +1,210 @@
+package export
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type ExportRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewExportRepository(db *sql.DB, logger logger.Logger) export.ExportRepository {
+	return &ExportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const exportColumns = `id, user_id, notify_email, format, status, expires_at, created_at, completed_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanExport(row rowScanner) (*export.DataExport, error) {
+	var exp export.DataExport
+	var completedAt sql.NullTime
+	if err := row.Scan(&exp.ID, &exp.UserID, &exp.NotifyEmail, &exp.Format, &exp.Status, &exp.ExpiresAt, &exp.CreatedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		exp.CompletedAt = &completedAt.Time
+	}
+	return &exp, nil
+}
+
+func (r *ExportRepository) GetByID(ctx context.Context, input *export.GetExportInput) (*export.DataExport, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + exportColumns + ` FROM data_exports WHERE id = $1 AND user_id = $2`
+	exp, err := scanExport(r.db.QueryRowContext(ctx, query, input.ID, input.UserID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, export.ErrExportNotFound
+		}
+		r.logger.Error("Error getting data export: %v", err)
+		return nil, err
+	}
+	return exp, nil
+}
+
+func (r *ExportRepository) GetData(ctx context.Context, input *export.GetExportInput) ([]byte, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	query := `SELECT data FROM data_exports WHERE id = $1 AND user_id = $2 AND status = $3`
+	if err := r.db.QueryRowContext(ctx, query, input.ID, input.UserID, export.StatusReady).Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, export.ErrExportNotReady
+		}
+		r.logger.Error("Error getting data export content: %v", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *ExportRepository) List(ctx context.Context, input *export.ListExportsInput) ([]export.DataExport, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + exportColumns + ` FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, input.UserID)
+	if err != nil {
+		r.logger.Error("Error listing data exports: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []export.DataExport{}
+	for rows.Next() {
+		exp, err := scanExport(rows)
+		if err != nil {
+			r.logger.Error("Error scanning data export: %v", err)
+			return nil, err
+		}
+		exports = append(exports, *exp)
+	}
+	return exports, rows.Err()
+}
+
+func (r *ExportRepository) Create(ctx context.Context, input *export.CreateExportInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM data_exports WHERE expires_at < NOW()`); err != nil {
+		r.logger.Error("Error deleting expired data exports: %v", err)
+		return err
+	}
+
+	query := `INSERT INTO data_exports (id, user_id, notify_email, format, status, expires_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) WHERE status = 'pending' DO NOTHING`
+	res, err := r.db.ExecContext(ctx, query, input.ID, input.UserID, input.NotifyEmail, input.Format, export.StatusPending, input.ExpiresAt)
+	if err != nil {
+		r.logger.Error("Error creating data export: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return export.ErrExportPending
+	}
+	return nil
+}
+
+func (r *ExportRepository) Complete(ctx context.Context, input *export.CompleteExportInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `UPDATE data_exports SET status = $1, data = $2, completed_at = NOW() WHERE id = $3 AND status = $4`
+	return r.execAffectingExport(ctx, query, export.StatusReady, input.Data, input.ID, export.StatusPending)
+}
+
+func (r *ExportRepository) Fail(ctx context.Context, id string) error {
+	query := `UPDATE data_exports SET status = $1, completed_at = NOW() WHERE id = $2 AND status = $3`
+	return r.execAffectingExport(ctx, query, export.StatusFailed, id, export.StatusPending)
+}
+
+func (r *ExportRepository) Claim(ctx context.Context, id string, lease time.Duration, maxAttempts int) (bool, error) {
+	query := `UPDATE data_exports SET locked_until = NOW() + make_interval(secs => $1), attempts = attempts + 1
+		WHERE id = $2 AND status = $3 AND attempts < $4 AND (locked_until IS NULL OR locked_until < NOW())`
+	res, err := r.db.ExecContext(ctx, query, lease.Seconds(), id, export.StatusPending, maxAttempts)
+	if err != nil {
+		r.logger.Error("Error claiming data export: %v", err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *ExportRepository) ListStale(ctx context.Context, unclaimedAfter time.Duration, maxAttempts int, limit int) ([]export.DataExport, error) {
+	query := `SELECT ` + exportColumns + ` FROM data_exports WHERE status = $1 AND attempts < $2
+		AND (locked_until < NOW() OR (locked_until IS NULL AND created_at < NOW() - make_interval(secs => $3)))
+		ORDER BY created_at LIMIT $4`
+	rows, err := r.db.QueryContext(ctx, query, export.StatusPending, maxAttempts, unclaimedAfter.Seconds(), limit)
+	if err != nil {
+		r.logger.Error("Error listing stale data exports: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []export.DataExport{}
+	for rows.Next() {
+		exp, err := scanExport(rows)
+		if err != nil {
+			r.logger.Error("Error scanning data export: %v", err)
+			return nil, err
+		}
+		exports = append(exports, *exp)
+	}
+	return exports, rows.Err()
+}
+
+func (r *ExportRepository) FailAbandoned(ctx context.Context, maxAttempts int) error {
+	query := `UPDATE data_exports SET status = $1, completed_at = NOW() WHERE status = $2 AND attempts >= $3 AND locked_until < NOW()`
+	if _, err := r.db.ExecContext(ctx, query, export.StatusFailed, export.StatusPending, maxAttempts); err != nil {
+		r.logger.Error("Error failing abandoned data exports: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *ExportRepository) DeleteByUser(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM data_exports WHERE user_id = $1`, userID); err != nil {
+		r.logger.Error("Error deleting data exports of user: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *ExportRepository) execAffectingExport(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating data export: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return export.ErrExportNotFound
+	}
+	return nil
+}
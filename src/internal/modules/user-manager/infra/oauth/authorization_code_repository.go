@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/logger"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+type AuthorizationCodeRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewAuthorizationCodeRepository(db *sql.DB, logger logger.Logger) oauth.AuthorizationCodeRepository {
+	return &AuthorizationCodeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AuthorizationCodeRepository) Create(ctx context.Context, input *oauth.CreateAuthorizationCodeInput) (string, error) {
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oauth_authorization_codes WHERE expires_at < NOW()`); err != nil {
+		r.logger.Error("Error deleting expired authorization codes: %v", err)
+		return "", err
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	sealedRefreshToken, err := sealToken(code, input.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	query := `INSERT INTO oauth_authorization_codes (code_hash, client_id, redirect_uri, scope, nonce, code_challenge, code_challenge_method, subject, email, name, sealed_refresh_token, auth_time, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err = r.db.ExecContext(ctx, query, hashToken(code), input.ClientID, input.RedirectURI, input.Scope, input.Nonce, input.CodeChallenge, input.CodeChallengeMethod,
+		input.Subject, input.Email, input.Name, sealedRefreshToken, input.AuthTime, input.ExpiresAt)
+	if err != nil {
+		r.logger.Error("Error creating authorization code: %v", err)
+		return "", err
+	}
+	return code, nil
+}
+
+func (r *AuthorizationCodeRepository) Consume(ctx context.Context, code string) (*oauth.AuthorizationCode, error) {
+	authCode := oauth.AuthorizationCode{Code: code}
+	var sealedRefreshToken string
+	query := `DELETE FROM oauth_authorization_codes WHERE code_hash = $1
+		RETURNING client_id, redirect_uri, scope, nonce, code_challenge, code_challenge_method, subject, email, name, sealed_refresh_token, auth_time, expires_at`
+	err := r.db.QueryRowContext(ctx, query, hashToken(code)).Scan(&authCode.ClientID, &authCode.RedirectURI, &authCode.Scope, &authCode.Nonce, &authCode.CodeChallenge,
+		&authCode.CodeChallengeMethod, &authCode.Subject, &authCode.Email, &authCode.Name, &sealedRefreshToken, &authCode.AuthTime, &authCode.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, oauth.ErrInvalidGrant
+		}
+		r.logger.Error("Error consuming authorization code: %v", err)
+		return nil, err
+	}
+
+	if authCode.ExpiresAt.Before(time.Now()) {
+		return nil, oauth.ErrInvalidGrant
+	}
+
+	refreshToken, err := openToken(code, sealedRefreshToken)
+	if err != nil {
+		r.logger.Error("Error opening authorization code refresh token: %v", err)
+		return nil, oauth.ErrInvalidGrant
+	}
+	authCode.RefreshToken = refreshToken
+	return &authCode, nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// codeCipher derives the key sealing the tokens of a code from the code itself, which is never stored.
+func codeCipher(code string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(code))
+	mac.Write([]byte("oauth_authorization_code_seal"))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealToken(code, token string) (string, error) {
+	aead, err := codeCipher(code)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(token), nil)), nil
+}
+
+func openToken(code, sealed string) (string, error) {
+	aead, err := codeCipher(code)
+	if err != nil {
+		return "", err
+	}
+	raw, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", errors.New("sealed token too short")
+	}
+	token, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
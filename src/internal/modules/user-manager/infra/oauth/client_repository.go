@@ -0,0 +1,143 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+type ClientRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewClientRepository(db *sql.DB, logger logger.Logger) oauth.ClientRepository {
+	return &ClientRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const clientColumns = `client_id, name, COALESCE(secret_hash, ''), redirect_uris, scopes, access_token_ttl, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanClient(row rowScanner) (*oauth.Client, error) {
+	var client oauth.Client
+	err := row.Scan(&client.ClientID, &client.Name, &client.SecretHash, pq.Array(&client.RedirectURIs), pq.Array(&client.Scopes), &client.AccessTokenTTL, &client.CreatedAt, &client.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *ClientRepository) GetByID(ctx context.Context, input *oauth.GetClientInput) (*oauth.Client, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + clientColumns + ` FROM oauth_clients WHERE client_id = $1`
+	client, err := scanClient(r.db.QueryRowContext(ctx, query, input.ClientID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, oauth.ErrClientNotFound
+		}
+		r.logger.Error("Error getting OAuth client by ID: %v", err)
+		return nil, err
+	}
+	return client, nil
+}
+
+func (r *ClientRepository) List(ctx context.Context) ([]oauth.Client, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+clientColumns+` FROM oauth_clients ORDER BY created_at`)
+	if err != nil {
+		r.logger.Error("Error listing OAuth clients: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []oauth.Client{}
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			r.logger.Error("Error scanning OAuth client: %v", err)
+			return nil, err
+		}
+		clients = append(clients, *client)
+	}
+	return clients, rows.Err()
+}
+
+func (r *ClientRepository) Create(ctx context.Context, input *oauth.CreateClientInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	var secretHash interface{}
+	if input.SecretHash != "" {
+		secretHash = input.SecretHash
+	}
+
+	query := `INSERT INTO oauth_clients (client_id, name, secret_hash, redirect_uris, scopes, access_token_ttl) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := r.db.ExecContext(ctx, query, input.ClientID, input.Name, secretHash, pq.Array(input.RedirectURIs), pq.Array(input.Scopes), input.AccessTokenTTL); err != nil {
+		r.logger.Error("Error creating OAuth client: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *ClientRepository) Update(ctx context.Context, input *oauth.UpdateClientInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	var redirectURIs, scopes interface{}
+	if input.RedirectURIs != nil {
+		redirectURIs = pq.Array(*input.RedirectURIs)
+	}
+	if input.Scopes != nil {
+		scopes = pq.Array(*input.Scopes)
+	}
+
+	query := `UPDATE oauth_clients SET name = COALESCE($1, name), redirect_uris = COALESCE($2, redirect_uris), scopes = COALESCE($3, scopes),
+		access_token_ttl = COALESCE($4, access_token_ttl), updated_at = NOW() WHERE client_id = $5`
+	return r.execAffectingClient(ctx, query, input.Name, redirectURIs, scopes, input.AccessTokenTTL, input.ClientID)
+}
+
+func (r *ClientRepository) SetSecret(ctx context.Context, input *oauth.SetClientSecretInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `UPDATE oauth_clients SET secret_hash = $1, updated_at = NOW() WHERE client_id = $2`
+	return r.execAffectingClient(ctx, query, input.SecretHash, input.ClientID)
+}
+
+func (r *ClientRepository) Delete(ctx context.Context, input *oauth.DeleteClientInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return r.execAffectingClient(ctx, `DELETE FROM oauth_clients WHERE client_id = $1`, input.ClientID)
+}
+
+func (r *ClientRepository) execAffectingClient(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating OAuth client: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return oauth.ErrClientNotFound
+	}
+	return nil
+}
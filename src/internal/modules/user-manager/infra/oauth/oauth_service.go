@@ -0,0 +1,174 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/jwt_issuer"
+	"auth-api/src/pkg/jwt_verify"
+	"auth-api/src/pkg/logger"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type OAuthService struct {
+	clients    oauth.ClientRepository
+	issuer     jwt_issuer.JWTIssuer
+	jwtVerify  jwt_verify.JWTVerify
+	idTokenTTL time.Duration
+	logger     logger.Logger
+}
+
+func NewOAuthService(clients oauth.ClientRepository, issuer jwt_issuer.JWTIssuer, idTokenTTL time.Duration, logger logger.Logger) oauth.OAuthService {
+	jwtVerify := jwt_verify.NewAuthWithJWK(issuer.JWK(), jwt_verify.ValidationRules{
+		Issuer:   issuer.Issuer(),
+		TokenUse: "access",
+	}, logger)
+
+	return &OAuthService{
+		clients:    clients,
+		issuer:     issuer,
+		jwtVerify:  jwtVerify,
+		idTokenTTL: idTokenTTL,
+		logger:     logger,
+	}
+}
+
+func (s *OAuthService) Issuer() string {
+	return s.issuer.Issuer()
+}
+
+func (s *OAuthService) JWK() *jwt_verify.JWK {
+	return s.issuer.JWK()
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce    string           `json:"nonce,omitempty"`
+	Email    string           `json:"email,omitempty"`
+	Name     string           `json:"name,omitempty"`
+}
+
+func (s *OAuthService) IssueIdToken(ctx context.Context, input oauth.IssueIdTokenInput) (string, error) {
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := idTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer.Issuer(),
+			Subject:   input.Subject,
+			Audience:  jwt.ClaimStrings{input.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.idTokenTTL)),
+		},
+		Nonce: input.Nonce,
+		Email: input.Email,
+		Name:  input.Name,
+	}
+	if !input.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(input.AuthTime)
+	}
+
+	token, err := s.issuer.Sign(claims)
+	if err != nil {
+		s.logger.Error("Error signing ID token: %v", err)
+		return "", err
+	}
+	return token, nil
+}
+
+type clientTokenClaims struct {
+	jwt.RegisteredClaims
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+	TokenUse string `json:"token_use"`
+}
+
+func (s *OAuthService) IssueClientToken(ctx context.Context, input oauth.IssueClientTokenInput) (string, error) {
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token, err := s.issuer.Sign(clientTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer.Issuer(),
+			Subject:   input.ClientID,
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(input.TTL)),
+		},
+		ClientID: input.ClientID,
+		Scope:    input.Scope,
+		TokenUse: "access",
+	})
+	if err != nil {
+		s.logger.Error("Error signing client token: %v", err)
+		return "", err
+	}
+	return token, nil
+}
+
+// IsClientToken checks the unverified claims: client credentials tokens come from this issuer and have the client as subject.
+func (s *OAuthService) IsClientToken(token string) bool {
+	claims := clientTokenClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return false
+	}
+	return claims.Issuer == s.issuer.Issuer() && claims.ClientID != "" && claims.Subject == claims.ClientID
+}
+
+func (s *OAuthService) ValidateClientToken(ctx context.Context, token string) (*oauth.ClientClaims, error) {
+	_, claims, err := s.jwtVerify.ParseJWT(token)
+	if err != nil {
+		return nil, oauth.ErrInvalidToken
+	}
+	if claims.Iss != s.issuer.Issuer() || claims.TokenUse != "access" || claims.ClientId == "" || claims.Sub != claims.ClientId {
+		return nil, oauth.ErrInvalidToken
+	}
+
+	// Deleting a client, removing its secret or its scopes takes effect on the tokens already issued.
+	client, err := s.clients.GetByID(ctx, &oauth.GetClientInput{ClientID: claims.ClientId})
+	if err != nil {
+		if err != oauth.ErrClientNotFound {
+			s.logger.Error("Error getting the client of a token: %v", err)
+		}
+		return nil, oauth.ErrInvalidToken
+	}
+	if !client.IsConfidential() {
+		return nil, oauth.ErrInvalidToken
+	}
+	scopes := []string{}
+	for _, scope := range strings.Fields(claims.Scope) {
+		if client.AllowsScopes(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &oauth.ClientClaims{
+		ClientID:  claims.ClientId,
+		Scopes:    scopes,
+		Jti:       claims.Jti,
+		IssuedAt:  time.Unix(claims.Iat, 0),
+		ExpiresAt: time.Unix(claims.Exp, 0),
+	}, nil
+}
+
+// TokenExpiresIn reads the exp claim without verifying the signature, the token comes straight from the auth provider.
+func (s *OAuthService) TokenExpiresIn(token string) int64 {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return 0
+	}
+
+	expiresIn := int64(time.Until(claims.ExpiresAt.Time).Seconds())
+	if expiresIn < 0 {
+		return 0
+	}
+	return expiresIn
+}
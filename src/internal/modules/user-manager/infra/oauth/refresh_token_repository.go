@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+const bindingColumns = `client_id, subject, username, origin_jti, scope, auth_time, created_at, expires_at`
+
+type RefreshTokenRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRefreshTokenRepository(db *sql.DB, logger logger.Logger) oauth.RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RefreshTokenRepository) Bind(ctx context.Context, input *oauth.BindRefreshTokenInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oauth_refresh_tokens WHERE expires_at < NOW()`); err != nil {
+		r.logger.Error("Error deleting expired refresh token bindings: %v", err)
+		return err
+	}
+
+	var authTime sql.NullTime
+	if !input.AuthTime.IsZero() {
+		authTime = sql.NullTime{Time: input.AuthTime, Valid: true}
+	}
+
+	query := `INSERT INTO oauth_refresh_tokens (token_hash, client_id, subject, username, origin_jti, scope, auth_time, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (token_hash) DO UPDATE SET client_id = EXCLUDED.client_id, subject = EXCLUDED.subject, username = EXCLUDED.username,
+		origin_jti = EXCLUDED.origin_jti, scope = EXCLUDED.scope, auth_time = EXCLUDED.auth_time, created_at = NOW(), expires_at = EXCLUDED.expires_at`
+	if _, err := r.db.ExecContext(ctx, query, hashToken(input.RefreshToken), input.ClientID, input.Subject, input.Username, input.OriginJti, input.Scope, authTime, input.ExpiresAt); err != nil {
+		r.logger.Error("Error binding refresh token: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) Get(ctx context.Context, refreshToken string) (*oauth.RefreshTokenBinding, error) {
+	query := `SELECT ` + bindingColumns + ` FROM oauth_refresh_tokens WHERE token_hash = $1 AND expires_at > NOW()`
+	binding, err := scanBinding(r.db.QueryRowContext(ctx, query, hashToken(refreshToken)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, oauth.ErrInvalidGrant
+		}
+		r.logger.Error("Error getting refresh token binding: %v", err)
+		return nil, err
+	}
+	return binding, nil
+}
+
+func (r *RefreshTokenRepository) GetByOriginJti(ctx context.Context, originJti string) (*oauth.RefreshTokenBinding, error) {
+	if originJti == "" {
+		return nil, oauth.ErrInvalidGrant
+	}
+
+	query := `SELECT ` + bindingColumns + ` FROM oauth_refresh_tokens WHERE origin_jti = $1 AND expires_at > NOW() ORDER BY created_at DESC LIMIT 1`
+	binding, err := scanBinding(r.db.QueryRowContext(ctx, query, originJti))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, oauth.ErrInvalidGrant
+		}
+		r.logger.Error("Error getting refresh token binding by origin jti: %v", err)
+		return nil, err
+	}
+	return binding, nil
+}
+
+func (r *RefreshTokenRepository) Delete(ctx context.Context, refreshToken string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oauth_refresh_tokens WHERE token_hash = $1`, hashToken(refreshToken)); err != nil {
+		r.logger.Error("Error deleting refresh token binding: %v", err)
+		return err
+	}
+	return nil
+}
+
+func scanBinding(row *sql.Row) (*oauth.RefreshTokenBinding, error) {
+	var binding oauth.RefreshTokenBinding
+	var authTime sql.NullTime
+	if err := row.Scan(&binding.ClientID, &binding.Subject, &binding.Username, &binding.OriginJti, &binding.Scope, &authTime, &binding.CreatedAt, &binding.ExpiresAt); err != nil {
+		return nil, err
+	}
+	binding.AuthTime = authTime.Time
+	return &binding, nil
+}
@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+type InvitationRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewInvitationRepository(db *sql.DB, logger logger.Logger) organization.InvitationRepository {
+	return &InvitationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// executor joins the transaction of the context, if any.
+func (r *InvitationRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+const invitationColumns = `id, organization_id, email, role_name, status, invited_by, version, expires_at, accepted_at, created_at, updated_at`
+
+func scanInvitation(row rowScanner) (*organization.Invitation, error) {
+	var inv organization.Invitation
+	var acceptedAt sql.NullTime
+	err := row.Scan(&inv.ID, &inv.OrganizationID, &inv.Email, &inv.Role, &inv.Status, &inv.InvitedBy, &inv.Version, &inv.ExpiresAt, &acceptedAt, &inv.CreatedAt, &inv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		inv.AcceptedAt = &acceptedAt.Time
+	}
+	return &inv, nil
+}
+
+func (r *InvitationRepository) GetByID(ctx context.Context, input *organization.GetInvitationInput) (*organization.Invitation, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE id = $1`
+	inv, err := scanInvitation(r.executor(ctx).QueryRowContext(ctx, query, input.ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, organization.ErrInvitationNotFound
+		}
+		r.logger.Error("Error getting invitation by ID: %v", err)
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *InvitationRepository) List(ctx context.Context, input *organization.ListInvitationsInput) ([]organization.Invitation, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + invitationColumns + ` FROM organization_invitations WHERE organization_id = $1 AND ($2 = '' OR status = $2) ORDER BY created_at DESC`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, input.OrganizationID, string(input.Status))
+	if err != nil {
+		r.logger.Error("Error listing invitations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []organization.Invitation{}
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			r.logger.Error("Error scanning invitation: %v", err)
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, input *organization.CreateInvitationInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	// Expired invitations are still pending, retire them so they do not block a new one.
+	query := `UPDATE organization_invitations SET status = 'revoked', updated_at = NOW()
+		WHERE organization_id = $1 AND email = $2 AND status = 'pending' AND expires_at <= NOW()`
+	if _, err := r.executor(ctx).ExecContext(ctx, query, input.OrganizationID, input.Email); err != nil {
+		r.logger.Error("Error expiring invitations: %v", err)
+		return err
+	}
+
+	query = `INSERT INTO organization_invitations (id, organization_id, email, role_name, status, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, 'pending', $5, $6) ON CONFLICT (organization_id, email) WHERE status = 'pending' DO NOTHING`
+	res, err := r.executor(ctx).ExecContext(ctx, query, input.ID, input.OrganizationID, input.Email, input.Role, input.InvitedBy, input.ExpiresAt)
+	if err != nil {
+		r.logger.Error("Error creating invitation: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrInvitationPending
+	}
+	return nil
+}
+
+func (r *InvitationRepository) Renew(ctx context.Context, input *organization.RenewInvitationInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `UPDATE organization_invitations SET version = version + 1, expires_at = $1, updated_at = NOW() WHERE id = $2 AND status = 'pending'`
+	return r.execAffectingInvitation(ctx, query, input.ExpiresAt, input.ID)
+}
+
+func (r *InvitationRepository) SetStatus(ctx context.Context, input *organization.SetInvitationStatusInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `UPDATE organization_invitations SET status = $1, accepted_at = CASE WHEN $1 = 'accepted' THEN NOW() END, updated_at = NOW()
+		WHERE id = $2 AND status = 'pending'`
+	return r.execAffectingInvitation(ctx, query, string(input.Status), input.ID)
+}
+
+// execAffectingInvitation only updates pending invitations, anything else is no longer usable.
+func (r *InvitationRepository) execAffectingInvitation(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating invitation: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrInvalidInvitation
+	}
+	return nil
+}
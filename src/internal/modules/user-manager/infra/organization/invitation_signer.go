@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	invitationTokenType = "org_invitation"
+	// minSecretLength is the HS256 key size.
+	minSecretLength = 32
+)
+
+// InvitationSigner signs the invitation links as short HS256 JWTs. The version claim ties a link to the
+// last time the invitation was sent.
+type InvitationSigner struct {
+	secret []byte
+}
+
+type invitationClaims struct {
+	jwt.RegisteredClaims
+	Version int    `json:"ver"`
+	Type    string `json:"typ"`
+}
+
+// NewInvitationSigner needs a secret shared by every instance, links signed by one must verify on the others
+// and keep working after a restart.
+func NewInvitationSigner(secret string) (organization.InvitationSigner, error) {
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("the invitation signing secret must be at least %d bytes", minSecretLength)
+	}
+	return &InvitationSigner{
+		secret: []byte(secret),
+	}, nil
+}
+
+func (s *InvitationSigner) Sign(invitation *organization.Invitation) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, invitationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        invitation.ID,
+			ExpiresAt: jwt.NewNumericDate(invitation.ExpiresAt),
+		},
+		Version: invitation.Version,
+		Type:    invitationTokenType,
+	})
+	return token.SignedString(s.secret)
+}
+
+func (s *InvitationSigner) Verify(token string) (*organization.InvitationToken, error) {
+	claims := invitationClaims{}
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != invitationTokenType || claims.ID == "" {
+		return nil, organization.ErrInvalidInvitation
+	}
+
+	return &organization.InvitationToken{
+		InvitationID: claims.ID,
+		Version:      claims.Version,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+type MemberRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewMemberRepository(db *sql.DB, logger logger.Logger) organization.MemberRepository {
+	return &MemberRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// executor joins the transaction of the context, if any.
+func (r *MemberRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+const memberColumns = `organization_id, user_id, email, role_name, created_at, updated_at`
+
+func scanMember(row rowScanner) (*organization.Member, error) {
+	var member organization.Member
+	if err := row.Scan(&member.OrganizationID, &member.UserID, &member.Email, &member.Role, &member.CreatedAt, &member.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *MemberRepository) Get(ctx context.Context, input *organization.GetMemberInput) (*organization.Member, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + memberColumns + ` FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	member, err := scanMember(r.executor(ctx).QueryRowContext(ctx, query, input.OrganizationID, input.UserID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, organization.ErrMemberNotFound
+		}
+		r.logger.Error("Error getting organization member: %v", err)
+		return nil, err
+	}
+	return member, nil
+}
+
+func (r *MemberRepository) List(ctx context.Context, input *organization.ListMembersInput) ([]organization.Member, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + memberColumns + ` FROM organization_members WHERE organization_id = $1 ORDER BY email`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, input.OrganizationID)
+	if err != nil {
+		r.logger.Error("Error listing organization members: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []organization.Member{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			r.logger.Error("Error scanning organization member: %v", err)
+			return nil, err
+		}
+		members = append(members, *member)
+	}
+	return members, rows.Err()
+}
+
+func (r *MemberRepository) ListMemberships(ctx context.Context, input *organization.ListMembershipsInput) ([]organization.Membership, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT o.id, o.name, o.slug, o.created_at, o.updated_at, m.role_name FROM organization_members m
+		JOIN organizations o ON o.id = m.organization_id WHERE m.user_id = $1 ORDER BY o.name`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, input.UserID)
+	if err != nil {
+		r.logger.Error("Error listing organization memberships: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := []organization.Membership{}
+	for rows.Next() {
+		var m organization.Membership
+		if err := rows.Scan(&m.ID, &m.Name, &m.Slug, &m.CreatedAt, &m.UpdatedAt, &m.Role); err != nil {
+			r.logger.Error("Error scanning organization membership: %v", err)
+			return nil, err
+		}
+		memberships = append(memberships, m)
+	}
+	return memberships, rows.Err()
+}
+
+func (r *MemberRepository) CountWithRole(ctx context.Context, organizationID string, role string) (int, error) {
+	// FOR UPDATE cannot be used with COUNT, the rows are locked and counted here instead
+	query := `SELECT user_id FROM organization_members WHERE organization_id = $1 AND role_name = $2 FOR UPDATE`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, organizationID, role)
+	if err != nil {
+		r.logger.Error("Error counting organization members: %v", err)
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
+
+func (r *MemberRepository) Add(ctx context.Context, input *organization.AddMemberInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO organization_members (organization_id, user_id, email, role_name) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, user_id) DO NOTHING`
+	res, err := r.executor(ctx).ExecContext(ctx, query, input.OrganizationID, input.UserID, input.Email, input.Role)
+	if err != nil {
+		r.logger.Error("Error adding organization member: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrMemberAlreadyExists
+	}
+	return nil
+}
+
+func (r *MemberRepository) UpdateRole(ctx context.Context, input *organization.UpdateMemberInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `UPDATE organization_members SET role_name = $1, updated_at = NOW() WHERE organization_id = $2 AND user_id = $3`
+	return r.execAffectingMember(ctx, query, input.Role, input.OrganizationID, input.UserID)
+}
+
+func (r *MemberRepository) UpdateEmail(ctx context.Context, userID string, email string) error {
+	query := `UPDATE organization_members SET email = $1, updated_at = NOW() WHERE user_id = $2`
+	if _, err := r.executor(ctx).ExecContext(ctx, query, email, userID); err != nil {
+		r.logger.Error("Error updating organization member email: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *MemberRepository) Remove(ctx context.Context, input *organization.RemoveMemberInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	return r.execAffectingMember(ctx, query, input.OrganizationID, input.UserID)
+}
+
+func (r *MemberRepository) execAffectingMember(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating organization member: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrMemberNotFound
+	}
+	return nil
+}
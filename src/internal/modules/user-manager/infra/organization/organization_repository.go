@@ -0,0 +1,137 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+type OrganizationRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewOrganizationRepository(db *sql.DB, logger logger.Logger) organization.OrganizationRepository {
+	return &OrganizationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const organizationColumns = `id, name, slug, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrganization(row rowScanner) (*organization.Organization, error) {
+	var org organization.Organization
+	if err := row.Scan(&org.ID, &org.Name, &org.Slug, &org.CreatedAt, &org.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, input *organization.GetOrganizationInput) (*organization.Organization, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE id = $1`
+	org, err := scanOrganization(r.db.QueryRowContext(ctx, query, input.ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		r.logger.Error("Error getting organization by ID: %v", err)
+		return nil, err
+	}
+	return org, nil
+}
+
+func (r *OrganizationRepository) List(ctx context.Context) ([]organization.Organization, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+organizationColumns+` FROM organizations ORDER BY name`)
+	if err != nil {
+		r.logger.Error("Error listing organizations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	orgs := []organization.Organization{}
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			r.logger.Error("Error scanning organization: %v", err)
+			return nil, err
+		}
+		orgs = append(orgs, *org)
+	}
+	return orgs, rows.Err()
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, input *organization.CreateOrganizationInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO organizations (id, name, slug) VALUES ($1, $2, $3) ON CONFLICT (slug) DO NOTHING`
+	res, err := r.db.ExecContext(ctx, query, input.ID, input.Name, input.Slug)
+	if err != nil {
+		r.logger.Error("Error creating organization: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrSlugAlreadyTaken
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, input *organization.UpdateOrganizationInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if input.Slug != nil {
+		var taken bool
+		query := `SELECT EXISTS (SELECT 1 FROM organizations WHERE slug = $1 AND id <> $2)`
+		if err := r.db.QueryRowContext(ctx, query, *input.Slug, input.ID).Scan(&taken); err != nil {
+			r.logger.Error("Error checking organization slug: %v", err)
+			return err
+		}
+		if taken {
+			return organization.ErrSlugAlreadyTaken
+		}
+	}
+
+	query := `UPDATE organizations SET name = COALESCE($1, name), slug = COALESCE($2, slug), updated_at = NOW() WHERE id = $3`
+	return r.execAffectingOrganization(ctx, query, input.Name, input.Slug, input.ID)
+}
+
+func (r *OrganizationRepository) Delete(ctx context.Context, input *organization.DeleteOrganizationInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return r.execAffectingOrganization(ctx, `DELETE FROM organizations WHERE id = $1`, input.ID)
+}
+
+func (r *OrganizationRepository) execAffectingOrganization(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating organization: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return organization.ErrOrganizationNotFound
+	}
+	return nil
+}
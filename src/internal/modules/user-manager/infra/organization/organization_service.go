@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/logger"
+	"context"
+	"net/http"
+)
+
+type OrganizationServiceImpl struct {
+	members organization.MemberRepository
+	roles   role.RoleService
+	logger  logger.Logger
+}
+
+func NewOrganizationServiceImpl(members organization.MemberRepository, roles role.RoleService, logger logger.Logger) organization.OrganizationService {
+	return &OrganizationServiceImpl{
+		members: members,
+		roles:   roles,
+		logger:  logger,
+	}
+}
+
+func (s *OrganizationServiceImpl) Authorize(ctx context.Context, input organization.AuthorizeInput) (*organization.Member, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	platformAdmin, err := s.roles.HasPermission(ctx, input.Groups, organization.PermissionOrgsManage)
+	if err != nil {
+		return nil, err
+	}
+	if platformAdmin {
+		return nil, nil
+	}
+
+	member, err := s.members.Get(ctx, &organization.GetMemberInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         input.UserID,
+	})
+	if err != nil {
+		if err == organization.ErrMemberNotFound {
+			return nil, organization.ErrNotAMember
+		}
+		return nil, err
+	}
+
+	allowed, err := s.roles.HasPermission(ctx, []string{member.Role}, input.Permission)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, app_error.NewApiError(http.StatusForbidden, "Missing permission", "Permission: "+input.Permission)
+	}
+	return member, nil
+}
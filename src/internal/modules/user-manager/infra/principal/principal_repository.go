@@ -0,0 +1,262 @@
+package principal
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/principal"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type PrincipalRepository struct {
+	db           *sql.DB
+	transactions transaction.UnitOfWork
+	logger       logger.Logger
+}
+
+func NewPrincipalRepository(db *sql.DB, transactions transaction.UnitOfWork, logger logger.Logger) principal.PrincipalRepository {
+	return &PrincipalRepository{
+		db:           db,
+		transactions: transactions,
+		logger:       logger,
+	}
+}
+
+const principalColumns = `p.id, p.name, p.email, p.phone, p.phone_verified, p.metadata, p.created_at, p.last_login_at,
+	ARRAY(SELECT r.role_name FROM principal_roles r WHERE r.principal_id = p.id ORDER BY r.role_name)`
+
+// hasRole matches the principals holding the role given as parameter n, or every principal when it is empty.
+func hasRole(n string) string {
+	return `($` + n + `::text = '' OR EXISTS (SELECT 1 FROM principal_roles r WHERE r.principal_id = p.id AND r.role_name = $` + n + `))`
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPrincipal(row rowScanner) (*principal.Principal, error) {
+	var p principal.Principal
+	var metadata []byte
+	var roles []string
+	if err := row.Scan(&p.ID, &p.Name, &p.Email, &p.Phone, &p.PhoneVerified, &metadata, &p.CreatedAt, &p.LastLoginAt, pq.Array(&roles)); err != nil {
+		return nil, err
+	}
+	p.Roles = roles
+	p.Attributes = map[string]interface{}{}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &p.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+// executor joins the transaction of the context, if any.
+func (r *PrincipalRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+func (r *PrincipalRepository) Get(ctx context.Context, input *principal.GetPrincipalInput) (*principal.Principal, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + principalColumns + ` FROM principals p WHERE p.id = $1 AND ` + hasRole("2")
+	args := []interface{}{input.ID, input.Role}
+	if input.ID == "" {
+		query = `SELECT ` + principalColumns + ` FROM principals p WHERE p.email = $1 AND ` + hasRole("2")
+		args = []interface{}{strings.ToLower(input.Email), input.Role}
+	}
+
+	p, err := scanPrincipal(r.executor(ctx).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, principal.ErrPrincipalNotFound
+		}
+		r.logger.Error("Error getting principal: %v", err)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *PrincipalRepository) List(ctx context.Context, input *principal.ListPrincipalsInput) (*principal.PrincipalPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var afterCreatedAt *time.Time
+	var afterID *string
+	if input.After != nil {
+		afterCreatedAt, afterID = &input.After.CreatedAt, &input.After.ID
+	}
+
+	var emails interface{}
+	if input.Emails != nil {
+		emails = pq.Array(input.Emails)
+	}
+
+	var search *string
+	if input.Search != "" {
+		pattern := "%" + escapeLike(input.Search) + "%"
+		search = &pattern
+	}
+
+	// Fetch one extra row to know whether there is a next page.
+	query := `SELECT ` + principalColumns + ` FROM principals p
+		WHERE ` + hasRole("1") + `
+		AND ($2::timestamptz IS NULL OR (p.created_at, p.id) < ($2, $3))
+		AND ($4::text IS NULL OR p.email ILIKE $4 OR p.name ILIKE $4)
+		AND ($5::timestamptz IS NULL OR p.created_at >= $5)
+		AND ($6::timestamptz IS NULL OR p.created_at < $6)
+		AND ($7::text[] IS NULL OR p.email = ANY($7))
+		ORDER BY p.created_at DESC, p.id DESC LIMIT $8`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, input.Role, afterCreatedAt, afterID, search, input.CreatedAfter, input.CreatedBefore, emails, input.Limit+1)
+	if err != nil {
+		r.logger.Error("Error listing principals: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &principal.PrincipalPage{Principals: []*principal.Principal{}}
+	for rows.Next() {
+		p, err := scanPrincipal(rows)
+		if err != nil {
+			r.logger.Error("Error scanning principal: %v", err)
+			return nil, err
+		}
+		page.Principals = append(page.Principals, p)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error listing principals: %v", err)
+		return nil, err
+	}
+
+	if len(page.Principals) > input.Limit {
+		page.Principals = page.Principals[:input.Limit]
+		page.NextCursor = principal.NewCursor(page.Principals[input.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *PrincipalRepository) Grant(ctx context.Context, input *principal.GrantRoleInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	attributes := input.Principal.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	metadata, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+
+	return r.transactions.WithTx(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO principals (id, name, email, phone, metadata) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET phone = COALESCE(principals.phone, EXCLUDED.phone), metadata = EXCLUDED.metadata || principals.metadata`
+		if _, err := r.executor(ctx).ExecContext(ctx, query, input.Principal.ID, input.Principal.Name, input.Principal.Email, input.Principal.Phone, metadata); err != nil {
+			// Another principal holds the email.
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				if input.Role == role.RoleAdmin {
+					return admin.ErrAdminAlreadyExists
+				}
+				return user.ErrUserAlreadyExists
+			}
+			r.logger.Error("Error creating principal: %v", err)
+			return err
+		}
+
+		query = `INSERT INTO principal_roles (principal_id, role_name) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		if _, err := r.executor(ctx).ExecContext(ctx, query, input.Principal.ID, input.Role); err != nil {
+			r.logger.Error("Error granting principal role: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *PrincipalRepository) Revoke(ctx context.Context, input *principal.RevokeRoleInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return r.transactions.WithTx(ctx, func(ctx context.Context) error {
+		query := `DELETE FROM principal_roles WHERE principal_id = $1 AND role_name = $2`
+		if _, err := r.executor(ctx).ExecContext(ctx, query, input.ID, input.Role); err != nil {
+			r.logger.Error("Error revoking principal role: %v", err)
+			return err
+		}
+
+		query = `DELETE FROM principals p WHERE p.id = $1 AND NOT EXISTS (SELECT 1 FROM principal_roles r WHERE r.principal_id = p.id)`
+		if _, err := r.executor(ctx).ExecContext(ctx, query, input.ID); err != nil {
+			r.logger.Error("Error deleting principal: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *PrincipalRepository) Update(ctx context.Context, input *principal.UpdatePrincipalInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	var attributes []byte
+	if input.Attributes != nil {
+		encoded, err := json.Marshal(input.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = encoded
+	}
+
+	query := `UPDATE principals SET name = COALESCE($1, name), email = COALESCE($2, email), phone = COALESCE($3, phone),
+		phone_verified = phone_verified AND ($3::varchar IS NULL OR $3::varchar = phone),
+		metadata = CASE WHEN $5::jsonb IS NULL THEN metadata ELSE jsonb_strip_nulls(metadata || $5::jsonb) END WHERE id = $4`
+	if _, err := r.executor(ctx).ExecContext(ctx, query, input.Name, input.Email, input.Phone, input.ID, attributes); err != nil {
+		r.logger.Error("Error updating principal: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *PrincipalRepository) MarkPhoneVerified(ctx context.Context, input *principal.MarkPhoneVerifiedInput) (bool, error) {
+	if err := input.Validate(); err != nil {
+		return false, err
+	}
+
+	query := `UPDATE principals SET phone_verified = TRUE WHERE id = $1 AND phone = $2`
+	res, err := r.executor(ctx).ExecContext(ctx, query, input.ID, input.Phone)
+	if err != nil {
+		r.logger.Error("Error marking principal phone as verified: %v", err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *PrincipalRepository) RecordLogin(ctx context.Context, email string) error {
+	query := `UPDATE principals SET last_login_at = NOW() WHERE email = $1`
+	if _, err := r.executor(ctx).ExecContext(ctx, query, strings.ToLower(email)); err != nil {
+		r.logger.Error("Error recording principal login: %v", err)
+		return err
+	}
+	return nil
+}
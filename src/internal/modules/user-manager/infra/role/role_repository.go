@@ -0,0 +1,147 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+type RoleRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRoleRepository(db *sql.DB, logger logger.Logger) role.RoleRepository {
+	return &RoleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const roleColumns = `name, description, permissions, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRole(row rowScanner) (*role.Role, error) {
+	var r role.Role
+	if err := row.Scan(&r.Name, &r.Description, pq.Array(&r.Permissions), &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *RoleRepository) GetByName(ctx context.Context, input *role.GetRoleInput) (*role.Role, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE name = $1`
+	found, err := scanRole(r.db.QueryRowContext(ctx, query, input.Name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, role.ErrRoleNotFound
+		}
+		r.logger.Error("Error getting role by name: %v", err)
+		return nil, err
+	}
+	return found, nil
+}
+
+func (r *RoleRepository) List(ctx context.Context) ([]role.Role, error) {
+	return r.list(ctx, `SELECT `+roleColumns+` FROM roles ORDER BY name`)
+}
+
+func (r *RoleRepository) ListByNames(ctx context.Context, names []string) ([]role.Role, error) {
+	if len(names) == 0 {
+		return []role.Role{}, nil
+	}
+	return r.list(ctx, `SELECT `+roleColumns+` FROM roles WHERE name = ANY($1) ORDER BY name`, pq.Array(names))
+}
+
+func (r *RoleRepository) list(ctx context.Context, query string, args ...interface{}) ([]role.Role, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error listing roles: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []role.Role{}
+	for rows.Next() {
+		found, err := scanRole(rows)
+		if err != nil {
+			r.logger.Error("Error scanning role: %v", err)
+			return nil, err
+		}
+		roles = append(roles, *found)
+	}
+	return roles, rows.Err()
+}
+
+func (r *RoleRepository) Create(ctx context.Context, input *role.CreateRoleInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO roles (name, description, permissions) VALUES ($1, $2, $3) ON CONFLICT (name) DO NOTHING`
+	res, err := r.db.ExecContext(ctx, query, input.Name, input.Description, pq.Array(input.Permissions))
+	if err != nil {
+		r.logger.Error("Error creating role: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return role.ErrRoleAlreadyExists
+	}
+	return nil
+}
+
+func (r *RoleRepository) Update(ctx context.Context, input *role.UpdateRoleInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	var permissions interface{}
+	if input.Permissions != nil {
+		permissions = pq.Array(*input.Permissions)
+	}
+
+	query := `UPDATE roles SET description = COALESCE($1, description), permissions = COALESCE($2, permissions), updated_at = NOW() WHERE name = $3`
+	return r.execAffectingRole(ctx, query, input.Description, permissions, input.Name)
+}
+
+func (r *RoleRepository) Delete(ctx context.Context, input *role.DeleteRoleInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	err := r.execAffectingRole(ctx, `DELETE FROM roles WHERE name = $1`, input.Name)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+		return role.ErrRoleInUse
+	}
+	return err
+}
+
+func (r *RoleRepository) execAffectingRole(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Error updating role: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return role.ErrRoleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/pkg/logger"
+	"context"
+	"sync"
+	"time"
+)
+
+// RoleServiceImpl answers permission checks from an in-memory copy of the roles table, reloaded
+// every cacheTTL so that role changes made on other instances are picked up.
+type RoleServiceImpl struct {
+	repo     role.RoleRepository
+	cacheTTL time.Duration
+	logger   logger.Logger
+
+	mu       sync.Mutex
+	roles    map[string]role.Role
+	loadedAt time.Time
+}
+
+func NewRoleServiceImpl(repo role.RoleRepository, cacheTTL time.Duration, logger logger.Logger) role.RoleService {
+	return &RoleServiceImpl{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}
+}
+
+func (s *RoleServiceImpl) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	cached, err := s.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range roles {
+		if r, ok := cached[name]; ok && r.HasPermission(permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *RoleServiceImpl) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = time.Time{}
+}
+
+func (s *RoleServiceImpl) load(ctx context.Context) (map[string]role.Role, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.roles != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.roles, nil
+	}
+
+	roles, err := s.repo.List(ctx)
+	if err != nil {
+		s.logger.Error("Error loading roles: %v", err)
+		return nil, err
+	}
+
+	s.roles = make(map[string]role.Role, len(roles))
+	for _, r := range roles {
+		s.roles[r.Name] = r
+	}
+	s.loadedAt = time.Now()
+	return s.roles, nil
+}
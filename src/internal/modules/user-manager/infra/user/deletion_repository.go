@@ -0,0 +1,98 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DeletionRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewDeletionRepository(db *sql.DB, logger logger.Logger) user.DeletionRepository {
+	return &DeletionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// executor joins the transaction of the context, if any.
+func (r *DeletionRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+func (r *DeletionRepository) Get(ctx context.Context, userID user.UserID) (*user.PendingDeletion, error) {
+	var deletion user.PendingDeletion
+	query := `SELECT user_id, email, requested_at, scheduled_for FROM account_deletions WHERE user_id = $1`
+	err := r.executor(ctx).QueryRowContext(ctx, query, userID.String()).Scan(&deletion.UserID, &deletion.Email, &deletion.RequestedAt, &deletion.ScheduledFor)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, user.ErrDeletionNotFound
+		}
+		r.logger.Error("Error getting account deletion: %v", err)
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (r *DeletionRepository) Create(ctx context.Context, deletion *user.PendingDeletion) error {
+	query := `INSERT INTO account_deletions (user_id, email, requested_at, scheduled_for) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id) DO NOTHING`
+	res, err := r.executor(ctx).ExecContext(ctx, query, deletion.UserID.String(), deletion.Email, deletion.RequestedAt, deletion.ScheduledFor)
+	if err != nil {
+		r.logger.Error("Error creating account deletion: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return user.ErrDeletionAlreadyRequested
+	}
+	return nil
+}
+
+func (r *DeletionRepository) Delete(ctx context.Context, userID user.UserID) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `DELETE FROM account_deletions WHERE user_id = $1`, userID.String())
+	if err != nil {
+		r.logger.Error("Error deleting account deletion: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return user.ErrDeletionNotFound
+	}
+	return nil
+}
+
+func (r *DeletionRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*user.PendingDeletion, error) {
+	query := `UPDATE account_deletions SET locked_until = NOW() + make_interval(secs => $3)
+		WHERE user_id IN (SELECT user_id FROM account_deletions WHERE scheduled_for <= $1 AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY scheduled_for LIMIT $2 FOR UPDATE SKIP LOCKED)
+		RETURNING user_id, email, requested_at, scheduled_for`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, now, limit, lease.Seconds())
+	if err != nil {
+		r.logger.Error("Error claiming due account deletions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	deletions := []*user.PendingDeletion{}
+	for rows.Next() {
+		var deletion user.PendingDeletion
+		if err := rows.Scan(&deletion.UserID, &deletion.Email, &deletion.RequestedAt, &deletion.ScheduledFor); err != nil {
+			r.logger.Error("Error scanning account deletion: %v", err)
+			return nil, err
+		}
+		deletions = append(deletions, &deletion)
+	}
+	return deletions, rows.Err()
+}
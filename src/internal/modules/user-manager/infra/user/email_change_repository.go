@@ -0,0 +1,66 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+type EmailChangeRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewEmailChangeRepository(db *sql.DB, logger logger.Logger) user.EmailChangeRepository {
+	return &EmailChangeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// executor joins the transaction of the context, if any.
+func (r *EmailChangeRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+func (r *EmailChangeRepository) Get(ctx context.Context, userID user.UserID) (*user.EmailChange, error) {
+	var change user.EmailChange
+	query := `SELECT user_id, new_email, requested_at FROM email_changes WHERE user_id = $1`
+	err := r.executor(ctx).QueryRowContext(ctx, query, userID.String()).Scan(&change.UserID, &change.NewEmail, &change.RequestedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, user.ErrEmailChangeNotFound
+		}
+		r.logger.Error("Error getting email change: %v", err)
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *EmailChangeRepository) Save(ctx context.Context, change *user.EmailChange) error {
+	query := `INSERT INTO email_changes (user_id, new_email, requested_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET new_email = EXCLUDED.new_email, requested_at = EXCLUDED.requested_at`
+	if _, err := r.executor(ctx).ExecContext(ctx, query, change.UserID.String(), change.NewEmail, change.RequestedAt); err != nil {
+		r.logger.Error("Error saving email change: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) Delete(ctx context.Context, userID user.UserID) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `DELETE FROM email_changes WHERE user_id = $1`, userID.String())
+	if err != nil {
+		r.logger.Error("Error deleting email change: %v", err)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return user.ErrEmailChangeNotFound
+	}
+	return nil
+}
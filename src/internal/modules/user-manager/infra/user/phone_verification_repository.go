@@ -0,0 +1,42 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type PhoneVerificationRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewPhoneVerificationRepository(db *sql.DB, logger logger.Logger) user.PhoneVerificationRepository {
+	return &PhoneVerificationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PhoneVerificationRepository) RecordSend(ctx context.Context, userID user.UserID, phone string, window time.Duration, maxSends int) (bool, error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM phone_verification_sends WHERE sent_at < NOW() - make_interval(secs => $1)`, window.Seconds()); err != nil {
+		r.logger.Error("Error deleting old phone verification sends: %v", err)
+		return false, err
+	}
+
+	query := `INSERT INTO phone_verification_sends (user_id, phone) SELECT $1, $2
+		WHERE (SELECT COUNT(*) FROM phone_verification_sends WHERE user_id = $1) < $3
+		AND (SELECT COUNT(*) FROM phone_verification_sends WHERE phone = $2) < $3`
+	res, err := r.db.ExecContext(ctx, query, userID.String(), phone, maxSends)
+	if err != nil {
+		r.logger.Error("Error recording phone verification send: %v", err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
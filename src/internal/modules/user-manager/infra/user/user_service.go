@@ -1,111 +1,195 @@
 package user
 
-import "auth-api/src/internal/modules/user-manager/domain/user"
-
+import (
+	"auth-api/src/internal/modules/user-manager/domain/principal"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"context"
+	"slices"
+)
+
+// UserService stores the user profiles as the principals holding the User role.
 type UserService struct {
-	repo user.UserRepository
+	principals principal.PrincipalRepository
 }
 
-func NewUserService(repo user.UserRepository) user.UserService {
+func NewUserService(principals principal.PrincipalRepository) user.UserService {
 	return &UserService{
-		repo: repo,
+		principals: principals,
 	}
 }
 
-func (u *UserService) GetByID(input *user.GetUserInput) (*user.User, error) {
+func (u *UserService) GetByID(ctx context.Context, input *user.GetUserInput) (*user.User, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	return u.repo.GetByID(input)
+	return u.get(ctx, &principal.GetPrincipalInput{ID: input.ID, Role: role.RoleUser})
 }
 
-func (u *UserService) GetByEmail(email *user.GetUserByEmailInput) (*user.User, error) {
-	if err := email.Validate(); err != nil {
+func (u *UserService) List(ctx context.Context, input *user.ListUsersInput) (*user.UserPage, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	return u.repo.GetByEmail(email)
+	page, err := u.principals.List(ctx, &principal.ListPrincipalsInput{
+		Role:          role.RoleUser,
+		After:         input.After(),
+		Search:        input.Search,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		Emails:        input.Emails,
+		Limit:         input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := &user.UserPage{Users: make([]*user.User, len(page.Principals)), NextCursor: page.NextCursor}
+	for i, p := range page.Principals {
+		usr, err := toUser(p)
+		if err != nil {
+			return nil, err
+		}
+		users.Users[i] = usr
+	}
+	return users, nil
 }
 
-func (u *UserService) Create(input *user.CreateUserInput) (*user.CreateUserOutput, error) {
-	if err := input.Validate(); err != nil {
+func (u *UserService) GetByEmail(ctx context.Context, email *user.GetUserByEmailInput) (*user.User, error) {
+	if err := email.Validate(); err != nil {
 		return nil, err
 	}
 
-	getUserByEmailInput := user.GetUserByEmailInput{Email: input.Email}
-	if err := getUserByEmailInput.Validate(); err != nil {
+	return u.get(ctx, &principal.GetPrincipalInput{Email: email.Email, Role: role.RoleUser})
+}
+
+// Create gives the User role to the principal, an admin keeping its profile. The email can only belong to the
+// principal itself.
+func (u *UserService) Create(ctx context.Context, input *user.CreateUserInput) (*user.CreateUserOutput, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	userExists, err := u.repo.GetByEmail(&getUserByEmailInput)
+	existing, err := u.principals.Get(ctx, &principal.GetPrincipalInput{Email: input.Email})
 	if err != nil {
-		if err != user.ErrUserNotFound {
+		if err != principal.ErrPrincipalNotFound {
 			return nil, err
 		}
 	}
 
-	if userExists != nil {
+	if existing != nil && (existing.ID != input.ID.String() || slices.Contains(existing.Roles, role.RoleUser)) {
 		return nil, user.ErrUserAlreadyExists
 	}
 
 	out := user.NewCreateUserOutput(&input.ID, u)
 
-	if err := u.repo.Create(input); err != nil {
+	grantRoleInput := &principal.GrantRoleInput{
+		Principal: &principal.Principal{
+			ID:         input.ID.String(),
+			Name:       input.Name,
+			Email:      input.Email,
+			Phone:      input.Phone,
+			Attributes: input.Attributes,
+		},
+		Role: role.RoleUser,
+	}
+	if err := u.principals.Grant(ctx, grantRoleInput); err != nil {
 		return nil, err
 	}
 
 	return out, nil
 }
 
-func (u *UserService) Update(input *user.UpdateUserInput) (*user.UpdateUserOutput, error) {
+func (u *UserService) Update(ctx context.Context, input *user.UpdateUserInput) (*user.UpdateUserOutput, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	getUserInput := user.GetUserInput{ID: input.ID.String()}
-	if err := getUserInput.Validate(); err != nil {
+	userOut, err := u.get(ctx, &principal.GetPrincipalInput{ID: input.ID.String(), Role: role.RoleUser})
+	if err != nil {
 		return nil, err
 	}
 
-	userOut, err := u.repo.GetByID(&getUserInput)
+	out := user.NewUpdateUserOutput(userOut, u)
+
+	updatePrincipalInput := &principal.UpdatePrincipalInput{
+		ID:         input.ID.String(),
+		Name:       input.Name,
+		Email:      input.Email,
+		Phone:      input.Phone,
+		Attributes: input.Attributes,
+	}
+	return out, u.principals.Update(ctx, updatePrincipalInput)
+}
+
+func (u *UserService) MarkPhoneVerified(ctx context.Context, input *user.MarkPhoneVerifiedInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	verified, err := u.principals.MarkPhoneVerified(ctx, &principal.MarkPhoneVerifiedInput{ID: input.ID.String(), Phone: input.Phone})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if userOut == nil {
-		return nil, user.ErrUserNotFound
+	if !verified {
+		return user.ErrPhoneChanged
 	}
+	return nil
+}
 
-	out := user.NewUpdateUserOutput(userOut, u)
+func (u *UserService) RecordLogin(ctx context.Context, input *user.RecordLoginInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
 
-	return out, u.repo.Update(input)
+	return u.principals.RecordLogin(ctx, input.Email)
 }
 
-func (u *UserService) Delete(id *user.DeleteUserInput) (*user.DeleteUserOutput, error) {
+// Delete takes the User role back, the profile is only deleted when the principal is not an admin.
+func (u *UserService) Delete(ctx context.Context, id *user.DeleteUserInput) (*user.DeleteUserOutput, error) {
 	if err := id.Validate(); err != nil {
 		return nil, err
 	}
 
-	getUserInput := user.GetUserInput{ID: id.ID.String()}
-	if err := getUserInput.Validate(); err != nil {
-		return nil, err
-	}
-
-	userOut, err := u.repo.GetByID(&getUserInput)
+	userOut, err := u.get(ctx, &principal.GetPrincipalInput{ID: id.ID.String(), Role: role.RoleUser})
 	if err != nil {
 		return nil, err
 	}
 
-	if userOut == nil {
-		return nil, user.ErrUserNotFound
-	}
-
-	out := user.NewDeleteUserOutput(userOut, u.repo)
+	out := user.NewDeleteUserOutput(userOut, u)
 
-	if err := u.repo.Delete(id); err != nil {
+	if err := u.principals.Revoke(ctx, &principal.RevokeRoleInput{ID: id.ID.String(), Role: role.RoleUser}); err != nil {
 		return nil, err
 	}
 
 	return out, nil
+}
 
+func (u *UserService) get(ctx context.Context, input *principal.GetPrincipalInput) (*user.User, error) {
+	p, err := u.principals.Get(ctx, input)
+	if err != nil {
+		if err == principal.ErrPrincipalNotFound {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return toUser(p)
+}
+
+func toUser(p *principal.Principal) (*user.User, error) {
+	id, err := user.ParseUserID(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &user.User{
+		ID:            id,
+		Name:          p.Name,
+		Email:         p.Email,
+		Phone:         p.Phone,
+		PhoneVerified: p.PhoneVerified,
+		Attributes:    user.Attributes(p.Attributes),
+		CreatedAt:     p.CreatedAt,
+		LastLoginAt:   p.LastLoginAt,
+	}, nil
 }
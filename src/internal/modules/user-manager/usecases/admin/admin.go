@@ -3,6 +3,7 @@ package admin
 import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 )
 
@@ -11,9 +12,9 @@ type UseCases struct {
 	Update   *UpdateAdminUseCase
 }
 
-func NewUseCases(adminService admin.AdminService, authService auth.AuthService, logger logger.Logger) *UseCases {
+func NewUseCases(adminService admin.AdminService, authService auth.AuthService, sagaService saga.SagaService, logger logger.Logger) *UseCases {
 	return &UseCases{
-		Register: NewRegisterAdminUseCase(adminService, authService, logger),
-		Update:   NewUpdateAdminUseCase(adminService, logger),
+		Register: NewRegisterAdminUseCase(adminService, authService, sagaService, logger),
+		Update:   NewUpdateAdminUseCase(adminService, authService, sagaService, logger),
 	}
 }
@@ -0,0 +1,133 @@
+package admin_test
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	admin_usecases "auth-api/src/internal/modules/user-manager/usecases/admin"
+	"auth-api/src/internal/modules/user-manager/usecases/usecasetest"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/saga/sagatest"
+	"context"
+	"errors"
+	"testing"
+)
+
+type fixture struct {
+	auth     auth.AuthService
+	admins   *usecasetest.Admins
+	sagas    *sagatest.Repository
+	useCases *admin_usecases.UseCases
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	log := usecasetest.NewLogger(t)
+	authService, _ := usecasetest.NewAuthService(t, log)
+	f := &fixture{auth: authService, admins: usecasetest.NewAdmins(), sagas: sagatest.NewRepository()}
+	f.useCases = admin_usecases.NewUseCases(f.admins, authService, sagatest.NewService(f.sagas, saga_infra.Options{}, log), log)
+	return f
+}
+
+func (f *fixture) register(email string) error {
+	return f.useCases.Register.Execute(context.Background(), admin_usecases.RegisterAdminInput{
+		SignupAdmin:      auth.CreateAdminInput{Username: email, Password: usecasetest.Password, Name: "Ada Admin"},
+		CreateAdminInput: admin.CreateAdminInput{Email: email, Name: "Ada Admin"},
+	})
+}
+
+func TestRegisterAdminCreatesTheAuthUserAndTheProfile(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	if err := f.register("ada@example.com"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	authUser, err := f.auth.GetUser(ctx, auth.GetUserInput{Username: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	profile, err := f.admins.GetByID(ctx, &admin.GetAdminInput{ID: authUser.Id})
+	if err != nil {
+		t.Fatalf("the profile was not created under the auth user ID: %v", err)
+	}
+	if profile.Email != "ada@example.com" || profile.Name != "Ada Admin" {
+		t.Errorf("profile = %+v", profile)
+	}
+	groups, err := f.auth.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("ListUserGroups: %v", err)
+	}
+	if len(groups) != 1 || groups[0] != string(auth.GroupAdmin) {
+		t.Errorf("groups = %v, want the Admin group", groups)
+	}
+}
+
+func TestRegisterAdminRefusesAnExistingEmail(t *testing.T) {
+	f := newFixture(t)
+	if err := f.register("ada@example.com"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if err := f.register("ADA@example.com"); err != admin.ErrAdminAlreadyExists {
+		t.Fatalf("Register = %v, want ErrAdminAlreadyExists", err)
+	}
+	if sagas := f.sagas.Sagas(); len(sagas) != 1 {
+		t.Errorf("%d sagas started, want the second registration refused up front", len(sagas))
+	}
+}
+
+func TestRegisterAdminDeletesTheAuthUserWhenTheProfileFails(t *testing.T) {
+	f := newFixture(t)
+	f.admins.CreateErr = errors.New("database down")
+
+	if err := f.register("ada@example.com"); err != f.admins.CreateErr {
+		t.Fatalf("Register = %v, want the profile error", err)
+	}
+
+	if _, err := f.auth.GetUser(context.Background(), auth.GetUserInput{Username: "ada@example.com"}); err != auth.ErrUserNotFound {
+		t.Errorf("GetUser = %v, want the auth user deleted by the compensation", err)
+	}
+}
+
+func TestUpdateAdminRenamesTheAuthUser(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	if err := f.register("ada@example.com"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	authUser, _ := f.auth.GetUser(ctx, auth.GetUserInput{Username: "ada@example.com"})
+	id, _ := admin.ParseAdminID(authUser.Id)
+
+	name := "Ada Lovelace"
+	if err := f.useCases.Update.Execute(ctx, admin_usecases.UpdateAdminInput{UpdateAdminInput: admin.UpdateAdminInput{ID: id, Name: &name}}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if authUser, _ := f.auth.GetUser(ctx, auth.GetUserInput{Username: "ada@example.com"}); authUser.Name != name {
+		t.Errorf("auth name = %q, want %q", authUser.Name, name)
+	}
+	if profile, _ := f.admins.GetByID(ctx, &admin.GetAdminInput{ID: id.String()}); profile.Name != name {
+		t.Errorf("profile name = %q, want %q", profile.Name, name)
+	}
+}
+
+func TestUpdateAdminRestoresTheAuthNameWhenTheProfileFails(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	if err := f.register("ada@example.com"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	authUser, _ := f.auth.GetUser(ctx, auth.GetUserInput{Username: "ada@example.com"})
+	id, _ := admin.ParseAdminID(authUser.Id)
+	f.admins.UpdateErr = errors.New("database down")
+
+	name := "Ada Lovelace"
+	if err := f.useCases.Update.Execute(ctx, admin_usecases.UpdateAdminInput{UpdateAdminInput: admin.UpdateAdminInput{ID: id, Name: &name}}); err != f.admins.UpdateErr {
+		t.Fatalf("Update = %v, want the profile error", err)
+	}
+
+	if authUser, _ := f.auth.GetUser(ctx, auth.GetUserInput{Username: "ada@example.com"}); authUser.Name != "Ada Admin" {
+		t.Errorf("auth name = %q, want the previous name restored", authUser.Name)
+	}
+}
@@ -3,13 +3,21 @@ package admin
 import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
+
+	"github.com/google/uuid"
 )
 
+// RegisterAdminSaga creates the admin in the auth provider, then its profile.
+const RegisterAdminSaga saga.Type = "admin.register"
+
 type RegisterAdminUseCase struct {
 	adminService admin.AdminService
 	auth         auth.AuthService
+	sagas        saga.SagaService
 	logger       logger.Logger
 }
 
@@ -18,15 +26,26 @@ type RegisterAdminInput struct {
 	admin.CreateAdminInput
 }
 
-func NewRegisterAdminUseCase(adminService admin.AdminService, auth auth.AuthService, logger logger.Logger) *RegisterAdminUseCase {
-	return &RegisterAdminUseCase{
+// registerAdminPayload is the stored state of an admin registration, the password is only passed as a secret.
+type registerAdminPayload struct {
+	AdminID  string `json:"adminId,omitempty"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+}
+
+func NewRegisterAdminUseCase(adminService admin.AdminService, auth auth.AuthService, sagas saga.SagaService, logger logger.Logger) *RegisterAdminUseCase {
+	uc := &RegisterAdminUseCase{
 		adminService: adminService,
 		auth:         auth,
+		sagas:        sagas,
 		logger:       logger,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
-func (uc *RegisterAdminUseCase) Execute(ctx context.Context, input RegisterAdminInput) (execErr error) {
+func (uc *RegisterAdminUseCase) Execute(ctx context.Context, input RegisterAdminInput) error {
 	if err := input.SignupAdmin.Validate(); err != nil {
 		return err
 	}
@@ -37,7 +56,7 @@ func (uc *RegisterAdminUseCase) Execute(ctx context.Context, input RegisterAdmin
 	if err := getByEmailInput.Validate(); err != nil {
 		return err
 	}
-	if exists, err := uc.adminService.GetByEmail(getByEmailInput); err != nil {
+	if exists, err := uc.adminService.GetByEmail(ctx, getByEmailInput); err != nil {
 		if err != admin.ErrAdminNotFound {
 			return err
 		}
@@ -45,40 +64,103 @@ func (uc *RegisterAdminUseCase) Execute(ctx context.Context, input RegisterAdmin
 		return admin.ErrAdminAlreadyExists
 	}
 
-	signUpOutput, err := uc.auth.CreateAdmin(ctx, input.SignupAdmin)
-	if err != nil {
+	// The ID is only known once the auth provider created the admin, a placeholder validates the rest up front.
+	createInput := input.CreateAdminInput
+	createInput.ID = admin.AdminID(uuid.New())
+	if err := createInput.Validate(); err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := signUpOutput.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back sign up: %s", err)
-			}
-		}
-	}()
 
-	adminId, err := admin.ParseAdminID(signUpOutput.Id)
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type: RegisterAdminSaga,
+		Payload: registerAdminPayload{
+			Username: input.SignupAdmin.Username,
+			Email:    createInput.Email,
+			Name:     createInput.Name,
+		},
+		Secrets: map[string]string{"password": input.SignupAdmin.Password},
+	})
+}
+
+func (uc *RegisterAdminUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: RegisterAdminSaga,
+		Steps: []saga.Step{
+			{Name: "create_admin", Action: uc.createAdmin, Compensate: uc.deleteAdmin, Inline: true},
+			{Name: "create_profile", Action: uc.createProfile, Compensate: uc.deleteProfile, Transactional: true},
+		},
+	}
+}
+
+func (uc *RegisterAdminUseCase) createAdmin(ctx context.Context, exec *saga.Execution) error {
+	var payload registerAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	password, _ := exec.Secret("password")
+
+	createOutput, err := uc.auth.CreateAdmin(ctx, auth.CreateAdminInput{
+		Username: payload.Username,
+		Password: password,
+		Name:     payload.Name,
+	})
 	if err != nil {
 		return err
 	}
 
-	input.CreateAdminInput.ID = adminId
+	payload.AdminID = createOutput.Id
+	return exec.Encode(payload)
+}
 
-	if err := input.CreateAdminInput.Validate(); err != nil {
+// deleteAdmin deletes the auth provider user created by the saga.
+func (uc *RegisterAdminUseCase) deleteAdmin(ctx context.Context, exec *saga.Execution) error {
+	var payload registerAdminPayload
+	if err := exec.Decode(&payload); err != nil {
 		return err
 	}
+	return auth_usecases.DeleteSignedUpUser(ctx, uc.auth, payload.Username, payload.AdminID)
+}
 
-	createOut, err := uc.adminService.Create(&input.CreateAdminInput)
+func (uc *RegisterAdminUseCase) createProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload registerAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if _, err := uc.adminService.GetByID(ctx, &admin.GetAdminInput{ID: payload.AdminID}); err == nil {
+		return nil
+	} else if err != admin.ErrAdminNotFound {
+		return err
+	}
+
+	adminId, err := admin.ParseAdminID(payload.AdminID)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := createOut.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back create admin: %s", err)
-			}
-		}
-	}()
+	createInput := &admin.CreateAdminInput{
+		ID:    adminId,
+		Name:  payload.Name,
+		Email: payload.Email,
+	}
+	if err := createInput.Validate(); err != nil {
+		return err
+	}
+	_, err = uc.adminService.Create(ctx, createInput)
+	return err
+}
 
+func (uc *RegisterAdminUseCase) deleteProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload registerAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	adminId, err := admin.ParseAdminID(payload.AdminID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.adminService.Delete(ctx, &admin.DeleteAdminInput{ID: adminId}); err != nil && err != admin.ErrAdminNotFound {
+		return err
+	}
 	return nil
 }
@@ -2,12 +2,19 @@ package admin
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
 )
 
+// UpdateAdminSaga renames the admin in the auth provider, then updates its profile.
+const UpdateAdminSaga saga.Type = "admin.update"
+
 type UpdateAdminUseCase struct {
 	adminService admin.AdminService
+	auth         auth.AuthService
+	sagas        saga.SagaService
 	logger       logger.Logger
 }
 
@@ -15,29 +22,95 @@ type UpdateAdminInput struct {
 	admin.UpdateAdminInput
 }
 
-func NewUpdateAdminUseCase(adminService admin.AdminService, logger logger.Logger) *UpdateAdminUseCase {
-	return &UpdateAdminUseCase{
+// updateAdminPayload is the stored state of an admin update, PreviousName restores the auth provider name.
+type updateAdminPayload struct {
+	AdminID      string  `json:"adminId"`
+	Username     string  `json:"username"`
+	Name         *string `json:"name,omitempty"`
+	Email        *string `json:"email,omitempty"`
+	PreviousName string  `json:"previousName"`
+}
+
+func NewUpdateAdminUseCase(adminService admin.AdminService, auth auth.AuthService, sagas saga.SagaService, logger logger.Logger) *UpdateAdminUseCase {
+	uc := &UpdateAdminUseCase{
 		adminService: adminService,
+		auth:         auth,
+		sagas:        sagas,
 		logger:       logger,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
-func (uc *UpdateAdminUseCase) Execute(ctx context.Context, input UpdateAdminInput) (execErr error) {
+func (uc *UpdateAdminUseCase) Execute(ctx context.Context, input UpdateAdminInput) error {
 	if err := input.UpdateAdminInput.Validate(); err != nil {
 		return err
 	}
 
-	updateOut, err := uc.adminService.Update(&input.UpdateAdminInput)
+	current, err := uc.adminService.GetByID(ctx, &admin.GetAdminInput{ID: input.ID.String()})
+	if err != nil {
+		return err
+	}
+
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type: UpdateAdminSaga,
+		Payload: updateAdminPayload{
+			AdminID:      input.ID.String(),
+			Username:     current.Email,
+			Name:         input.Name,
+			Email:        input.Email,
+			PreviousName: current.Name,
+		},
+	})
+}
+
+func (uc *UpdateAdminUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: UpdateAdminSaga,
+		Steps: []saga.Step{
+			{Name: "update_auth_name", Action: uc.updateAuthName, Compensate: uc.restoreAuthName},
+			// The profile is updated last, in the transaction recording the step, so it needs no compensation.
+			{Name: "update_profile", Action: uc.updateProfile, Transactional: true},
+		},
+	}
+}
+
+func (uc *UpdateAdminUseCase) updateAuthName(ctx context.Context, exec *saga.Execution) error {
+	var payload updateAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Name == nil || *payload.Name == payload.PreviousName {
+		return nil
+	}
+	return uc.auth.UpdateName(ctx, auth.UpdateNameInput{Username: payload.Username, Name: *payload.Name})
+}
+
+func (uc *UpdateAdminUseCase) restoreAuthName(ctx context.Context, exec *saga.Execution) error {
+	var payload updateAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Name == nil || *payload.Name == payload.PreviousName {
+		return nil
+	}
+	return uc.auth.UpdateName(ctx, auth.UpdateNameInput{Username: payload.Username, Name: payload.PreviousName})
+}
+
+func (uc *UpdateAdminUseCase) updateProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload updateAdminPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	adminId, err := admin.ParseAdminID(payload.AdminID)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := updateOut.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back update admin: %s", err)
-			}
-		}
-	}()
 
-	return nil
+	_, err = uc.adminService.Update(ctx, &admin.UpdateAdminInput{
+		ID:    adminId,
+		Name:  payload.Name,
+		Email: payload.Email,
+	})
+	return err
 }
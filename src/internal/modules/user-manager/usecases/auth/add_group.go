@@ -4,14 +4,21 @@ import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
 	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
 )
 
+// AddGroupSaga creates the missing admin or user profile, adds the group in the auth provider and signs the user out.
+const AddGroupSaga saga.Type = "auth.add_group"
+
 type AddGroupUseCase struct {
 	adminService admin.AdminService
 	userService  user.UserService
 	auth         auth.AuthService
+	denylist     denylist.DenylistService
+	sagas        saga.SagaService
 	logger       logger.Logger
 }
 
@@ -21,16 +28,33 @@ type AddGroupInput struct {
 	CreateUserInput  *user.CreateUserInput
 }
 
-func NewAddGroupUseCase(adminService admin.AdminService, userService user.UserService, auth auth.AuthService, logger logger.Logger) *AddGroupUseCase {
-	return &AddGroupUseCase{
+// addGroupPayload is the stored state of an add group saga, ProfileCreated and HadGroup tell the compensations
+// whether the profile and the group belong to the saga.
+type addGroupPayload struct {
+	Username       string         `json:"username"`
+	Group          auth.UserGroup `json:"group"`
+	UserID         string         `json:"userId"`
+	Name           string         `json:"name"`
+	Email          string         `json:"email"`
+	Phone          *string        `json:"phone,omitempty"`
+	ProfileCreated bool           `json:"profileCreated"`
+	HadGroup       bool           `json:"hadGroup"`
+}
+
+func NewAddGroupUseCase(adminService admin.AdminService, userService user.UserService, auth auth.AuthService, denylist denylist.DenylistService, sagas saga.SagaService, logger logger.Logger) *AddGroupUseCase {
+	uc := &AddGroupUseCase{
 		adminService: adminService,
 		auth:         auth,
+		denylist:     denylist,
+		sagas:        sagas,
 		logger:       logger,
 		userService:  userService,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
-func (uc *AddGroupUseCase) Execute(ctx context.Context, input AddGroupInput) (execErr error) {
+func (uc *AddGroupUseCase) Execute(ctx context.Context, input AddGroupInput) error {
 	if err := input.AddGroupInput.Validate(); err != nil {
 		return err
 	}
@@ -50,98 +74,199 @@ func (uc *AddGroupUseCase) Execute(ctx context.Context, input AddGroupInput) (ex
 		return auth.ErrUserNotFound
 	}
 
+	payload := addGroupPayload{
+		Username: input.AddGroupInput.Username,
+		Group:    input.AddGroupInput.GroupName,
+		UserID:   getUserOutput.Id,
+	}
 	switch input.AddGroupInput.GroupName {
+	case auth.GroupAdmin:
+		payload.Name = input.CreateAdminInput.Name
+		payload.Email = input.CreateAdminInput.Email
+	case auth.GroupUser:
+		payload.Name = input.CreateUserInput.Name
+		payload.Email = input.CreateUserInput.Email
+		payload.Phone = input.CreateUserInput.Phone
+	default:
+		return auth.ErrInvalidGroup
+	}
+
+	groups, err := uc.auth.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: payload.Username})
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		payload.HadGroup = payload.HadGroup || auth.UserGroup(group) == payload.Group
+	}
+
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type:    AddGroupSaga,
+		Payload: payload,
+	})
+}
+
+func (uc *AddGroupUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: AddGroupSaga,
+		Steps: []saga.Step{
+			{Name: "create_profile", Action: uc.createProfile, Compensate: uc.deleteProfile, Transactional: true},
+			{Name: "add_group", Action: uc.addGroup, Compensate: uc.removeGroup},
+			{Name: "logout", Action: uc.logout},
+		},
+	}
+}
+
+func (uc *AddGroupUseCase) createProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload addGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	switch payload.Group {
 	case auth.GroupAdmin:
 		getByEmailInput := &admin.GetAdminByEmailInput{
-			Email: input.CreateAdminInput.Email,
+			Email: payload.Email,
 		}
 		if err := getByEmailInput.Validate(); err != nil {
 			return err
 		}
 
-		exists, err := uc.adminService.GetByEmail(getByEmailInput)
+		exists, err := uc.adminService.GetByEmail(ctx, getByEmailInput)
 		if err != nil && err != admin.ErrAdminNotFound {
 			return err
 		}
+		if exists != nil {
+			return nil
+		}
 
-		if exists == nil {
-			adminId, err := admin.ParseAdminID(getUserOutput.Id)
-			if err != nil {
-				return err
-			}
-
-			input.CreateAdminInput.ID = adminId
-
-			if err := input.CreateAdminInput.Validate(); err != nil {
-				return err
-			}
-
-			createOut, err := uc.adminService.Create(input.CreateAdminInput)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				if execErr != nil {
-					if err := createOut.Rollback(ctx); err != nil {
-						uc.logger.Error("Error rolling back create admin: %s", err)
-					}
-				}
-			}()
+		adminId, err := admin.ParseAdminID(payload.UserID)
+		if err != nil {
+			return err
+		}
+		createInput := &admin.CreateAdminInput{
+			ID:    adminId,
+			Name:  payload.Name,
+			Email: payload.Email,
+		}
+		if err := createInput.Validate(); err != nil {
+			return err
+		}
+		if _, err := uc.adminService.Create(ctx, createInput); err != nil {
+			return err
 		}
 
 	case auth.GroupUser:
 		getByEmailInput := &user.GetUserByEmailInput{
-			Email: input.CreateUserInput.Email,
+			Email: payload.Email,
 		}
-
 		if err := getByEmailInput.Validate(); err != nil {
 			return err
 		}
 
-		exists, err := uc.userService.GetByEmail(getByEmailInput)
+		exists, err := uc.userService.GetByEmail(ctx, getByEmailInput)
 		if err != nil && err != user.ErrUserNotFound {
 			return err
 		}
+		if exists != nil {
+			return nil
+		}
 
-		if exists == nil {
-			userId, err := user.ParseUserID(getUserOutput.Id)
-			if err != nil {
-				return err
-			}
+		userId, err := user.ParseUserID(payload.UserID)
+		if err != nil {
+			return err
+		}
+		createInput := &user.CreateUserInput{
+			ID:    userId,
+			Name:  payload.Name,
+			Email: payload.Email,
+			Phone: payload.Phone,
+		}
+		if err := createInput.Validate(); err != nil {
+			return err
+		}
+		if _, err := uc.userService.Create(ctx, createInput); err != nil {
+			return err
+		}
+	default:
+		return auth.ErrInvalidGroup
+	}
 
-			input.CreateUserInput.ID = userId
+	payload.ProfileCreated = true
+	return exec.Encode(payload)
+}
 
-			if err := input.CreateUserInput.Validate(); err != nil {
-				return err
-			}
+// deleteProfile only deletes a profile created by this saga, an existing one is left alone.
+func (uc *AddGroupUseCase) deleteProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload addGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if !payload.ProfileCreated {
+		return nil
+	}
 
-			createOut, err := uc.userService.Create(input.CreateUserInput)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				if execErr != nil {
-					if err := createOut.Rollback(ctx); err != nil {
-						uc.logger.Error("Error rolling back create user: %s", err)
-					}
-				}
-			}()
+	switch payload.Group {
+	case auth.GroupAdmin:
+		adminId, err := admin.ParseAdminID(payload.UserID)
+		if err != nil {
+			return err
+		}
+		if _, err := uc.adminService.Delete(ctx, &admin.DeleteAdminInput{ID: adminId}); err != nil && err != admin.ErrAdminNotFound {
+			return err
+		}
+	case auth.GroupUser:
+		userId, err := user.ParseUserID(payload.UserID)
+		if err != nil {
+			return err
+		}
+		if _, err := uc.userService.Delete(ctx, &user.DeleteUserInput{ID: userId}); err != nil && err != user.ErrUserNotFound {
+			return err
 		}
-	default:
-		return auth.ErrInvalidGroup
 	}
+	return nil
+}
 
-	err = uc.auth.AddGroup(ctx, input.AddGroupInput)
-	if err != nil {
+func (uc *AddGroupUseCase) addGroup(ctx context.Context, exec *saga.Execution) error {
+	var payload addGroupPayload
+	if err := exec.Decode(&payload); err != nil {
 		return err
 	}
 
-	err = uc.auth.AdminLogout(ctx, auth.AdminLogoutInput{ // Force logout user to update token and update token with new group
-		Username: input.AddGroupInput.Username,
+	return uc.auth.AddGroup(ctx, auth.AddGroupInput{
+		Username:  payload.Username,
+		GroupName: payload.Group,
 	})
-	if err != nil {
-		uc.logger.Error("Error admin logging out: %s", err) //TODO: check if need to return error
+}
+
+// removeGroup only removes a group the user did not have before the saga.
+func (uc *AddGroupUseCase) removeGroup(ctx context.Context, exec *saga.Execution) error {
+	var payload addGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.HadGroup {
+		return nil
 	}
 
+	err := uc.auth.RemoveGroup(ctx, auth.RemoveGroupInput{
+		Username:  payload.Username,
+		GroupName: payload.Group,
+	})
+	if err != nil && err != auth.ErrUserNotFound {
+		return err
+	}
+	return nil
+}
+
+// logout forces the user to sign in again so the new tokens carry the group, a failure is only logged.
+func (uc *AddGroupUseCase) logout(ctx context.Context, exec *saga.Execution) error {
+	var payload addGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if err := AdminLogout(ctx, uc.auth, uc.denylist, payload.Username); err != nil {
+		uc.logger.Error("Error admin logging out: %s", err)
+	}
 	return nil
 }
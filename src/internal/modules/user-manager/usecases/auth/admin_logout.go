@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"context"
+)
+
+// AdminLogout signs the user out everywhere and denylists the access tokens already issued,
+// so they stop working right away instead of when they expire.
+func AdminLogout(ctx context.Context, authService auth.AuthService, denylistService denylist.DenylistService, username string) error {
+	usr, err := authService.GetUser(ctx, auth.GetUserInput{
+		Username: username,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := authService.AdminLogout(ctx, auth.AdminLogoutInput{
+		Username: username,
+	}); err != nil {
+		return err
+	}
+
+	return denylistService.DenySubject(ctx, denylist.DenySubjectInput{
+		Subject: usr.Id,
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
 	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 )
 
@@ -27,20 +29,20 @@ type UseCases struct {
 	SendForgotPasswordCode *SendForgotPasswordCodeUseCase
 }
 
-func NewUseCases(authService auth.AuthService, adminService admin.AdminService, userService user.UserService, logger logger.Logger) *UseCases {
+func NewUseCases(authService auth.AuthService, adminService admin.AdminService, userService user.UserService, denylistService denylist.DenylistService, sagaService saga.SagaService, logger logger.Logger) *UseCases {
 	return &UseCases{
-		Login:                  NewLoginUseCase(authService),
-		AddGroup:               NewAddGroupUseCase(adminService, userService, authService, logger),
-		RemoveGroup:            NewRemoveGroupUseCase(authService, logger),
+		Login:                  NewLoginUseCase(authService, userService, logger),
+		AddGroup:               NewAddGroupUseCase(adminService, userService, authService, denylistService, sagaService, logger),
+		RemoveGroup:            NewRemoveGroupUseCase(adminService, authService, denylistService, sagaService, logger),
 		RefreshToken:           NewRefreshTokenUseCase(authService),
 		AddMFA:                 NewAddMFAUseCase(authService),
-		VerifyMFA:              NewVerifyMFAUseCase(authService),
+		VerifyMFA:              NewVerifyMFAUseCase(authService, userService, logger),
 		AdminRemoveMFA:         NewAdminRemoveMFAUseCase(authService),
 		RemoveMFA:              NewRemoveMFAUseCase(authService),
 		ConfirmSignUp:          NewConfirmSignUpUseCase(authService),
 		GetMe:                  NewGetMeUseCase(authService),
 		ActivateMFA:            NewActivateMFAUseCase(authService),
-		Logout:                 NewLogoutUseCase(authService),
+		Logout:                 NewLogoutUseCase(authService, denylistService),
 		SetPassword:            NewSetPasswordUseCase(authService),
 		SendConfirmationCode:   NewSendConfirmationCodeUseCase(logger, authService),
 		ChangePassword:         NewChangePasswordUseCase(authService),
@@ -0,0 +1,195 @@
+package auth_test
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/modules/user-manager/usecases/usecasetest"
+	"auth-api/src/internal/shared/saga/domain/saga"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/saga/sagatest"
+	"context"
+	"errors"
+	"testing"
+)
+
+type fixture struct {
+	auth     auth.AuthService
+	admins   *usecasetest.Admins
+	users    *usecasetest.Users
+	denylist *usecasetest.Denylist
+	sagas    *sagatest.Repository
+	useCases *auth_usecases.UseCases
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	log := usecasetest.NewLogger(t)
+	authService, _ := usecasetest.NewAuthService(t, log)
+	f := &fixture{
+		auth:     authService,
+		admins:   usecasetest.NewAdmins(),
+		users:    usecasetest.NewUsers(),
+		denylist: usecasetest.NewDenylist(),
+		sagas:    sagatest.NewRepository(),
+	}
+	sagaService := sagatest.NewService(f.sagas, saga_infra.Options{}, log)
+	f.useCases = auth_usecases.NewUseCases(authService, f.admins, f.users, f.denylist, sagaService, log)
+	return f
+}
+
+func (f *fixture) groups(t *testing.T, username string) map[auth.UserGroup]bool {
+	t.Helper()
+	groups, err := f.auth.ListUserGroups(context.Background(), auth.ListUserGroupsInput{Username: username})
+	if err != nil {
+		t.Fatalf("ListUserGroups: %v", err)
+	}
+	set := make(map[auth.UserGroup]bool, len(groups))
+	for _, group := range groups {
+		set[auth.UserGroup(group)] = true
+	}
+	return set
+}
+
+func (f *fixture) lastSaga(t *testing.T) *saga.Saga {
+	t.Helper()
+	sagas := f.sagas.Sagas()
+	if len(sagas) == 0 {
+		t.Fatal("no saga was started")
+	}
+	return sagas[len(sagas)-1]
+}
+
+func TestLoginReturnsTheTokensOfAConfirmedUser(t *testing.T) {
+	f := newFixture(t)
+	usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+
+	out, err := f.useCases.Login.Execute(context.Background(), auth_usecases.LoginInput{
+		LoginInput: auth.LoginInput{Username: "grace@example.com", Password: usecasetest.Password},
+	})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if out.AccessToken == nil || out.RefreshToken == nil {
+		t.Fatalf("Login = %+v, want the tokens", out)
+	}
+
+	claims, err := f.auth.ValidateToken(context.Background(), *out.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if len(claims.UserGroups) != 1 || claims.UserGroups[0] != string(auth.GroupUser) {
+		t.Errorf("groups = %v, want the User group", claims.UserGroups)
+	}
+}
+
+func TestLoginRefusesAWrongPassword(t *testing.T) {
+	f := newFixture(t)
+	usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+
+	_, err := f.useCases.Login.Execute(context.Background(), auth_usecases.LoginInput{
+		LoginInput: auth.LoginInput{Username: "grace@example.com", Password: "Wrong-password1"},
+	})
+	if err != auth.ErrInvalidUsernameOrPassword {
+		t.Fatalf("Login = %v, want ErrInvalidUsernameOrPassword", err)
+	}
+}
+
+func TestAddGroupCreatesTheProfileAndSignsTheUserOut(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	signUp := usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+
+	err := f.useCases.AddGroup.Execute(ctx, auth_usecases.AddGroupInput{
+		AddGroupInput:    auth.AddGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+		CreateAdminInput: &admin.CreateAdminInput{Name: "Grace Hopper", Email: "grace@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	if !f.groups(t, "grace@example.com")[auth.GroupAdmin] {
+		t.Error("the Admin group was not added")
+	}
+	if _, err := f.admins.GetByID(ctx, &admin.GetAdminInput{ID: signUp.Id}); err != nil {
+		t.Errorf("the admin profile was not created: %v", err)
+	}
+	if !f.denylist.SubjectDenied(signUp.Id) {
+		t.Error("the tokens of the user were not denied")
+	}
+	if sg := f.lastSaga(t); sg.Status != saga.StatusCompleted {
+		t.Errorf("saga is %s, want completed", sg.Status)
+	}
+}
+
+func TestRemoveGroupRevokesTheAdminProfile(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	signUp := usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+	if err := f.useCases.AddGroup.Execute(ctx, auth_usecases.AddGroupInput{
+		AddGroupInput:    auth.AddGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+		CreateAdminInput: &admin.CreateAdminInput{Name: "Grace Hopper", Email: "grace@example.com"},
+	}); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	err := f.useCases.RemoveGroup.Execute(ctx, auth_usecases.RemoveGroupInput{
+		RemoveGroupInput: auth.RemoveGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+	})
+	if err != nil {
+		t.Fatalf("RemoveGroup: %v", err)
+	}
+
+	groups := f.groups(t, "grace@example.com")
+	if groups[auth.GroupAdmin] || !groups[auth.GroupUser] {
+		t.Errorf("groups = %v, want only the User group left", groups)
+	}
+	if _, err := f.admins.GetByID(ctx, &admin.GetAdminInput{ID: signUp.Id}); err != admin.ErrAdminNotFound {
+		t.Errorf("GetByID = %v, want the admin profile deleted", err)
+	}
+}
+
+func TestRemoveGroupAddsTheGroupBackWhenTheRevocationFails(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+	if err := f.useCases.AddGroup.Execute(ctx, auth_usecases.AddGroupInput{
+		AddGroupInput:    auth.AddGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+		CreateAdminInput: &admin.CreateAdminInput{Name: "Grace Hopper", Email: "grace@example.com"},
+	}); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+	f.admins.DeleteErr = errors.New("database down")
+
+	err := f.useCases.RemoveGroup.Execute(ctx, auth_usecases.RemoveGroupInput{
+		RemoveGroupInput: auth.RemoveGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+	})
+	if err != f.admins.DeleteErr {
+		t.Fatalf("RemoveGroup = %v, want the revocation error", err)
+	}
+
+	if !f.groups(t, "grace@example.com")[auth.GroupAdmin] {
+		t.Error("the Admin group was not added back")
+	}
+	if sg := f.lastSaga(t); sg.Status != saga.StatusCompensated {
+		t.Errorf("saga is %s, want compensated", sg.Status)
+	}
+}
+
+func TestRemoveGroupDoesNotAddAGroupTheUserNeverHad(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	usecasetest.SignUp(t, f.auth, "grace@example.com", "Grace Hopper")
+	f.admins.DeleteErr = errors.New("database down")
+
+	err := f.useCases.RemoveGroup.Execute(ctx, auth_usecases.RemoveGroupInput{
+		RemoveGroupInput: auth.RemoveGroupInput{Username: "grace@example.com", GroupName: auth.GroupAdmin},
+	})
+	if err == nil {
+		t.Fatal("RemoveGroup succeeded, want the revocation error")
+	}
+
+	if f.groups(t, "grace@example.com")[auth.GroupAdmin] {
+		t.Error("the compensation added the Admin group the user never had")
+	}
+}
@@ -24,7 +24,7 @@ func (uc *ConfirmSignUpUseCase) Execute(ctx context.Context, input ConfirmSignUp
 	verifyCodeInput := auth.VerifyCodeInput{
 		Username:   input.Username,
 		Code:       input.Code,
-		Identifier: "CONFIRMATION_CODE",
+		Identifier: auth.ConfirmationCodeIdentifier,
 	}
 	if err := verifyCodeInput.Validate(); err != nil {
 		return nil, err
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"context"
+)
+
+// DeleteSignedUpUser deletes the auth provider user a saga signed up, identified by the ID its step recorded. A sign up
+// interrupted before recording the ID is left alone, the username alone does not prove the account belongs to the
+// saga, and the reconciliation reports it.
+func DeleteSignedUpUser(ctx context.Context, authService auth.AuthService, username, id string) error {
+	if id == "" {
+		return nil
+	}
+
+	authUser, err := authService.GetUser(ctx, auth.GetUserInput{Username: username})
+	if err == auth.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if authUser.Id != id {
+		return nil
+	}
+
+	if err := authService.DeleteUser(ctx, auth.DeleteUserInput{Username: username}); err != nil && err != auth.ErrUserNotFound {
+		return err
+	}
+	return nil
+}
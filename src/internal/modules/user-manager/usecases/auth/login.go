@@ -2,20 +2,26 @@ package auth
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
 	"context"
 )
 
 type LoginUseCase struct {
-	auth auth.AuthService
+	auth        auth.AuthService
+	userService user.UserService
+	logger      logger.Logger
 }
 
 type LoginInput struct {
 	auth.LoginInput
 }
 
-func NewLoginUseCase(auth auth.AuthService) *LoginUseCase {
+func NewLoginUseCase(auth auth.AuthService, userService user.UserService, logger logger.Logger) *LoginUseCase {
 	return &LoginUseCase{
-		auth: auth,
+		auth:        auth,
+		userService: userService,
+		logger:      logger,
 	}
 }
 
@@ -24,5 +30,19 @@ func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*auth.Lo
 		return nil, err
 	}
 
-	return uc.auth.Login(ctx, input.LoginInput)
+	output, err := uc.auth.Login(ctx, input.LoginInput)
+	if err != nil {
+		return nil, err
+	}
+	if output.AccessToken != nil {
+		recordLogin(ctx, uc.userService, input.Username, uc.logger)
+	}
+	return output, nil
+}
+
+// recordLogin stores the last login of a user, a failure does not fail the sign in.
+func recordLogin(ctx context.Context, userService user.UserService, username string, logger logger.Logger) {
+	if err := userService.RecordLogin(ctx, &user.RecordLoginInput{Email: username}); err != nil {
+		logger.Error("Error recording the login of %s: %v", username, err)
+	}
 }
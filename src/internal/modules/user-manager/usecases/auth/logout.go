@@ -2,27 +2,42 @@ package auth
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
 	"context"
 )
 
 type LogoutUseCase struct {
-	auth auth.AuthService
+	auth     auth.AuthService
+	denylist denylist.DenylistService
 }
 
 type LogoutInput struct {
 	auth.LogoutInput
 }
 
-func NewLogoutUseCase(auth auth.AuthService) *LogoutUseCase {
+func NewLogoutUseCase(auth auth.AuthService, denylist denylist.DenylistService) *LogoutUseCase {
 	return &LogoutUseCase{
-		auth: auth,
+		auth:     auth,
+		denylist: denylist,
 	}
 }
 
+// Execute signs the user out of every device, like the provider does, and denylists the access tokens already issued.
 func (uc *LogoutUseCase) Execute(ctx context.Context, input LogoutInput) error {
 	if err := input.LogoutInput.Validate(); err != nil {
 		return err
 	}
 
-	return uc.auth.Logout(ctx, input.LogoutInput)
+	claims, err := uc.auth.ValidateToken(ctx, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.auth.Logout(ctx, input.LogoutInput); err != nil {
+		return err
+	}
+
+	return uc.denylist.DenySubject(ctx, denylist.DenySubjectInput{
+		Subject: claims.Id,
+	})
 }
@@ -1,41 +1,152 @@
 package auth
 
 import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
 )
 
+// RemoveGroupSaga removes the group in the auth provider, revokes the Admin role along the Admin group and signs the user out.
+const RemoveGroupSaga saga.Type = "auth.remove_group"
+
 type RemoveGroupUseCase struct {
-	auth   auth.AuthService
-	logger logger.Logger
+	adminService admin.AdminService
+	auth         auth.AuthService
+	denylist     denylist.DenylistService
+	sagas        saga.SagaService
+	logger       logger.Logger
 }
 
 type RemoveGroupInput struct {
 	auth.RemoveGroupInput
 }
 
-func NewRemoveGroupUseCase(auth auth.AuthService, logger logger.Logger) *RemoveGroupUseCase {
-	return &RemoveGroupUseCase{
-		auth:   auth,
-		logger: logger,
+// removeGroupPayload is the stored state of a remove group saga, HadGroup tells the compensation whether the group
+// was removed by the saga.
+type removeGroupPayload struct {
+	Username string         `json:"username"`
+	Group    auth.UserGroup `json:"group"`
+	UserID   string         `json:"userId"`
+	HadGroup bool           `json:"hadGroup"`
+}
+
+func NewRemoveGroupUseCase(adminService admin.AdminService, auth auth.AuthService, denylist denylist.DenylistService, sagas saga.SagaService, logger logger.Logger) *RemoveGroupUseCase {
+	uc := &RemoveGroupUseCase{
+		adminService: adminService,
+		auth:         auth,
+		denylist:     denylist,
+		sagas:        sagas,
+		logger:       logger,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
 func (uc *RemoveGroupUseCase) Execute(ctx context.Context, input RemoveGroupInput) error {
 	if err := input.RemoveGroupInput.Validate(); err != nil {
 		return err
 	}
+	if !input.GroupName.IsBuiltin() {
+		return auth.ErrInvalidGroup
+	}
+
+	authUser, err := uc.auth.GetUser(ctx, auth.GetUserInput{Username: input.Username})
+	if err != nil {
+		return err
+	}
+	groups, err := uc.auth.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: input.Username})
+	if err != nil {
+		return err
+	}
+
+	payload := removeGroupPayload{
+		Username: input.Username,
+		Group:    input.GroupName,
+		UserID:   authUser.Id,
+	}
+	for _, group := range groups {
+		payload.HadGroup = payload.HadGroup || auth.UserGroup(group) == payload.Group
+	}
+
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type:    RemoveGroupSaga,
+		Payload: payload,
+	})
+}
+
+func (uc *RemoveGroupUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: RemoveGroupSaga,
+		Steps: []saga.Step{
+			{Name: "remove_group", Action: uc.removeGroup, Compensate: uc.addGroup},
+			// The role is revoked in the transaction recording the step and the logout never fails, so it needs no compensation.
+			{Name: "revoke_admin", Action: uc.revokeAdmin, Transactional: true},
+			{Name: "logout", Action: uc.logout},
+		},
+	}
+}
+
+func (uc *RemoveGroupUseCase) removeGroup(ctx context.Context, exec *saga.Execution) error {
+	var payload removeGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	return uc.auth.RemoveGroup(ctx, auth.RemoveGroupInput{
+		Username:  payload.Username,
+		GroupName: payload.Group,
+	})
+}
 
-	if err := uc.auth.RemoveGroup(ctx, input.RemoveGroupInput); err != nil {
+// addGroup only adds back a group the user had before the saga.
+func (uc *RemoveGroupUseCase) addGroup(ctx context.Context, exec *saga.Execution) error {
+	var payload removeGroupPayload
+	if err := exec.Decode(&payload); err != nil {
 		return err
 	}
+	if !payload.HadGroup {
+		return nil
+	}
+
+	return uc.auth.AddGroup(ctx, auth.AddGroupInput{
+		Username:  payload.Username,
+		GroupName: payload.Group,
+	})
+}
 
-	if err := uc.auth.AdminLogout(ctx, auth.AdminLogoutInput{
-		Username: input.RemoveGroupInput.Username,
-	}); err != nil {
-		uc.logger.Error("Failed to admin logout", err)
+// revokeAdmin takes the Admin role back from the principal, its profile is kept while it is still a user. The
+// User role is left alone, reconciliation adds the User group back to users with a profile.
+func (uc *RemoveGroupUseCase) revokeAdmin(ctx context.Context, exec *saga.Execution) error {
+	var payload removeGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Group != auth.GroupAdmin {
+		return nil
 	}
 
+	adminID, err := admin.ParseAdminID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.adminService.Delete(ctx, &admin.DeleteAdminInput{ID: adminID}); err != nil && err != admin.ErrAdminNotFound {
+		return err
+	}
+	return nil
+}
+
+// logout signs the user out so the new tokens lose the group, a failure is only logged.
+func (uc *RemoveGroupUseCase) logout(ctx context.Context, exec *saga.Execution) error {
+	var payload removeGroupPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if err := AdminLogout(ctx, uc.auth, uc.denylist, payload.Username); err != nil {
+		uc.logger.Error("Error admin logging out: %s", err)
+	}
 	return nil
 }
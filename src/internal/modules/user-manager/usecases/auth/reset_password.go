@@ -25,7 +25,7 @@ func (uc *ResetPasswordUseCase) Execute(ctx context.Context, input ResetPassword
 	verifyCodeInput := auth.VerifyCodeInput{
 		Username:   input.Username,
 		Code:       input.Code,
-		Identifier: "FORGOT_PASSWORD_CODE",
+		Identifier: auth.ForgotPasswordCodeIdentifier,
 	}
 	if err := verifyCodeInput.Validate(); err != nil {
 		return err
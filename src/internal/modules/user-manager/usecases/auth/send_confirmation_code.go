@@ -44,7 +44,7 @@ func (sc *SendConfirmationCodeUseCase) Execute(ctx context.Context, input SendCo
 
 	generateAndSaveInput := auth.GenerateAndSendCodeInput{
 		Username:   input.Username,
-		Identifier: "CONFIRMATION_CODE",
+		Identifier: auth.ConfirmationCodeIdentifier,
 		Subject:    "Please confirm your email",
 		Body:       "Your confirmation code is: %s",
 	}
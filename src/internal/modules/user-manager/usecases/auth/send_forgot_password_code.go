@@ -44,7 +44,7 @@ func (sc *SendForgotPasswordCodeUseCase) Execute(ctx context.Context, input Send
 
 	generateAndSaveInput := auth.GenerateAndSendCodeInput{
 		Username:   input.Username,
-		Identifier: "FORGOT_PASSWORD_CODE",
+		Identifier: auth.ForgotPasswordCodeIdentifier,
 		Subject:    "Reset your password",
 		Body:       "Your reset password code is: %s",
 	}
@@ -2,20 +2,26 @@ package auth
 
 import (
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
 	"context"
 )
 
 type VerifyMFAUseCase struct {
-	auth auth.AuthService
+	auth        auth.AuthService
+	userService user.UserService
+	logger      logger.Logger
 }
 
 type VerifyMFAInput struct {
 	auth.VerifyMFAInput
 }
 
-func NewVerifyMFAUseCase(auth auth.AuthService) *VerifyMFAUseCase {
+func NewVerifyMFAUseCase(auth auth.AuthService, userService user.UserService, logger logger.Logger) *VerifyMFAUseCase {
 	return &VerifyMFAUseCase{
-		auth: auth,
+		auth:        auth,
+		userService: userService,
+		logger:      logger,
 	}
 }
 
@@ -24,5 +30,12 @@ func (uc *VerifyMFAUseCase) Execute(ctx context.Context, input VerifyMFAInput) (
 		return nil, err
 	}
 
-	return uc.auth.VerifyMFA(ctx, input.VerifyMFAInput)
+	output, err := uc.auth.VerifyMFA(ctx, input.VerifyMFAInput)
+	if err != nil {
+		return nil, err
+	}
+	if output.AccessToken != nil {
+		recordLogin(ctx, uc.userService, input.Username, uc.logger)
+	}
+	return output, nil
 }
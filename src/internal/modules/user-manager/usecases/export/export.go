@@ -0,0 +1,36 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/pkg/logger"
+	"time"
+)
+
+type Options struct {
+	// TTL is how long a ready export can be downloaded.
+	TTL time.Duration
+}
+
+type UseCases struct {
+	RequestExport  *RequestExportUseCase
+	ListExports    *ListExportsUseCase
+	DownloadExport *DownloadExportUseCase
+	GenerateExport *GenerateExportUseCase
+	RetryStale     *RetryStaleExportsUseCase
+}
+
+func NewUseCases(exports export.ExportRepository, userService user.UserService, authService auth.AuthService, members organization.MemberRepository, emailService email.EmailService, dispatcher events.EventDispatcher, options Options, logger logger.Logger) *UseCases {
+	generateExport := NewGenerateExportUseCase(exports, userService, authService, members, emailService, logger)
+	return &UseCases{
+		RequestExport:  NewRequestExportUseCase(exports, userService, dispatcher, options.TTL),
+		ListExports:    NewListExportsUseCase(exports),
+		DownloadExport: NewDownloadExportUseCase(exports),
+		GenerateExport: generateExport,
+		RetryStale:     NewRetryStaleExportsUseCase(exports, generateExport, logger),
+	}
+}
@@ -0,0 +1,178 @@
+package export
+
+import (
+	"archive/zip"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/pkg/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PersonalData is everything held about a user, the JSON export is this document.
+type PersonalData struct {
+	ExportedAt    time.Time                 `json:"exportedAt"`
+	Profile       *user.User                `json:"profile"`
+	Account       *auth.User                `json:"account,omitempty"`
+	Groups        []string                  `json:"groups"`
+	MFAEnabled    bool                      `json:"mfaEnabled"`
+	Organizations []organization.Membership `json:"organizations"`
+}
+
+const (
+	// generateTimeout bounds a generation, the lease outlives it so a running export is never claimed twice.
+	generateTimeout = 5 * time.Minute
+	generateLease   = 2 * generateTimeout
+	// maxGenerateAttempts is how many times an export is claimed before it is marked as failed.
+	maxGenerateAttempts = 3
+)
+
+type GenerateExportUseCase struct {
+	exports      export.ExportRepository
+	userService  user.UserService
+	authService  auth.AuthService
+	members      organization.MemberRepository
+	emailService email.EmailService
+	logger       logger.Logger
+}
+
+type GenerateExportInput struct {
+	ExportID string
+	UserID   string
+}
+
+func NewGenerateExportUseCase(exports export.ExportRepository, userService user.UserService, authService auth.AuthService, members organization.MemberRepository, emailService email.EmailService, logger logger.Logger) *GenerateExportUseCase {
+	return &GenerateExportUseCase{
+		exports:      exports,
+		userService:  userService,
+		authService:  authService,
+		members:      members,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// Execute claims the export, builds and stores the archive, then emails the requester. A failed export is
+// marked as failed, an export claimed by someone else is left to them.
+func (uc *GenerateExportUseCase) Execute(ctx context.Context, input GenerateExportInput) error {
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+
+	exp, err := uc.exports.GetByID(ctx, &export.GetExportInput{ID: input.ExportID, UserID: input.UserID})
+	if err != nil {
+		return err
+	}
+	if exp.Status != export.StatusPending {
+		return nil
+	}
+
+	claimed, err := uc.exports.Claim(ctx, exp.ID, generateLease, maxGenerateAttempts)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	data, err := uc.build(ctx, exp)
+	if err != nil {
+		uc.logger.Error("Error generating data export %s: %v", exp.ID, err)
+		if err := uc.exports.Fail(ctx, exp.ID); err != nil {
+			uc.logger.Error("Error marking data export %s as failed: %v", exp.ID, err)
+		}
+		return err
+	}
+
+	if err := uc.exports.Complete(ctx, &export.CompleteExportInput{ID: exp.ID, Data: data}); err != nil {
+		return err
+	}
+
+	return uc.emailService.SendEmail(ctx, email.Email{
+		To:      exp.NotifyEmail,
+		Subject: "Your personal data export is ready",
+		Body:    fmt.Sprintf("The personal data export %s is ready and can be downloaded until %s.", exp.ID, exp.ExpiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+func (uc *GenerateExportUseCase) build(ctx context.Context, exp *export.DataExport) ([]byte, error) {
+	personalData, err := uc.collect(ctx, exp.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exp.Format == export.FormatJSON {
+		return json.MarshalIndent(personalData, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"profile.json", personalData.Profile},
+		{"account.json", personalData.Account},
+		{"groups.json", personalData.Groups},
+		{"organizations.json", personalData.Organizations},
+		{"personal-data.json", personalData},
+	}
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (uc *GenerateExportUseCase) collect(ctx context.Context, userID string) (*PersonalData, error) {
+	profile, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	personalData := &PersonalData{
+		ExportedAt: time.Now().UTC(),
+		Profile:    profile,
+		Groups:     []string{},
+	}
+
+	account, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: profile.Email})
+	if err != nil && err != auth.ErrUserNotFound {
+		return nil, err
+	}
+	if account != nil {
+		personalData.Account = account
+		personalData.MFAEnabled = account.MFAEnabled
+
+		groups, err := uc.authService.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: profile.Email})
+		if err != nil {
+			return nil, err
+		}
+		personalData.Groups = groups
+	}
+
+	memberships, err := uc.members.ListMemberships(ctx, &organization.ListMembershipsInput{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	personalData.Organizations = memberships
+
+	return personalData, nil
+}
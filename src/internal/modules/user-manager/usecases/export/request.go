@@ -0,0 +1,122 @@
+package export
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RequestExportUseCase struct {
+	exports     export.ExportRepository
+	userService user.UserService
+	events      events.EventDispatcher
+	ttl         time.Duration
+}
+
+type RequestExportInput struct {
+	UserID      string
+	NotifyEmail string
+	Format      export.Format
+}
+
+func NewRequestExportUseCase(exports export.ExportRepository, userService user.UserService, events events.EventDispatcher, ttl time.Duration) *RequestExportUseCase {
+	return &RequestExportUseCase{
+		exports:     exports,
+		userService: userService,
+		events:      events,
+		ttl:         ttl,
+	}
+}
+
+// Execute records the export and hands its generation to the DataExportRequested handlers.
+func (uc *RequestExportUseCase) Execute(ctx context.Context, input RequestExportInput) (*export.DataExport, error) {
+	getUserInput := &user.GetUserInput{ID: input.UserID}
+	if err := getUserInput.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := uc.userService.GetByID(ctx, getUserInput); err != nil {
+		return nil, err
+	}
+
+	createInput := &export.CreateExportInput{
+		ID:          uuid.NewString(),
+		UserID:      input.UserID,
+		NotifyEmail: input.NotifyEmail,
+		Format:      input.Format,
+		ExpiresAt:   time.Now().Add(uc.ttl),
+	}
+	if err := createInput.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.exports.Create(ctx, createInput); err != nil {
+		return nil, err
+	}
+
+	exp, err := uc.exports.GetByID(ctx, &export.GetExportInput{ID: createInput.ID, UserID: input.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.events.Dispatch(&export.DataExportRequestedEvent{
+		ExportID: exp.ID,
+		UserID:   exp.UserID,
+	}); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+type ListExportsUseCase struct {
+	exports export.ExportRepository
+}
+
+func NewListExportsUseCase(exports export.ExportRepository) *ListExportsUseCase {
+	return &ListExportsUseCase{
+		exports: exports,
+	}
+}
+
+func (uc *ListExportsUseCase) Execute(ctx context.Context, input export.ListExportsInput) ([]export.DataExport, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.exports.List(ctx, &input)
+}
+
+type DownloadExportUseCase struct {
+	exports export.ExportRepository
+}
+
+func NewDownloadExportUseCase(exports export.ExportRepository) *DownloadExportUseCase {
+	return &DownloadExportUseCase{
+		exports: exports,
+	}
+}
+
+func (uc *DownloadExportUseCase) Execute(ctx context.Context, input export.GetExportInput) (*export.Archive, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	exp, err := uc.exports.GetByID(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	if exp.IsExpired() {
+		return nil, export.ErrExportExpired
+	}
+	if exp.Status != export.StatusReady {
+		return nil, export.ErrExportNotReady
+	}
+
+	data, err := uc.exports.GetData(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+	return export.NewArchive(exp, data), nil
+}
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/export"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+// staleExportsBatch is the number of stale exports retried by each RetryStaleExports call.
+const staleExportsBatch = 20
+
+// RetryStaleExportsUseCase generates again the exports whose generation stopped, e.g. with the instance
+// running it, so that a pending export never blocks the user's next request.
+type RetryStaleExportsUseCase struct {
+	exports  export.ExportRepository
+	generate *GenerateExportUseCase
+	logger   logger.Logger
+}
+
+func NewRetryStaleExportsUseCase(exports export.ExportRepository, generate *GenerateExportUseCase, logger logger.Logger) *RetryStaleExportsUseCase {
+	return &RetryStaleExportsUseCase{
+		exports:  exports,
+		generate: generate,
+		logger:   logger,
+	}
+}
+
+// Execute fails the exports out of attempts, then claims and generates the stale ones.
+func (uc *RetryStaleExportsUseCase) Execute(ctx context.Context) error {
+	if err := uc.exports.FailAbandoned(ctx, maxGenerateAttempts); err != nil {
+		return err
+	}
+
+	stale, err := uc.exports.ListStale(ctx, generateLease, maxGenerateAttempts, staleExportsBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, exp := range stale {
+		if err := uc.generate.Execute(ctx, GenerateExportInput{ExportID: exp.ID, UserID: exp.UserID}); err != nil {
+			uc.logger.Error("Error retrying data export %s: %v", exp.ID, err)
+		}
+	}
+	return nil
+}
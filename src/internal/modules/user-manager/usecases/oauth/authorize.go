@@ -0,0 +1,182 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/logger"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type AuthorizeUseCase struct {
+	clients oauth.ClientRepository
+	options Options
+}
+
+type AuthorizeInput struct {
+	oauth.AuthorizeInput
+}
+
+func NewAuthorizeUseCase(clients oauth.ClientRepository, options Options) *AuthorizeUseCase {
+	return &AuthorizeUseCase{
+		clients: clients,
+		options: options,
+	}
+}
+
+// Execute validates the authorization request and returns where the user agent must go next:
+// the hosted login page, or back to the client with an error once the redirect URI is trusted.
+func (uc *AuthorizeUseCase) Execute(ctx context.Context, input AuthorizeInput) (*oauth.AuthorizeOutput, error) {
+	if err := verifyClient(ctx, uc.clients, &input.AuthorizeInput); err != nil {
+		return nil, err
+	}
+	if err := input.AuthorizeInput.ValidateRequest(); err != nil {
+		return &oauth.AuthorizeOutput{RedirectTo: errorRedirect(input.AuthorizeInput, err)}, nil
+	}
+
+	query := url.Values{}
+	query.Set("response_type", input.ResponseType)
+	query.Set("client_id", input.ClientID)
+	query.Set("redirect_uri", input.RedirectURI)
+	query.Set("code_challenge", input.CodeChallenge)
+	query.Set("code_challenge_method", input.CodeChallengeMethod)
+	setIfNotEmpty(query, "scope", input.Scope)
+	setIfNotEmpty(query, "state", input.State)
+	setIfNotEmpty(query, "nonce", input.Nonce)
+
+	return &oauth.AuthorizeOutput{RedirectTo: uc.options.LoginPageURL + "?" + query.Encode()}, nil
+}
+
+// verifyClient makes sure the client exists and the redirect URI is registered for it.
+func verifyClient(ctx context.Context, clients oauth.ClientRepository, input *oauth.AuthorizeInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	client, err := getClient(ctx, clients, input.ClientID)
+	if err != nil {
+		return err
+	}
+	if !client.HasRedirectURI(input.RedirectURI) {
+		return oauth.ErrInvalidRedirectURI
+	}
+	return nil
+}
+
+// getClient loads a client for the OAuth endpoints, where an unknown client is an invalid_client error.
+func getClient(ctx context.Context, clients oauth.ClientRepository, clientID string) (*oauth.Client, error) {
+	client, err := clients.GetByID(ctx, &oauth.GetClientInput{ClientID: clientID})
+	if err != nil {
+		if errors.Is(err, oauth.ErrClientNotFound) {
+			return nil, oauth.ErrInvalidClient
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+func errorRedirect(input oauth.AuthorizeInput, err error) string {
+	query := url.Values{}
+	var oauthErr *app_error.OAuthError
+	if errors.As(err, &oauthErr) {
+		query.Set("error", oauthErr.Code)
+		setIfNotEmpty(query, "error_description", oauthErr.Description)
+	} else {
+		query.Set("error", "server_error")
+	}
+	setIfNotEmpty(query, "state", input.State)
+	return appendQuery(input.RedirectURI, query)
+}
+
+func appendQuery(uri string, query url.Values) string {
+	if strings.Contains(uri, "?") {
+		return uri + "&" + query.Encode()
+	}
+	return uri + "?" + query.Encode()
+}
+
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// codeIssuer turns a successful login into an authorization code for the requesting client.
+type codeIssuer struct {
+	auth    auth.AuthService
+	getMe   *auth_usecases.GetMeUseCase
+	codes   oauth.AuthorizationCodeRepository
+	codeTTL time.Duration
+	logger  logger.Logger
+}
+
+func newCodeIssuer(auth auth.AuthService, getMe *auth_usecases.GetMeUseCase, codes oauth.AuthorizationCodeRepository, codeTTL time.Duration, logger logger.Logger) *codeIssuer {
+	return &codeIssuer{
+		auth:    auth,
+		getMe:   getMe,
+		codes:   codes,
+		codeTTL: codeTTL,
+		logger:  logger,
+	}
+}
+
+func (i *codeIssuer) Issue(ctx context.Context, input oauth.AuthorizeInput, login *auth.LoginOutput) (*oauth.AuthorizeOutput, error) {
+	if login.NextStep != nil {
+		return &oauth.AuthorizeOutput{
+			NextStep: login.NextStep,
+			Session:  login.Session,
+		}, nil
+	}
+	if login.AccessToken == nil {
+		return nil, auth.ErrInvalidAccessCode
+	}
+
+	claims, err := i.auth.ValidateToken(ctx, *login.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := i.getMe.Execute(ctx, auth_usecases.GetMeInput{
+		GetMeInput: auth.GetMeInput{
+			AccessToken: *login.AccessToken,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The tokens are issued again from the refresh token when the code is exchanged.
+	if login.RefreshToken == nil {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	code, err := i.codes.Create(ctx, &oauth.CreateAuthorizationCodeInput{
+		ClientID:            input.ClientID,
+		RedirectURI:         input.RedirectURI,
+		Scope:               input.Scope,
+		Nonce:               input.Nonce,
+		CodeChallenge:       input.CodeChallenge,
+		CodeChallengeMethod: input.CodeChallengeMethod,
+		Subject:             claims.Id,
+		Email:               claims.Email,
+		Name:                me.Name,
+		RefreshToken:        *login.RefreshToken,
+		AuthTime:            now,
+		ExpiresAt:           now.Add(i.codeTTL),
+	})
+	if err != nil {
+		i.logger.Error("Error issuing authorization code: %v", err)
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("code", code)
+	setIfNotEmpty(query, "state", input.State)
+	return &oauth.AuthorizeOutput{RedirectTo: appendQuery(input.RedirectURI, query)}, nil
+}
@@ -0,0 +1,47 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"context"
+)
+
+type AuthorizeLoginUseCase struct {
+	clients oauth.ClientRepository
+	login   *auth_usecases.LoginUseCase
+	issuer  *codeIssuer
+}
+
+type AuthorizeLoginInput struct {
+	oauth.AuthorizeInput
+	auth.LoginInput
+}
+
+func NewAuthorizeLoginUseCase(clients oauth.ClientRepository, login *auth_usecases.LoginUseCase, issuer *codeIssuer) *AuthorizeLoginUseCase {
+	return &AuthorizeLoginUseCase{
+		clients: clients,
+		login:   login,
+		issuer:  issuer,
+	}
+}
+
+// Execute logs the user in from the hosted UI. It either returns the next challenge to complete
+// or the client redirect carrying the authorization code.
+func (uc *AuthorizeLoginUseCase) Execute(ctx context.Context, input AuthorizeLoginInput) (*oauth.AuthorizeOutput, error) {
+	if err := verifyClient(ctx, uc.clients, &input.AuthorizeInput); err != nil {
+		return nil, err
+	}
+	if err := input.AuthorizeInput.ValidateRequest(); err != nil {
+		return &oauth.AuthorizeOutput{RedirectTo: errorRedirect(input.AuthorizeInput, err)}, nil
+	}
+
+	out, err := uc.login.Execute(ctx, auth_usecases.LoginInput{
+		LoginInput: input.LoginInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.issuer.Issue(ctx, input.AuthorizeInput, out)
+}
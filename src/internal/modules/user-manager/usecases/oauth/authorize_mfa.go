@@ -0,0 +1,45 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"context"
+)
+
+type AuthorizeMFAUseCase struct {
+	clients   oauth.ClientRepository
+	verifyMFA *auth_usecases.VerifyMFAUseCase
+	issuer    *codeIssuer
+}
+
+type AuthorizeMFAInput struct {
+	oauth.AuthorizeInput
+	auth.VerifyMFAInput
+}
+
+func NewAuthorizeMFAUseCase(clients oauth.ClientRepository, verifyMFA *auth_usecases.VerifyMFAUseCase, issuer *codeIssuer) *AuthorizeMFAUseCase {
+	return &AuthorizeMFAUseCase{
+		clients:   clients,
+		verifyMFA: verifyMFA,
+		issuer:    issuer,
+	}
+}
+
+func (uc *AuthorizeMFAUseCase) Execute(ctx context.Context, input AuthorizeMFAInput) (*oauth.AuthorizeOutput, error) {
+	if err := verifyClient(ctx, uc.clients, &input.AuthorizeInput); err != nil {
+		return nil, err
+	}
+	if err := input.AuthorizeInput.ValidateRequest(); err != nil {
+		return &oauth.AuthorizeOutput{RedirectTo: errorRedirect(input.AuthorizeInput, err)}, nil
+	}
+
+	out, err := uc.verifyMFA.Execute(ctx, auth_usecases.VerifyMFAInput{
+		VerifyMFAInput: input.VerifyMFAInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.issuer.Issue(ctx, input.AuthorizeInput, out)
+}
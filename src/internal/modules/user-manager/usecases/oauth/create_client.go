@@ -0,0 +1,78 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/password"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/google/uuid"
+)
+
+type CreateClientUseCase struct {
+	clients oauth.ClientRepository
+	logger  logger.Logger
+}
+
+type CreateClientInput struct {
+	oauth.CreateClientInput
+	// Confidential clients get a secret and can use the client_credentials grant.
+	Confidential bool
+}
+
+func NewCreateClientUseCase(clients oauth.ClientRepository, logger logger.Logger) *CreateClientUseCase {
+	return &CreateClientUseCase{
+		clients: clients,
+		logger:  logger,
+	}
+}
+
+func (uc *CreateClientUseCase) Execute(ctx context.Context, input CreateClientInput) (*oauth.CreateClientOutput, error) {
+	input.ClientID = uuid.NewString()
+	if err := input.CreateClientInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	var secret string
+	if input.Confidential {
+		var secretHash string
+		var err error
+		secret, secretHash, err = newClientSecret()
+		if err != nil {
+			uc.logger.Error("Error generating client secret: %v", err)
+			return nil, err
+		}
+		input.SecretHash = secretHash
+	}
+
+	if err := uc.clients.Create(ctx, &input.CreateClientInput); err != nil {
+		return nil, err
+	}
+
+	client, err := uc.clients.GetByID(ctx, &oauth.GetClientInput{ClientID: input.ClientID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth.CreateClientOutput{
+		Client:       *client,
+		ClientSecret: secret,
+	}, nil
+}
+
+// newClientSecret returns a random secret and the hash stored for it.
+func newClientSecret() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	hash, err := password.Hash(secret)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, hash, nil
+}
@@ -0,0 +1,28 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"context"
+)
+
+type DeleteClientUseCase struct {
+	clients oauth.ClientRepository
+}
+
+type DeleteClientInput struct {
+	oauth.DeleteClientInput
+}
+
+func NewDeleteClientUseCase(clients oauth.ClientRepository) *DeleteClientUseCase {
+	return &DeleteClientUseCase{
+		clients: clients,
+	}
+}
+
+func (uc *DeleteClientUseCase) Execute(ctx context.Context, input DeleteClientInput) error {
+	if err := input.DeleteClientInput.Validate(); err != nil {
+		return err
+	}
+
+	return uc.clients.Delete(ctx, &input.DeleteClientInput)
+}
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/jwt_verify"
+)
+
+type DiscoveryUseCase struct {
+	oauth oauth.OAuthService
+}
+
+func NewDiscoveryUseCase(oauthService oauth.OAuthService) *DiscoveryUseCase {
+	return &DiscoveryUseCase{
+		oauth: oauthService,
+	}
+}
+
+func (uc *DiscoveryUseCase) Configuration() *oauth.DiscoveryOutput {
+	issuer := uc.oauth.Issuer()
+	return &oauth.DiscoveryOutput{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth2/authorize",
+		TokenEndpoint:                     issuer + "/oauth2/token",
+		UserInfoEndpoint:                  issuer + "/oauth2/userinfo",
+		IntrospectionEndpoint:             issuer + "/oauth2/introspect",
+		RevocationEndpoint:                issuer + "/oauth2/revoke",
+		JwksURI:                           issuer + "/oauth2/jwks",
+		ScopesSupported:                   oauth.SupportedScopes,
+		ResponseTypesSupported:            []string{oauth.ResponseTypeCode},
+		GrantTypesSupported:               []string{oauth.GrantTypeAuthorizationCode, oauth.GrantTypeRefreshToken, oauth.GrantTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{oauth.CodeChallengeMethodS256, oauth.CodeChallengeMethodPlain},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "email", "name"},
+	}
+}
+
+func (uc *DiscoveryUseCase) JWKS() *jwt_verify.JWK {
+	return uc.oauth.JWK()
+}
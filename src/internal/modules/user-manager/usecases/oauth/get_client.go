@@ -0,0 +1,28 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"context"
+)
+
+type GetClientUseCase struct {
+	clients oauth.ClientRepository
+}
+
+type GetClientInput struct {
+	oauth.GetClientInput
+}
+
+func NewGetClientUseCase(clients oauth.ClientRepository) *GetClientUseCase {
+	return &GetClientUseCase{
+		clients: clients,
+	}
+}
+
+func (uc *GetClientUseCase) Execute(ctx context.Context, input GetClientInput) (*oauth.Client, error) {
+	if err := input.GetClientInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.clients.GetByID(ctx, &input.GetClientInput)
+}
@@ -0,0 +1,179 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"strings"
+	"time"
+)
+
+type IntrospectUseCase struct {
+	clients       oauth.ClientRepository
+	refreshTokens oauth.RefreshTokenRepository
+	oauth         oauth.OAuthService
+	auth          auth.AuthService
+	denylist      denylist.DenylistService
+	logger        logger.Logger
+}
+
+type IntrospectInput struct {
+	oauth.IntrospectInput
+}
+
+func NewIntrospectUseCase(clients oauth.ClientRepository, refreshTokens oauth.RefreshTokenRepository, oauthService oauth.OAuthService, authService auth.AuthService, denylist denylist.DenylistService, logger logger.Logger) *IntrospectUseCase {
+	return &IntrospectUseCase{
+		clients:       clients,
+		refreshTokens: refreshTokens,
+		oauth:         oauthService,
+		auth:          authService,
+		denylist:      denylist,
+		logger:        logger,
+	}
+}
+
+// Execute only answers confidential clients. Tokens that are unknown, expired, revoked or malformed
+// are reported as inactive instead of failing the request (RFC 7662 section 2.2).
+func (uc *IntrospectUseCase) Execute(ctx context.Context, input IntrospectInput) (*oauth.IntrospectOutput, error) {
+	if err := input.IntrospectInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := authenticateClient(ctx, uc.clients, input.ClientID, input.ClientSecret, uc.logger)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsConfidential() {
+		return nil, oauth.ErrUnauthorizedClient
+	}
+
+	if !isJWT(input.Token) {
+		return uc.introspectRefreshToken(ctx, input.Token)
+	}
+	if uc.oauth.IsClientToken(input.Token) {
+		return uc.introspectClientToken(ctx, input.Token)
+	}
+	return uc.introspectAccessToken(ctx, input.Token)
+}
+
+func (uc *IntrospectUseCase) introspectClientToken(ctx context.Context, token string) (*oauth.IntrospectOutput, error) {
+	claims, err := uc.oauth.ValidateClientToken(ctx, token)
+	if err != nil {
+		return &oauth.IntrospectOutput{}, nil
+	}
+
+	denied, err := uc.denylist.IsDenied(ctx, denylist.IsDeniedInput{
+		Jti:      claims.Jti,
+		Subject:  claims.ClientID,
+		IssuedAt: claims.IssuedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return &oauth.IntrospectOutput{}, nil
+	}
+
+	return &oauth.IntrospectOutput{
+		Active:    true,
+		Sub:       claims.ClientID,
+		ClientID:  claims.ClientID,
+		Scope:     strings.Join(claims.Scopes, " "),
+		TokenType: "Bearer",
+		TokenUse:  "access",
+		Jti:       claims.Jti,
+		Exp:       claims.ExpiresAt.Unix(),
+		Iss:       uc.oauth.Issuer(),
+	}, nil
+}
+
+func (uc *IntrospectUseCase) introspectAccessToken(ctx context.Context, token string) (*oauth.IntrospectOutput, error) {
+	claims, err := uc.auth.ValidateToken(ctx, token)
+	if err != nil || claims.TokenUse != "access" {
+		return &oauth.IntrospectOutput{}, nil
+	}
+
+	denied, err := uc.denylist.IsDenied(ctx, denylist.IsDeniedInput{
+		Jti:       claims.Jti,
+		OriginJti: claims.OriginJti,
+		Subject:   claims.Id,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return &oauth.IntrospectOutput{}, nil
+	}
+
+	out := userIntrospection(claims)
+	out.TokenType = "Bearer"
+	return out, nil
+}
+
+// introspectRefreshToken reads the binding recorded when the token was issued, without exercising the token: only
+// refresh tokens handed out at the token endpoint are known.
+func (uc *IntrospectUseCase) introspectRefreshToken(ctx context.Context, token string) (*oauth.IntrospectOutput, error) {
+	binding, err := uc.refreshTokens.Get(ctx, token)
+	if err != nil {
+		if err == oauth.ErrInvalidGrant {
+			return &oauth.IntrospectOutput{}, nil
+		}
+		return nil, err
+	}
+
+	denied, err := uc.denylist.IsDenied(ctx, denylist.IsDeniedInput{
+		OriginJti: binding.OriginJti,
+		Subject:   binding.Subject,
+		IssuedAt:  binding.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return &oauth.IntrospectOutput{}, nil
+	}
+
+	authUser, err := uc.auth.GetUser(ctx, auth.GetUserInput{Username: binding.Username})
+	if err != nil || authUser.Id != binding.Subject || !authUser.Enabled {
+		return &oauth.IntrospectOutput{}, nil
+	}
+	groups, err := uc.auth.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: binding.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth.IntrospectOutput{
+		Active:   true,
+		Sub:      binding.Subject,
+		Username: binding.Username,
+		ClientID: binding.ClientID,
+		Scope:    binding.Scope,
+		TokenUse: "refresh",
+		Exp:      binding.ExpiresAt.Unix(),
+		Iat:      binding.CreatedAt.Unix(),
+		Groups:   groups,
+	}, nil
+}
+
+func userIntrospection(claims *auth.Claims) *oauth.IntrospectOutput {
+	return &oauth.IntrospectOutput{
+		Active:   true,
+		Sub:      claims.Id,
+		Username: claims.Email,
+		ClientID: claims.ClientId,
+		Scope:    claims.Scope,
+		TokenUse: claims.TokenUse,
+		Jti:      claims.Jti,
+		Exp:      claims.ExpiresAt,
+		Iat:      claims.IssuedAt,
+		Groups:   claims.UserGroups,
+	}
+}
+
+// isJWT tells JWS access tokens apart from opaque refresh tokens.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,20 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"context"
+)
+
+type ListClientsUseCase struct {
+	clients oauth.ClientRepository
+}
+
+func NewListClientsUseCase(clients oauth.ClientRepository) *ListClientsUseCase {
+	return &ListClientsUseCase{
+		clients: clients,
+	}
+}
+
+func (uc *ListClientsUseCase) Execute(ctx context.Context) ([]oauth.Client, error) {
+	return uc.clients.List(ctx)
+}
@@ -0,0 +1,58 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"time"
+)
+
+type Options struct {
+	// LoginPageURL is the hosted UI page the authorize endpoint sends the user to.
+	LoginPageURL         string
+	AuthorizationCodeTTL time.Duration
+	// RefreshTokenTTL should match the refresh token lifetime of the auth provider.
+	RefreshTokenTTL time.Duration
+}
+
+type UseCases struct {
+	Discovery      *DiscoveryUseCase
+	Authorize      *AuthorizeUseCase
+	AuthorizeLogin *AuthorizeLoginUseCase
+	AuthorizeMFA   *AuthorizeMFAUseCase
+	Token          *TokenUseCase
+	UserInfo       *UserInfoUseCase
+	Introspect     *IntrospectUseCase
+	Revoke         *RevokeUseCase
+
+	CreateClient       *CreateClientUseCase
+	GetClient          *GetClientUseCase
+	ListClients        *ListClientsUseCase
+	UpdateClient       *UpdateClientUseCase
+	RotateClientSecret *RotateClientSecretUseCase
+	DeleteClient       *DeleteClientUseCase
+}
+
+func NewUseCases(authUseCases *auth_usecases.UseCases, authService auth.AuthService, oauthService oauth.OAuthService, clients oauth.ClientRepository, codes oauth.AuthorizationCodeRepository, refreshTokens oauth.RefreshTokenRepository, denylist denylist.DenylistService, options Options, logger logger.Logger) *UseCases {
+	issuer := newCodeIssuer(authService, authUseCases.GetMe, codes, options.AuthorizationCodeTTL, logger)
+
+	return &UseCases{
+		Discovery:      NewDiscoveryUseCase(oauthService),
+		Authorize:      NewAuthorizeUseCase(clients, options),
+		AuthorizeLogin: NewAuthorizeLoginUseCase(clients, authUseCases.Login, issuer),
+		AuthorizeMFA:   NewAuthorizeMFAUseCase(clients, authUseCases.VerifyMFA, issuer),
+		Token:          NewTokenUseCase(clients, codes, refreshTokens, options.RefreshTokenTTL, oauthService, authService, authUseCases.RefreshToken, authUseCases.GetMe, logger),
+		UserInfo:       NewUserInfoUseCase(authService, authUseCases.GetMe),
+		Introspect:     NewIntrospectUseCase(clients, refreshTokens, oauthService, authService, denylist, logger),
+		Revoke:         NewRevokeUseCase(clients, refreshTokens, oauthService, authService, denylist, logger),
+
+		CreateClient:       NewCreateClientUseCase(clients, logger),
+		GetClient:          NewGetClientUseCase(clients),
+		ListClients:        NewListClientsUseCase(clients),
+		UpdateClient:       NewUpdateClientUseCase(clients),
+		RotateClientSecret: NewRotateClientSecretUseCase(clients, logger),
+		DeleteClient:       NewDeleteClientUseCase(clients),
+	}
+}
@@ -0,0 +1,134 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"errors"
+	"time"
+)
+
+type RevokeUseCase struct {
+	clients       oauth.ClientRepository
+	refreshTokens oauth.RefreshTokenRepository
+	oauth         oauth.OAuthService
+	auth          auth.AuthService
+	denylist      denylist.DenylistService
+	logger        logger.Logger
+}
+
+type RevokeInput struct {
+	oauth.RevokeInput
+}
+
+func NewRevokeUseCase(clients oauth.ClientRepository, refreshTokens oauth.RefreshTokenRepository, oauthService oauth.OAuthService, authService auth.AuthService, denylist denylist.DenylistService, logger logger.Logger) *RevokeUseCase {
+	return &RevokeUseCase{
+		clients:       clients,
+		refreshTokens: refreshTokens,
+		oauth:         oauthService,
+		auth:          authService,
+		denylist:      denylist,
+		logger:        logger,
+	}
+}
+
+// Execute revokes refresh tokens with the auth provider and denylists access tokens by jti until they expire.
+// Invalid or already revoked tokens are not an error (RFC 7009 section 2.2).
+func (uc *RevokeUseCase) Execute(ctx context.Context, input RevokeInput) error {
+	if err := input.RevokeInput.Validate(); err != nil {
+		return err
+	}
+
+	client, err := authenticateClient(ctx, uc.clients, input.ClientID, input.ClientSecret, uc.logger)
+	if err != nil {
+		return err
+	}
+	if !client.IsConfidential() {
+		return oauth.ErrUnauthorizedClient
+	}
+
+	if !isJWT(input.Token) {
+		return uc.revokeRefreshToken(ctx, client, input.Token)
+	}
+	if uc.oauth.IsClientToken(input.Token) {
+		return uc.revokeClientToken(ctx, client, input.Token)
+	}
+	return uc.revokeAccessToken(ctx, client, input.Token)
+}
+
+// revokeRefreshToken only lets a client revoke the refresh tokens it was issued. It also denylists the session, the
+// access tokens already minted from the refresh token share its origin_jti.
+func (uc *RevokeUseCase) revokeRefreshToken(ctx context.Context, client *oauth.Client, token string) error {
+	binding, err := uc.refreshTokens.Get(ctx, token)
+	if err != nil {
+		if err == oauth.ErrInvalidGrant {
+			return nil
+		}
+		return err
+	}
+	if binding.ClientID != client.ClientID {
+		return oauth.ErrUnauthorizedClient
+	}
+
+	err = uc.auth.RevokeRefreshToken(ctx, auth.RevokeRefreshTokenInput{
+		RefreshToken: token,
+	})
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidRefreshToken) {
+			return err
+		}
+	}
+	if err := uc.refreshTokens.Delete(ctx, token); err != nil {
+		return err
+	}
+
+	if binding.OriginJti == "" {
+		return nil
+	}
+	return uc.denylist.DenyToken(ctx, denylist.DenyTokenInput{
+		Jti: binding.OriginJti,
+	})
+}
+
+// revokeClientToken only lets a client revoke the tokens it was issued.
+func (uc *RevokeUseCase) revokeClientToken(ctx context.Context, client *oauth.Client, token string) error {
+	claims, err := uc.oauth.ValidateClientToken(ctx, token)
+	if err != nil {
+		return nil
+	}
+	if claims.ClientID != client.ClientID {
+		return oauth.ErrUnauthorizedClient
+	}
+
+	return uc.denylist.DenyToken(ctx, denylist.DenyTokenInput{
+		Jti:       claims.Jti,
+		ExpiresAt: claims.ExpiresAt,
+	})
+}
+
+// revokeAccessToken only lets a client revoke the user access tokens it was issued. They carry the client ID of the
+// auth provider, so the client is the one the refresh token of their session was bound to.
+func (uc *RevokeUseCase) revokeAccessToken(ctx context.Context, client *oauth.Client, token string) error {
+	claims, err := uc.auth.ValidateToken(ctx, token)
+	if err != nil || claims.TokenUse != "access" || claims.Jti == "" {
+		return nil
+	}
+
+	binding, err := uc.refreshTokens.GetByOriginJti(ctx, claims.OriginJti)
+	if err != nil {
+		if err == oauth.ErrInvalidGrant {
+			return oauth.ErrUnauthorizedClient
+		}
+		return err
+	}
+	if binding.ClientID != client.ClientID || binding.Subject != claims.Id {
+		return oauth.ErrUnauthorizedClient
+	}
+
+	return uc.denylist.DenyToken(ctx, denylist.DenyTokenInput{
+		Jti:       claims.Jti,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	})
+}
@@ -0,0 +1,44 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+type RotateClientSecretUseCase struct {
+	clients oauth.ClientRepository
+	logger  logger.Logger
+}
+
+type RotateClientSecretInput struct {
+	ClientID string
+}
+
+func NewRotateClientSecretUseCase(clients oauth.ClientRepository, logger logger.Logger) *RotateClientSecretUseCase {
+	return &RotateClientSecretUseCase{
+		clients: clients,
+		logger:  logger,
+	}
+}
+
+// Execute replaces the client secret, which also turns a public client into a confidential one.
+func (uc *RotateClientSecretUseCase) Execute(ctx context.Context, input RotateClientSecretInput) (*oauth.RotateClientSecretOutput, error) {
+	secret, secretHash, err := newClientSecret()
+	if err != nil {
+		uc.logger.Error("Error generating client secret: %v", err)
+		return nil, err
+	}
+
+	if err := uc.clients.SetSecret(ctx, &oauth.SetClientSecretInput{
+		ClientID:   input.ClientID,
+		SecretHash: secretHash,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &oauth.RotateClientSecretOutput{
+		ClientID:     input.ClientID,
+		ClientSecret: secret,
+	}, nil
+}
@@ -0,0 +1,248 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/password"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+type TokenUseCase struct {
+	clients       oauth.ClientRepository
+	codes         oauth.AuthorizationCodeRepository
+	refreshTokens oauth.RefreshTokenRepository
+	// refreshTokenTTL bounds how long the client of a refresh token is remembered.
+	refreshTokenTTL time.Duration
+	oauth           oauth.OAuthService
+	auth            auth.AuthService
+	refreshToken    *auth_usecases.RefreshTokenUseCase
+	getMe           *auth_usecases.GetMeUseCase
+	logger          logger.Logger
+}
+
+type TokenInput struct {
+	oauth.TokenInput
+}
+
+func NewTokenUseCase(clients oauth.ClientRepository, codes oauth.AuthorizationCodeRepository, refreshTokens oauth.RefreshTokenRepository, refreshTokenTTL time.Duration, oauthService oauth.OAuthService, authService auth.AuthService, refreshToken *auth_usecases.RefreshTokenUseCase, getMe *auth_usecases.GetMeUseCase, logger logger.Logger) *TokenUseCase {
+	return &TokenUseCase{
+		clients:         clients,
+		codes:           codes,
+		refreshTokens:   refreshTokens,
+		refreshTokenTTL: refreshTokenTTL,
+		oauth:           oauthService,
+		auth:            authService,
+		refreshToken:    refreshToken,
+		getMe:           getMe,
+		logger:          logger,
+	}
+}
+
+func (uc *TokenUseCase) Execute(ctx context.Context, input TokenInput) (*oauth.TokenOutput, error) {
+	if err := input.TokenInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := authenticateClient(ctx, uc.clients, input.ClientID, input.ClientSecret, uc.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch input.GrantType {
+	case oauth.GrantTypeAuthorizationCode:
+		return uc.exchangeCode(ctx, input.TokenInput)
+	case oauth.GrantTypeClientCredentials:
+		return uc.clientCredentials(ctx, client, input.TokenInput)
+	default:
+		return uc.refresh(ctx, input.TokenInput)
+	}
+}
+
+// authenticateClient requires the secret of confidential clients and rejects secrets sent by public ones.
+func authenticateClient(ctx context.Context, clients oauth.ClientRepository, clientID string, clientSecret string, logger logger.Logger) (*oauth.Client, error) {
+	client, err := getClient(ctx, clients, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.IsConfidential() {
+		if clientSecret != "" {
+			return nil, oauth.ErrInvalidClient
+		}
+		return client, nil
+	}
+
+	valid, err := password.Verify(clientSecret, client.SecretHash)
+	if err != nil {
+		logger.Error("Error verifying client secret: %v", err)
+		return nil, oauth.ErrInvalidClient
+	}
+	if !valid {
+		return nil, oauth.ErrInvalidClient
+	}
+	return client, nil
+}
+
+func (uc *TokenUseCase) clientCredentials(ctx context.Context, client *oauth.Client, input oauth.TokenInput) (*oauth.TokenOutput, error) {
+	if !client.IsConfidential() {
+		return nil, oauth.ErrUnauthorizedClient
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = strings.Join(client.Scopes, " ")
+	}
+	if !client.AllowsScopes(scope) {
+		return nil, oauth.ErrInvalidScope
+	}
+
+	ttl := time.Duration(client.AccessTokenTTL) * time.Second
+	accessToken, err := uc.oauth.IssueClientToken(ctx, oauth.IssueClientTokenInput{
+		ClientID: client.ClientID,
+		Scope:    scope,
+		TTL:      ttl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth.TokenOutput{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+func (uc *TokenUseCase) exchangeCode(ctx context.Context, input oauth.TokenInput) (*oauth.TokenOutput, error) {
+	code, err := uc.codes.Consume(ctx, input.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if code.ClientID != input.ClientID || code.RedirectURI != input.RedirectURI || !code.VerifyCodeVerifier(input.CodeVerifier) {
+		return nil, oauth.ErrInvalidGrant
+	}
+
+	refreshed, err := uc.refreshToken.Execute(ctx, auth_usecases.RefreshTokenInput{
+		RefreshTokenInput: auth.RefreshTokenInput{
+			RefreshToken: code.RefreshToken,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidRefreshToken) {
+			return nil, oauth.ErrInvalidGrant
+		}
+		return nil, err
+	}
+
+	claims, err := uc.auth.ValidateToken(ctx, refreshed.AccessToken)
+	if err != nil {
+		uc.logger.Error("Error validating exchanged access token: %v", err)
+		return nil, err
+	}
+
+	err = uc.refreshTokens.Bind(ctx, &oauth.BindRefreshTokenInput{
+		RefreshToken: code.RefreshToken,
+		ClientID:     code.ClientID,
+		Subject:      code.Subject,
+		Username:     code.Email,
+		OriginJti:    claims.OriginJti,
+		Scope:        code.Scope,
+		AuthTime:     code.AuthTime,
+		ExpiresAt:    time.Now().Add(uc.refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &oauth.TokenOutput{
+		AccessToken:  refreshed.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    uc.oauth.TokenExpiresIn(refreshed.AccessToken),
+		RefreshToken: code.RefreshToken,
+		Scope:        code.Scope,
+	}
+
+	if code.HasScope(oauth.ScopeOpenID) {
+		idToken, err := uc.oauth.IssueIdToken(ctx, oauth.IssueIdTokenInput{
+			ClientID: code.ClientID,
+			Subject:  code.Subject,
+			Email:    code.Email,
+			Name:     code.Name,
+			Nonce:    code.Nonce,
+			AuthTime: code.AuthTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out.IdToken = idToken
+	}
+	return out, nil
+}
+
+// refresh only accepts the refresh tokens handed out to the client by exchangeCode.
+func (uc *TokenUseCase) refresh(ctx context.Context, input oauth.TokenInput) (*oauth.TokenOutput, error) {
+	binding, err := uc.refreshTokens.Get(ctx, input.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if binding.ClientID != input.ClientID {
+		return nil, oauth.ErrInvalidGrant
+	}
+
+	refreshed, err := uc.refreshToken.Execute(ctx, auth_usecases.RefreshTokenInput{
+		RefreshTokenInput: auth.RefreshTokenInput{
+			RefreshToken: input.RefreshToken,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidRefreshToken) {
+			return nil, oauth.ErrInvalidGrant
+		}
+		return nil, err
+	}
+
+	claims, err := uc.auth.ValidateToken(ctx, refreshed.AccessToken)
+	if err != nil {
+		uc.logger.Error("Error validating refreshed access token: %v", err)
+		return nil, err
+	}
+
+	out := &oauth.TokenOutput{
+		AccessToken: refreshed.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   uc.oauth.TokenExpiresIn(refreshed.AccessToken),
+		Scope:       binding.Scope,
+	}
+
+	// The ID token is only issued again to the grants that asked for one, with the time of the original sign in.
+	if binding.HasScope(oauth.ScopeOpenID) {
+		me, err := uc.getMe.Execute(ctx, auth_usecases.GetMeInput{
+			GetMeInput: auth.GetMeInput{
+				AccessToken: refreshed.AccessToken,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		idToken, err := uc.oauth.IssueIdToken(ctx, oauth.IssueIdTokenInput{
+			ClientID: input.ClientID,
+			Subject:  claims.Id,
+			Email:    claims.Email,
+			Name:     me.Name,
+			AuthTime: binding.AuthTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out.IdToken = idToken
+	}
+	return out, nil
+}
@@ -0,0 +1,32 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	"context"
+)
+
+type UpdateClientUseCase struct {
+	clients oauth.ClientRepository
+}
+
+type UpdateClientInput struct {
+	oauth.UpdateClientInput
+}
+
+func NewUpdateClientUseCase(clients oauth.ClientRepository) *UpdateClientUseCase {
+	return &UpdateClientUseCase{
+		clients: clients,
+	}
+}
+
+func (uc *UpdateClientUseCase) Execute(ctx context.Context, input UpdateClientInput) (*oauth.Client, error) {
+	if err := input.UpdateClientInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.clients.Update(ctx, &input.UpdateClientInput); err != nil {
+		return nil, err
+	}
+
+	return uc.clients.GetByID(ctx, &oauth.GetClientInput{ClientID: input.ClientID})
+}
@@ -0,0 +1,51 @@
+package oauth
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/oauth"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"context"
+)
+
+type UserInfoUseCase struct {
+	auth  auth.AuthService
+	getMe *auth_usecases.GetMeUseCase
+}
+
+type UserInfoInput struct {
+	oauth.UserInfoInput
+}
+
+func NewUserInfoUseCase(authService auth.AuthService, getMe *auth_usecases.GetMeUseCase) *UserInfoUseCase {
+	return &UserInfoUseCase{
+		auth:  authService,
+		getMe: getMe,
+	}
+}
+
+func (uc *UserInfoUseCase) Execute(ctx context.Context, input UserInfoInput) (*oauth.UserInfoOutput, error) {
+	if err := input.UserInfoInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	claims, err := uc.auth.ValidateToken(ctx, input.AccessToken)
+	if err != nil {
+		return nil, oauth.ErrInvalidToken
+	}
+
+	me, err := uc.getMe.Execute(ctx, auth_usecases.GetMeInput{
+		GetMeInput: auth.GetMeInput{
+			AccessToken: input.AccessToken,
+		},
+	})
+	if err != nil {
+		return nil, oauth.ErrInvalidToken
+	}
+
+	return &oauth.UserInfoOutput{
+		Sub:    claims.Id,
+		Email:  claims.Email,
+		Name:   me.Name,
+		Groups: claims.UserGroups,
+	}, nil
+}
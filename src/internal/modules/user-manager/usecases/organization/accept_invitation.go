@@ -0,0 +1,71 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"context"
+	"strings"
+)
+
+type AcceptInvitationUseCase struct {
+	members      organization.MemberRepository
+	invitations  organization.InvitationRepository
+	signer       organization.InvitationSigner
+	transactions transaction.UnitOfWork
+}
+
+type AcceptInvitationInput struct {
+	Token string
+	// UserID and Email identify the signed in user accepting the invitation.
+	UserID string
+	Email  string
+}
+
+func NewAcceptInvitationUseCase(members organization.MemberRepository, invitations organization.InvitationRepository, signer organization.InvitationSigner, transactions transaction.UnitOfWork) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{
+		members:      members,
+		invitations:  invitations,
+		signer:       signer,
+		transactions: transactions,
+	}
+}
+
+// Execute attaches the invited role to an existing account, which must own the invited email.
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, input AcceptInvitationInput) (*organization.Member, error) {
+	invitation, err := loadInvitation(ctx, uc.signer, uc.invitations, input.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(invitation.Email, input.Email) {
+		return nil, organization.ErrInvitationEmail
+	}
+
+	return acceptInvitation(ctx, uc.members, uc.invitations, uc.transactions, invitation, input.UserID)
+}
+
+// acceptInvitation adds the membership and consumes the invitation in one transaction, so that a link
+// cannot be used twice and a failed membership leaves the invitation pending.
+func acceptInvitation(ctx context.Context, members organization.MemberRepository, invitations organization.InvitationRepository, transactions transaction.UnitOfWork, invitation *organization.Invitation, userID string) (*organization.Member, error) {
+	if err := transactions.WithTx(ctx, func(ctx context.Context) error {
+		if err := members.Add(ctx, &organization.AddMemberInput{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Email:          invitation.Email,
+			Role:           invitation.Role,
+		}); err != nil {
+			return err
+		}
+
+		return invitations.SetStatus(ctx, &organization.SetInvitationStatusInput{
+			ID:     invitation.ID,
+			Status: organization.InvitationAccepted,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return members.Get(ctx, &organization.GetMemberInput{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+	})
+}
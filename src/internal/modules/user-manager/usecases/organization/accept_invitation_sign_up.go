@@ -0,0 +1,67 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"context"
+)
+
+type AcceptInvitationSignUpUseCase struct {
+	members      organization.MemberRepository
+	invitations  organization.InvitationRepository
+	signer       organization.InvitationSigner
+	auth         auth.AuthService
+	registerUser *user_usecases.RegisterUserUseCase
+	transactions transaction.UnitOfWork
+}
+
+type AcceptInvitationSignUpInput struct {
+	Token    string
+	Name     string
+	Password string
+	Phone    *string
+}
+
+func NewAcceptInvitationSignUpUseCase(members organization.MemberRepository, invitations organization.InvitationRepository, signer organization.InvitationSigner, auth auth.AuthService, registerUser *user_usecases.RegisterUserUseCase, transactions transaction.UnitOfWork) *AcceptInvitationSignUpUseCase {
+	return &AcceptInvitationSignUpUseCase{
+		members:      members,
+		invitations:  invitations,
+		signer:       signer,
+		auth:         auth,
+		registerUser: registerUser,
+		transactions: transactions,
+	}
+}
+
+// Execute registers the invitee with the invited email, then attaches the invited role to the new account.
+func (uc *AcceptInvitationSignUpUseCase) Execute(ctx context.Context, input AcceptInvitationSignUpInput) (*organization.Member, error) {
+	invitation, err := loadInvitation(ctx, uc.signer, uc.invitations, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.registerUser.Execute(ctx, user_usecases.RegisterUserInput{
+		SignUpInput: auth.SignUpInput{
+			Username: invitation.Email,
+			Password: input.Password,
+			Name:     input.Name,
+		},
+		CreateUserInput: user.CreateUserInput{
+			Name:  input.Name,
+			Email: invitation.Email,
+			Phone: input.Phone,
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	usr, err := uc.auth.GetUser(ctx, auth.GetUserInput{Username: invitation.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	return acceptInvitation(ctx, uc.members, uc.invitations, uc.transactions, invitation, usr.Id)
+}
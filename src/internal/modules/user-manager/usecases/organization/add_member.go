@@ -0,0 +1,62 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type AddMemberUseCase struct {
+	members organization.MemberRepository
+	roles   role.RoleRepository
+	auth    auth.AuthService
+}
+
+type AddMemberInput struct {
+	OrganizationID string
+	Username       string
+	Role           string
+	// Actor is the membership of the caller, nil for platform admins.
+	Actor *organization.Member
+}
+
+func NewAddMemberUseCase(members organization.MemberRepository, roles role.RoleRepository, auth auth.AuthService) *AddMemberUseCase {
+	return &AddMemberUseCase{
+		members: members,
+		roles:   roles,
+		auth:    auth,
+	}
+}
+
+func (uc *AddMemberUseCase) Execute(ctx context.Context, input AddMemberInput) (*organization.Member, error) {
+	usr, err := uc.auth.GetUser(ctx, auth.GetUserInput{
+		Username: input.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addMemberInput := organization.AddMemberInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         usr.Id,
+		Email:          usr.Email,
+		Role:           input.Role,
+	}
+	if err := addMemberInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := checkGrantable(ctx, uc.roles, input.Actor, input.Role); err != nil {
+		return nil, err
+	}
+
+	if err := uc.members.Add(ctx, &addMemberInput); err != nil {
+		return nil, err
+	}
+
+	return uc.members.Get(ctx, &organization.GetMemberInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         usr.Id,
+	})
+}
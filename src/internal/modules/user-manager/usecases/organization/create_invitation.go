@@ -0,0 +1,97 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/pkg/logger"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateInvitationUseCase struct {
+	orgs        organization.OrganizationRepository
+	members     organization.MemberRepository
+	invitations organization.InvitationRepository
+	roles       role.RoleRepository
+	auth        auth.AuthService
+	signer      organization.InvitationSigner
+	email       email.EmailService
+	options     Options
+	logger      logger.Logger
+}
+
+type CreateInvitationInput struct {
+	OrganizationID string
+	Email          string
+	Role           string
+	// InvitedBy is the user ID of the caller.
+	InvitedBy string
+	Actor     *organization.Member
+}
+
+func NewCreateInvitationUseCase(orgs organization.OrganizationRepository, members organization.MemberRepository, invitations organization.InvitationRepository, roles role.RoleRepository, auth auth.AuthService, signer organization.InvitationSigner, email email.EmailService, options Options, logger logger.Logger) *CreateInvitationUseCase {
+	return &CreateInvitationUseCase{
+		orgs:        orgs,
+		members:     members,
+		invitations: invitations,
+		roles:       roles,
+		auth:        auth,
+		signer:      signer,
+		email:       email,
+		options:     options,
+		logger:      logger,
+	}
+}
+
+func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvitationInput) (*organization.Invitation, error) {
+	createInput := organization.CreateInvitationInput{
+		ID:             uuid.NewString(),
+		OrganizationID: input.OrganizationID,
+		Email:          input.Email,
+		Role:           input.Role,
+		InvitedBy:      input.InvitedBy,
+		ExpiresAt:      time.Now().Add(uc.options.InvitationTTL),
+	}
+	if err := createInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgs.GetByID(ctx, &organization.GetOrganizationInput{ID: input.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkGrantable(ctx, uc.roles, input.Actor, input.Role); err != nil {
+		return nil, err
+	}
+
+	if usr, err := uc.auth.GetUser(ctx, auth.GetUserInput{Username: createInput.Email}); err == nil {
+		if _, err := uc.members.Get(ctx, &organization.GetMemberInput{OrganizationID: org.ID, UserID: usr.Id}); err == nil {
+			return nil, organization.ErrMemberAlreadyExists
+		}
+	}
+
+	if err := uc.invitations.Create(ctx, &createInput); err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitations.GetByID(ctx, &organization.GetInvitationInput{ID: createInput.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sendInvitation(ctx, uc.signer, uc.email, uc.options, org, invitation); err != nil {
+		uc.logger.Error("Error sending invitation: %v", err)
+		// Nobody received the link, do not leave a pending invitation blocking the next attempt.
+		if err := uc.invitations.SetStatus(ctx, &organization.SetInvitationStatusInput{ID: invitation.ID, Status: organization.InvitationRevoked}); err != nil {
+			uc.logger.Error("Error revoking unsent invitation: %v", err)
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,55 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/pkg/logger"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CreateOrganizationUseCase struct {
+	orgs      organization.OrganizationRepository
+	addMember *AddMemberUseCase
+	logger    logger.Logger
+}
+
+type CreateOrganizationInput struct {
+	organization.CreateOrganizationInput
+	// OwnerUsername optionally names the first OrgAdmin of the organization.
+	OwnerUsername string
+}
+
+func NewCreateOrganizationUseCase(orgs organization.OrganizationRepository, addMember *AddMemberUseCase, logger logger.Logger) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		orgs:      orgs,
+		addMember: addMember,
+		logger:    logger,
+	}
+}
+
+func (uc *CreateOrganizationUseCase) Execute(ctx context.Context, input CreateOrganizationInput) (*organization.Organization, error) {
+	input.ID = uuid.NewString()
+	if err := input.CreateOrganizationInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.orgs.Create(ctx, &input.CreateOrganizationInput); err != nil {
+		return nil, err
+	}
+
+	if input.OwnerUsername != "" {
+		if _, err := uc.addMember.Execute(ctx, AddMemberInput{
+			OrganizationID: input.ID,
+			Username:       input.OwnerUsername,
+			Role:           organization.RoleOrgAdmin,
+		}); err != nil {
+			if err := uc.orgs.Delete(ctx, &organization.DeleteOrganizationInput{ID: input.ID}); err != nil {
+				uc.logger.Error("Error rolling back organization creation: %v", err)
+			}
+			return nil, err
+		}
+	}
+
+	return uc.orgs.GetByID(ctx, &organization.GetOrganizationInput{ID: input.ID})
+}
@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type DeleteOrganizationUseCase struct {
+	orgs organization.OrganizationRepository
+}
+
+type DeleteOrganizationInput struct {
+	organization.DeleteOrganizationInput
+}
+
+func NewDeleteOrganizationUseCase(orgs organization.OrganizationRepository) *DeleteOrganizationUseCase {
+	return &DeleteOrganizationUseCase{
+		orgs: orgs,
+	}
+}
+
+func (uc *DeleteOrganizationUseCase) Execute(ctx context.Context, input DeleteOrganizationInput) error {
+	if err := input.DeleteOrganizationInput.Validate(); err != nil {
+		return err
+	}
+
+	return uc.orgs.Delete(ctx, &input.DeleteOrganizationInput)
+}
@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+	"time"
+)
+
+type GetInvitationUseCase struct {
+	orgs        organization.OrganizationRepository
+	invitations organization.InvitationRepository
+	signer      organization.InvitationSigner
+}
+
+type GetInvitationInput struct {
+	Token string
+}
+
+type GetInvitationOutput struct {
+	OrganizationName string    `json:"organizationName"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
+
+func NewGetInvitationUseCase(orgs organization.OrganizationRepository, invitations organization.InvitationRepository, signer organization.InvitationSigner) *GetInvitationUseCase {
+	return &GetInvitationUseCase{
+		orgs:        orgs,
+		invitations: invitations,
+		signer:      signer,
+	}
+}
+
+// Execute describes the invitation of a link so that the invitee knows what they are accepting.
+func (uc *GetInvitationUseCase) Execute(ctx context.Context, input GetInvitationInput) (*GetInvitationOutput, error) {
+	invitation, err := loadInvitation(ctx, uc.signer, uc.invitations, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgs.GetByID(ctx, &organization.GetOrganizationInput{ID: invitation.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetInvitationOutput{
+		OrganizationName: org.Name,
+		Email:            invitation.Email,
+		Role:             invitation.Role,
+		ExpiresAt:        invitation.ExpiresAt,
+	}, nil
+}
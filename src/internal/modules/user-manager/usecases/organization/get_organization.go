@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type GetOrganizationUseCase struct {
+	orgs organization.OrganizationRepository
+}
+
+type GetOrganizationInput struct {
+	organization.GetOrganizationInput
+}
+
+func NewGetOrganizationUseCase(orgs organization.OrganizationRepository) *GetOrganizationUseCase {
+	return &GetOrganizationUseCase{
+		orgs: orgs,
+	}
+}
+
+func (uc *GetOrganizationUseCase) Execute(ctx context.Context, input GetOrganizationInput) (*organization.Organization, error) {
+	if err := input.GetOrganizationInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.orgs.GetByID(ctx, &input.GetOrganizationInput)
+}
@@ -0,0 +1,47 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+// checkGrantable keeps org admins from escalating: they can only hand out, or take back, roles whose
+// permissions they hold themselves. A nil actor is a platform admin and may grant any existing role.
+func checkGrantable(ctx context.Context, roles role.RoleRepository, actor *organization.Member, roleName string) error {
+	target, err := roles.GetByName(ctx, &role.GetRoleInput{Name: roleName})
+	if err != nil {
+		return err
+	}
+	if actor == nil || actor.Role == target.Name {
+		return nil
+	}
+
+	actorRole, err := roles.GetByName(ctx, &role.GetRoleInput{Name: actor.Role})
+	if err != nil {
+		return err
+	}
+	for _, permission := range target.Permissions {
+		if !actorRole.HasPermission(permission) {
+			return organization.ErrRoleNotGrantable
+		}
+	}
+	return nil
+}
+
+// checkKeepsOrgAdmin fails when the member is the last OrgAdmin of the organization and is about to lose the role.
+// It must run in the transaction that changes the member, which holds the lock on the OrgAdmins until it ends.
+func checkKeepsOrgAdmin(ctx context.Context, members organization.MemberRepository, member *organization.Member) error {
+	if member.Role != organization.RoleOrgAdmin {
+		return nil
+	}
+
+	count, err := members.CountWithRole(ctx, member.OrganizationID, organization.RoleOrgAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return organization.ErrLastOrgAdmin
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// sendInvitation emails a freshly signed link to the invitee.
+func sendInvitation(ctx context.Context, signer organization.InvitationSigner, emailService email.EmailService, options Options, org *organization.Organization, invitation *organization.Invitation) error {
+	token, err := signer.Sign(invitation)
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(options.InvitationURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return emailService.SendEmail(ctx, email.Email{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", org.Name),
+		Body: fmt.Sprintf("You have been invited to join %s as %s. Accept the invitation before %s: %s",
+			org.Name, invitation.Role, invitation.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"), link.String()),
+	})
+}
+
+// loadInvitation returns the invitation of a signed link, as long as the link is the latest one sent and
+// the invitation can still be accepted.
+func loadInvitation(ctx context.Context, signer organization.InvitationSigner, invitations organization.InvitationRepository, token string) (*organization.Invitation, error) {
+	claims, err := signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := invitations.GetByID(ctx, &organization.GetInvitationInput{ID: claims.InvitationID})
+	if err != nil {
+		if err == organization.ErrInvitationNotFound {
+			return nil, organization.ErrInvalidInvitation
+		}
+		return nil, err
+	}
+	if invitation.Version != claims.Version || !invitation.IsUsable() {
+		return nil, organization.ErrInvalidInvitation
+	}
+	return invitation, nil
+}
+
+// getOrgInvitation loads an invitation through an organization route, invitations of other organizations
+// are reported as missing.
+func getOrgInvitation(ctx context.Context, invitations organization.InvitationRepository, organizationID string, invitationID string) (*organization.Invitation, error) {
+	invitation, err := invitations.GetByID(ctx, &organization.GetInvitationInput{ID: invitationID})
+	if err != nil {
+		return nil, err
+	}
+	if invitation.OrganizationID != organizationID {
+		return nil, organization.ErrInvitationNotFound
+	}
+	return invitation, nil
+}
@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+// IssueTokenUseCase exchanges a refresh token of a member for an access token carrying the org_id claim,
+// which AuthMiddleware then refuses on any other organization.
+type IssueTokenUseCase struct {
+	members organization.MemberRepository
+	auth    auth.AuthService
+}
+
+type IssueTokenInput struct {
+	auth.RefreshTokenInput
+	// UserID is the signed in caller, the refresh token must be theirs.
+	UserID string
+}
+
+func NewIssueTokenUseCase(members organization.MemberRepository, auth auth.AuthService) *IssueTokenUseCase {
+	return &IssueTokenUseCase{
+		members: members,
+		auth:    auth,
+	}
+}
+
+func (uc *IssueTokenUseCase) Execute(ctx context.Context, input IssueTokenInput) (*auth.RefreshTokenOutput, error) {
+	if err := input.RefreshTokenInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	_, err := uc.members.Get(ctx, &organization.GetMemberInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         input.UserID,
+	})
+	if err != nil {
+		if err == organization.ErrMemberNotFound {
+			return nil, organization.ErrNotAMember
+		}
+		return nil, err
+	}
+
+	out, err := uc.auth.RefreshToken(ctx, input.RefreshTokenInput)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := uc.auth.ValidateToken(ctx, out.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Id != input.UserID {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+	if claims.OrgId != input.OrganizationID {
+		return nil, organization.ErrOrgClaimNotMinted
+	}
+	return out, nil
+}
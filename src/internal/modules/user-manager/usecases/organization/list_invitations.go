@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type ListInvitationsUseCase struct {
+	invitations organization.InvitationRepository
+}
+
+type ListInvitationsInput struct {
+	organization.ListInvitationsInput
+}
+
+func NewListInvitationsUseCase(invitations organization.InvitationRepository) *ListInvitationsUseCase {
+	return &ListInvitationsUseCase{
+		invitations: invitations,
+	}
+}
+
+func (uc *ListInvitationsUseCase) Execute(ctx context.Context, input ListInvitationsInput) ([]organization.Invitation, error) {
+	if err := input.ListInvitationsInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.invitations.List(ctx, &input.ListInvitationsInput)
+}
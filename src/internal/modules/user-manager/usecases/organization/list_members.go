@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type ListMembersUseCase struct {
+	members organization.MemberRepository
+}
+
+type ListMembersInput struct {
+	organization.ListMembersInput
+}
+
+func NewListMembersUseCase(members organization.MemberRepository) *ListMembersUseCase {
+	return &ListMembersUseCase{
+		members: members,
+	}
+}
+
+func (uc *ListMembersUseCase) Execute(ctx context.Context, input ListMembersInput) ([]organization.Member, error) {
+	if err := input.ListMembersInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.members.List(ctx, &input.ListMembersInput)
+}
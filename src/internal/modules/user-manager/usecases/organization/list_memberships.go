@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type ListMembershipsUseCase struct {
+	members organization.MemberRepository
+}
+
+type ListMembershipsInput struct {
+	organization.ListMembershipsInput
+}
+
+func NewListMembershipsUseCase(members organization.MemberRepository) *ListMembershipsUseCase {
+	return &ListMembershipsUseCase{
+		members: members,
+	}
+}
+
+func (uc *ListMembershipsUseCase) Execute(ctx context.Context, input ListMembershipsInput) ([]organization.Membership, error) {
+	if err := input.ListMembershipsInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.members.ListMemberships(ctx, &input.ListMembershipsInput)
+}
@@ -0,0 +1,20 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type ListOrganizationsUseCase struct {
+	orgs organization.OrganizationRepository
+}
+
+func NewListOrganizationsUseCase(orgs organization.OrganizationRepository) *ListOrganizationsUseCase {
+	return &ListOrganizationsUseCase{
+		orgs: orgs,
+	}
+}
+
+func (uc *ListOrganizationsUseCase) Execute(ctx context.Context) ([]organization.Organization, error) {
+	return uc.orgs.List(ctx)
+}
@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	user_usecases "auth-api/src/internal/modules/user-manager/usecases/user"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"time"
+)
+
+type Options struct {
+	InvitationTTL time.Duration
+	// InvitationURL is the page receiving the token query parameter of invitation links.
+	InvitationURL string
+}
+
+type UseCases struct {
+	CreateOrganization *CreateOrganizationUseCase
+	ListOrganizations  *ListOrganizationsUseCase
+	GetOrganization    *GetOrganizationUseCase
+	UpdateOrganization *UpdateOrganizationUseCase
+	DeleteOrganization *DeleteOrganizationUseCase
+	ListMemberships    *ListMembershipsUseCase
+	ListMembers        *ListMembersUseCase
+	AddMember          *AddMemberUseCase
+	UpdateMember       *UpdateMemberUseCase
+	RemoveMember       *RemoveMemberUseCase
+	IssueToken         *IssueTokenUseCase
+
+	CreateInvitation       *CreateInvitationUseCase
+	ListInvitations        *ListInvitationsUseCase
+	ResendInvitation       *ResendInvitationUseCase
+	RevokeInvitation       *RevokeInvitationUseCase
+	GetInvitation          *GetInvitationUseCase
+	AcceptInvitation       *AcceptInvitationUseCase
+	AcceptInvitationSignUp *AcceptInvitationSignUpUseCase
+}
+
+func NewUseCases(userUseCases *user_usecases.UseCases, orgs organization.OrganizationRepository, members organization.MemberRepository, invitations organization.InvitationRepository, roles role.RoleRepository, authService auth.AuthService, signer organization.InvitationSigner, emailService email.EmailService, transactions transaction.UnitOfWork, options Options, logger logger.Logger) *UseCases {
+	addMember := NewAddMemberUseCase(members, roles, authService)
+
+	return &UseCases{
+		CreateOrganization: NewCreateOrganizationUseCase(orgs, addMember, logger),
+		ListOrganizations:  NewListOrganizationsUseCase(orgs),
+		GetOrganization:    NewGetOrganizationUseCase(orgs),
+		UpdateOrganization: NewUpdateOrganizationUseCase(orgs),
+		DeleteOrganization: NewDeleteOrganizationUseCase(orgs),
+		ListMemberships:    NewListMembershipsUseCase(members),
+		ListMembers:        NewListMembersUseCase(members),
+		AddMember:          addMember,
+		UpdateMember:       NewUpdateMemberUseCase(members, roles, transactions),
+		RemoveMember:       NewRemoveMemberUseCase(members, roles, transactions),
+		IssueToken:         NewIssueTokenUseCase(members, authService),
+
+		CreateInvitation:       NewCreateInvitationUseCase(orgs, members, invitations, roles, authService, signer, emailService, options, logger),
+		ListInvitations:        NewListInvitationsUseCase(invitations),
+		ResendInvitation:       NewResendInvitationUseCase(orgs, invitations, roles, signer, emailService, options),
+		RevokeInvitation:       NewRevokeInvitationUseCase(invitations, roles),
+		GetInvitation:          NewGetInvitationUseCase(orgs, invitations, signer),
+		AcceptInvitation:       NewAcceptInvitationUseCase(members, invitations, signer, transactions),
+		AcceptInvitationSignUp: NewAcceptInvitationSignUpUseCase(members, invitations, signer, authService, userUseCases.Register, transactions),
+	}
+}
@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"context"
+)
+
+type RemoveMemberUseCase struct {
+	members      organization.MemberRepository
+	roles        role.RoleRepository
+	transactions transaction.UnitOfWork
+}
+
+type RemoveMemberInput struct {
+	organization.RemoveMemberInput
+	Actor *organization.Member
+}
+
+func NewRemoveMemberUseCase(members organization.MemberRepository, roles role.RoleRepository, transactions transaction.UnitOfWork) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{
+		members:      members,
+		roles:        roles,
+		transactions: transactions,
+	}
+}
+
+func (uc *RemoveMemberUseCase) Execute(ctx context.Context, input RemoveMemberInput) error {
+	if err := input.RemoveMemberInput.Validate(); err != nil {
+		return err
+	}
+
+	return uc.transactions.WithTx(ctx, func(ctx context.Context) error {
+		member, err := uc.members.Get(ctx, &organization.GetMemberInput{
+			OrganizationID: input.OrganizationID,
+			UserID:         input.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := checkGrantable(ctx, uc.roles, input.Actor, member.Role); err != nil {
+			return err
+		}
+		if err := checkKeepsOrgAdmin(ctx, uc.members, member); err != nil {
+			return err
+		}
+
+		return uc.members.Remove(ctx, &input.RemoveMemberInput)
+	})
+}
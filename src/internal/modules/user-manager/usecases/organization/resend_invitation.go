@@ -0,0 +1,68 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"context"
+	"time"
+)
+
+type ResendInvitationUseCase struct {
+	orgs        organization.OrganizationRepository
+	invitations organization.InvitationRepository
+	roles       role.RoleRepository
+	signer      organization.InvitationSigner
+	email       email.EmailService
+	options     Options
+}
+
+type ResendInvitationInput struct {
+	OrganizationID string
+	InvitationID   string
+	Actor          *organization.Member
+}
+
+func NewResendInvitationUseCase(orgs organization.OrganizationRepository, invitations organization.InvitationRepository, roles role.RoleRepository, signer organization.InvitationSigner, email email.EmailService, options Options) *ResendInvitationUseCase {
+	return &ResendInvitationUseCase{
+		orgs:        orgs,
+		invitations: invitations,
+		roles:       roles,
+		signer:      signer,
+		email:       email,
+		options:     options,
+	}
+}
+
+// Execute sends a new link valid for a full TTL, the links sent before stop working.
+func (uc *ResendInvitationUseCase) Execute(ctx context.Context, input ResendInvitationInput) (*organization.Invitation, error) {
+	invitation, err := getOrgInvitation(ctx, uc.invitations, input.OrganizationID, input.InvitationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(ctx, uc.roles, input.Actor, invitation.Role); err != nil {
+		return nil, err
+	}
+
+	if err := uc.invitations.Renew(ctx, &organization.RenewInvitationInput{
+		ID:        invitation.ID,
+		ExpiresAt: time.Now().Add(uc.options.InvitationTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	invitation, err = uc.invitations.GetByID(ctx, &organization.GetInvitationInput{ID: invitation.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgs.GetByID(ctx, &organization.GetOrganizationInput{ID: invitation.OrganizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sendInvitation(ctx, uc.signer, uc.email, uc.options, org, invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
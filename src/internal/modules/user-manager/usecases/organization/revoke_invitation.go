@@ -0,0 +1,40 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type RevokeInvitationUseCase struct {
+	invitations organization.InvitationRepository
+	roles       role.RoleRepository
+}
+
+type RevokeInvitationInput struct {
+	OrganizationID string
+	InvitationID   string
+	Actor          *organization.Member
+}
+
+func NewRevokeInvitationUseCase(invitations organization.InvitationRepository, roles role.RoleRepository) *RevokeInvitationUseCase {
+	return &RevokeInvitationUseCase{
+		invitations: invitations,
+		roles:       roles,
+	}
+}
+
+func (uc *RevokeInvitationUseCase) Execute(ctx context.Context, input RevokeInvitationInput) error {
+	invitation, err := getOrgInvitation(ctx, uc.invitations, input.OrganizationID, input.InvitationID)
+	if err != nil {
+		return err
+	}
+	if err := checkGrantable(ctx, uc.roles, input.Actor, invitation.Role); err != nil {
+		return err
+	}
+
+	return uc.invitations.SetStatus(ctx, &organization.SetInvitationStatusInput{
+		ID:     invitation.ID,
+		Status: organization.InvitationRevoked,
+	})
+}
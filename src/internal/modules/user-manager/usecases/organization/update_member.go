@@ -0,0 +1,72 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"context"
+)
+
+type UpdateMemberUseCase struct {
+	members      organization.MemberRepository
+	roles        role.RoleRepository
+	transactions transaction.UnitOfWork
+}
+
+type UpdateMemberInput struct {
+	organization.UpdateMemberInput
+	Actor *organization.Member
+}
+
+func NewUpdateMemberUseCase(members organization.MemberRepository, roles role.RoleRepository, transactions transaction.UnitOfWork) *UpdateMemberUseCase {
+	return &UpdateMemberUseCase{
+		members:      members,
+		roles:        roles,
+		transactions: transactions,
+	}
+}
+
+func (uc *UpdateMemberUseCase) Execute(ctx context.Context, input UpdateMemberInput) (*organization.Member, error) {
+	if err := input.UpdateMemberInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *organization.Member
+	err := uc.transactions.WithTx(ctx, func(ctx context.Context) error {
+		member, err := uc.members.Get(ctx, &organization.GetMemberInput{
+			OrganizationID: input.OrganizationID,
+			UserID:         input.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		if member.Role == input.Role {
+			updated = member
+			return nil
+		}
+
+		if err := checkGrantable(ctx, uc.roles, input.Actor, member.Role); err != nil {
+			return err
+		}
+		if err := checkGrantable(ctx, uc.roles, input.Actor, input.Role); err != nil {
+			return err
+		}
+		if err := checkKeepsOrgAdmin(ctx, uc.members, member); err != nil {
+			return err
+		}
+
+		if err := uc.members.UpdateRole(ctx, &input.UpdateMemberInput); err != nil {
+			return err
+		}
+
+		updated, err = uc.members.Get(ctx, &organization.GetMemberInput{
+			OrganizationID: input.OrganizationID,
+			UserID:         input.UserID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
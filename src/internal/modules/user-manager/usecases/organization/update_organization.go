@@ -0,0 +1,32 @@
+package organization
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"context"
+)
+
+type UpdateOrganizationUseCase struct {
+	orgs organization.OrganizationRepository
+}
+
+type UpdateOrganizationInput struct {
+	organization.UpdateOrganizationInput
+}
+
+func NewUpdateOrganizationUseCase(orgs organization.OrganizationRepository) *UpdateOrganizationUseCase {
+	return &UpdateOrganizationUseCase{
+		orgs: orgs,
+	}
+}
+
+func (uc *UpdateOrganizationUseCase) Execute(ctx context.Context, input UpdateOrganizationInput) (*organization.Organization, error) {
+	if err := input.UpdateOrganizationInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.orgs.Update(ctx, &input.UpdateOrganizationInput); err != nil {
+		return nil, err
+	}
+
+	return uc.orgs.GetByID(ctx, &organization.GetOrganizationInput{ID: input.ID})
+}
@@ -0,0 +1,76 @@
+package reconcile
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/app_error"
+	"auth-api/src/pkg/logger"
+	"time"
+)
+
+// ErrReconciliationRunning is returned while another instance runs the reconciliation.
+var ErrReconciliationRunning = app_error.NewApiError(409, "A reconciliation is already running")
+
+type IssueKind string
+
+const (
+	// MissingUserProfile is a user of the User group without the User role, repaired by granting it from the auth provider.
+	MissingUserProfile IssueKind = "missing_user_profile"
+	// MissingAdminProfile is a user of the Admin group without the Admin role, repaired by granting it.
+	MissingAdminProfile IssueKind = "missing_admin_profile"
+	// OrphanAuthUser is an auth provider user with no profile and no built-in group, only reported.
+	OrphanAuthUser IssueKind = "orphan_auth_user"
+	// EmailMismatch is a profile whose email differs from the auth provider, which the profile is aligned with.
+	EmailMismatch IssueKind = "email_mismatch"
+	// NameMismatch is an auth provider name differing from the profile, which the auth provider is aligned with.
+	NameMismatch IssueKind = "name_mismatch"
+	// MissingUserGroup is a user with the User role outside the User group, repaired by adding the group.
+	MissingUserGroup IssueKind = "missing_user_group"
+	// StaleAdminProfile is an Admin role of a user outside the Admin group, only reported: admin rights are never granted.
+	StaleAdminProfile IssueKind = "stale_admin_profile"
+	// OrphanUserProfile and OrphanAdminProfile are profiles without an auth provider user, repaired by deleting them.
+	OrphanUserProfile  IssueKind = "orphan_user_profile"
+	OrphanAdminProfile IssueKind = "orphan_admin_profile"
+	// IDMismatch is a profile whose email belongs to another auth provider user, only reported.
+	IDMismatch IssueKind = "id_mismatch"
+)
+
+type Issue struct {
+	Kind   IssueKind `json:"kind"`
+	ID     string    `json:"id"`
+	Email  string    `json:"email"`
+	Detail string    `json:"detail,omitempty"`
+	// Repaired is set once the issue is fixed, Error when the repair failed.
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Report struct {
+	DryRun        bool      `json:"dryRun"`
+	StartedAt     time.Time `json:"startedAt"`
+	FinishedAt    time.Time `json:"finishedAt"`
+	AuthUsers     int       `json:"authUsers"`
+	UserProfiles  int       `json:"userProfiles"`
+	AdminProfiles int       `json:"adminProfiles"`
+	Skipped       int       `json:"skipped"`
+	Repaired      int       `json:"repaired"`
+	Failed        int       `json:"failed"`
+	Issues        []*Issue  `json:"issues"`
+}
+
+type Options struct {
+	// GracePeriod leaves alone the auth provider users created since, whose registration may still be running.
+	GracePeriod time.Duration
+}
+
+type UseCases struct {
+	Reconcile *ReconcileUseCase
+}
+
+func NewUseCases(authService auth.AuthService, userService user.UserService, adminService admin.AdminService, locker transaction.Locker, options Options, logger logger.Logger) *UseCases {
+	return &UseCases{
+		Reconcile: NewReconcileUseCase(authService, userService, adminService, locker, options.GracePeriod, logger),
+	}
+}
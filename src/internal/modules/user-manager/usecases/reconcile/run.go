@@ -0,0 +1,342 @@
+package reconcile
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// reconciliationLock keeps the instances from reconciling at the same time.
+const reconciliationLock = "reconciliation"
+
+type ReconcileUseCase struct {
+	authService  auth.AuthService
+	userService  user.UserService
+	adminService admin.AdminService
+	locker       transaction.Locker
+	gracePeriod  time.Duration
+	logger       logger.Logger
+}
+
+type ReconcileInput struct {
+	// DryRun only reports the issues.
+	DryRun bool
+}
+
+func NewReconcileUseCase(authService auth.AuthService, userService user.UserService, adminService admin.AdminService, locker transaction.Locker, gracePeriod time.Duration, logger logger.Logger) *ReconcileUseCase {
+	return &ReconcileUseCase{
+		authService:  authService,
+		userService:  userService,
+		adminService: adminService,
+		locker:       locker,
+		gracePeriod:  gracePeriod,
+		logger:       logger,
+	}
+}
+
+// Execute pages through the auth provider users, checking their profiles and groups, then through the profiles
+// to find the ones left without an auth provider user. Without DryRun each issue is repaired as it is found,
+// a failed repair is reported and does not stop the run. It fails with ErrReconciliationRunning while another
+// instance reconciles.
+func (uc *ReconcileUseCase) Execute(ctx context.Context, input ReconcileInput) (*Report, error) {
+	var report *Report
+	locked, err := uc.locker.TryWithLock(ctx, reconciliationLock, func(ctx context.Context) error {
+		var err error
+		report, err = uc.run(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, ErrReconciliationRunning
+	}
+	return report, nil
+}
+
+func (uc *ReconcileUseCase) run(ctx context.Context, input ReconcileInput) (*Report, error) {
+	report := &Report{DryRun: input.DryRun, StartedAt: time.Now(), Issues: []*Issue{}}
+
+	groups, err := uc.groupMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the IDs are kept between the two passes.
+	authIDs := make(map[string]struct{})
+	if err := uc.checkAuthUsers(ctx, report, groups, authIDs); err != nil {
+		return nil, err
+	}
+	if err := uc.checkUserProfiles(ctx, report, authIDs); err != nil {
+		return nil, err
+	}
+	if err := uc.checkAdminProfiles(ctx, report, authIDs); err != nil {
+		return nil, err
+	}
+
+	report.FinishedAt = time.Now()
+	uc.logger.Info("Reconciliation: %d auth users (%d skipped), %d user profiles, %d admin profiles, %d issues, %d repaired, %d failed (dry run: %t)",
+		report.AuthUsers, report.Skipped, report.UserProfiles, report.AdminProfiles, len(report.Issues), report.Repaired, report.Failed, report.DryRun)
+	return report, nil
+}
+
+// report records an issue and runs its repair, a nil repair means the issue is only reported.
+func (uc *ReconcileUseCase) report(report *Report, issue *Issue, repair func() error) {
+	report.Issues = append(report.Issues, issue)
+	uc.logger.Warning("Reconciliation issue %s for %s (%s): %s", issue.Kind, issue.Email, issue.ID, issue.Detail)
+	if report.DryRun || repair == nil {
+		return
+	}
+
+	if err := repair(); err != nil {
+		uc.logger.Error("Error repairing %s for %s: %v", issue.Kind, issue.Email, err)
+		issue.Error = err.Error()
+		report.Failed++
+		return
+	}
+	issue.Repaired = true
+	report.Repaired++
+}
+
+// groupMembers pages the members of the built-in groups once, by auth provider user ID.
+func (uc *ReconcileUseCase) groupMembers(ctx context.Context) (map[string][]auth.UserGroup, error) {
+	groups := map[string][]auth.UserGroup{}
+	for _, group := range []auth.UserGroup{auth.GroupAdmin, auth.GroupUser} {
+		input := auth.ListUsersInput{Group: group}
+		for {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			page, err := uc.authService.ListUsers(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			for _, authUser := range page.Users {
+				groups[authUser.Id] = append(groups[authUser.Id], group)
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+			input.Cursor = page.NextCursor
+		}
+	}
+	return groups, nil
+}
+
+func (uc *ReconcileUseCase) checkAuthUsers(ctx context.Context, report *Report, groups map[string][]auth.UserGroup, authIDs map[string]struct{}) error {
+	createdBefore := time.Now().Add(-uc.gracePeriod)
+	input := auth.ListUsersInput{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := uc.authService.ListUsers(ctx, input)
+		if err != nil {
+			return err
+		}
+		for _, authUser := range page.Users {
+			report.AuthUsers++
+			authIDs[authUser.Id] = struct{}{}
+			if authUser.CreatedAt.After(createdBefore) {
+				report.Skipped++
+				continue
+			}
+			if err := uc.checkAuthUser(ctx, report, authUser, groups[authUser.Id]); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		input.Cursor = page.NextCursor
+	}
+}
+
+func (uc *ReconcileUseCase) checkAuthUser(ctx context.Context, report *Report, authUser *auth.User, groups []auth.UserGroup) error {
+	var inUserGroup, inAdminGroup bool
+	for _, group := range groups {
+		inUserGroup = inUserGroup || group == auth.GroupUser
+		inAdminGroup = inAdminGroup || group == auth.GroupAdmin
+	}
+
+	profile, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: authUser.Id})
+	if err != nil && err != user.ErrUserNotFound {
+		return err
+	}
+	adminProfile, err := uc.adminService.GetByID(ctx, &admin.GetAdminInput{ID: authUser.Id})
+	if err != nil && err != admin.ErrAdminNotFound {
+		return err
+	}
+
+	newIssue := func(kind IssueKind, detail string) *Issue {
+		return &Issue{Kind: kind, ID: authUser.Id, Email: authUser.Email, Detail: detail}
+	}
+
+	if profile == nil && inUserGroup {
+		uc.report(report, newIssue(MissingUserProfile, "in the User group without the User role"), func() error {
+			userID, err := user.ParseUserID(authUser.Id)
+			if err != nil {
+				return err
+			}
+			_, err = uc.userService.Create(ctx, &user.CreateUserInput{ID: userID, Name: authUser.Name, Email: authUser.Email, Admin: true})
+			return err
+		})
+	}
+	if adminProfile == nil && inAdminGroup {
+		uc.report(report, newIssue(MissingAdminProfile, "in the Admin group without the Admin role"), func() error {
+			adminID, err := admin.ParseAdminID(authUser.Id)
+			if err != nil {
+				return err
+			}
+			_, err = uc.adminService.Create(ctx, &admin.CreateAdminInput{ID: adminID, Name: authUser.Name, Email: authUser.Email})
+			return err
+		})
+	}
+	if profile == nil && adminProfile == nil && !inUserGroup && !inAdminGroup {
+		uc.report(report, newIssue(OrphanAuthUser, "no profile and no built-in group"), nil)
+	}
+
+	if profile != nil {
+		if profile.Email != authUser.Email {
+			uc.report(report, newIssue(EmailMismatch, fmt.Sprintf("profile has %s", profile.Email)), func() error {
+				_, err := uc.userService.Update(ctx, &user.UpdateUserInput{ID: profile.ID, Email: &authUser.Email, Admin: true})
+				return err
+			})
+		}
+		if !inUserGroup {
+			uc.report(report, newIssue(MissingUserGroup, "User role outside the User group"), func() error {
+				return uc.authService.AddGroup(ctx, auth.AddGroupInput{Username: authUser.Email, GroupName: auth.GroupUser})
+			})
+		}
+	}
+	if adminProfile != nil {
+		// A user and admin share their profile, its email is only checked once.
+		if profile == nil && adminProfile.Email != authUser.Email {
+			uc.report(report, newIssue(EmailMismatch, fmt.Sprintf("profile has %s", adminProfile.Email)), func() error {
+				_, err := uc.adminService.Update(ctx, &admin.UpdateAdminInput{ID: adminProfile.ID, Email: &authUser.Email})
+				return err
+			})
+		}
+		if !inAdminGroup {
+			uc.report(report, newIssue(StaleAdminProfile, "Admin role outside the Admin group"), nil)
+		}
+	}
+
+	// A user and admin share their profile, and so its name.
+	name := ""
+	if profile != nil {
+		name = profile.Name
+	} else if adminProfile != nil {
+		name = adminProfile.Name
+	}
+	if name != "" && name != authUser.Name {
+		uc.report(report, newIssue(NameMismatch, fmt.Sprintf("auth provider has %q, profile has %q", authUser.Name, name)), func() error {
+			return uc.authService.UpdateName(ctx, auth.UpdateNameInput{Username: authUser.Email, Name: name})
+		})
+	}
+	return nil
+}
+
+func (uc *ReconcileUseCase) checkUserProfiles(ctx context.Context, report *Report, authIDs map[string]struct{}) error {
+	input := user.ListUsersInput{Limit: user.MaxListLimit}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := uc.userService.List(ctx, &input)
+		if err != nil {
+			return err
+		}
+		for _, profile := range page.Users {
+			report.UserProfiles++
+			if _, ok := authIDs[profile.ID.String()]; ok {
+				continue
+			}
+			issue, err := uc.checkOrphan(ctx, OrphanUserProfile, profile.ID.String(), profile.Email)
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				continue
+			}
+			var repair func() error
+			if issue.Kind == OrphanUserProfile {
+				repair = func() error {
+					_, err := uc.userService.Delete(ctx, &user.DeleteUserInput{ID: profile.ID})
+					return err
+				}
+			}
+			uc.report(report, issue, repair)
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		input.Cursor = page.NextCursor
+	}
+}
+
+func (uc *ReconcileUseCase) checkAdminProfiles(ctx context.Context, report *Report, authIDs map[string]struct{}) error {
+	input := admin.ListAdminsInput{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := uc.adminService.List(ctx, &input)
+		if err != nil {
+			return err
+		}
+		for _, adminProfile := range page.Admins {
+			report.AdminProfiles++
+			if _, ok := authIDs[adminProfile.ID.String()]; ok {
+				continue
+			}
+			issue, err := uc.checkOrphan(ctx, OrphanAdminProfile, adminProfile.ID.String(), adminProfile.Email)
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				continue
+			}
+			var repair func() error
+			if issue.Kind == OrphanAdminProfile {
+				repair = func() error {
+					_, err := uc.adminService.Delete(ctx, &admin.DeleteAdminInput{ID: adminProfile.ID})
+					return err
+				}
+			}
+			uc.report(report, issue, repair)
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		input.Cursor = page.NextCursor
+	}
+}
+
+// checkOrphan looks a profile missed by the first pass up by email, so users signed up during the run are not deleted.
+func (uc *ReconcileUseCase) checkOrphan(ctx context.Context, kind IssueKind, id, email string) (*Issue, error) {
+	authUser, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: email})
+	if err == auth.ErrUserNotFound {
+		return &Issue{Kind: kind, ID: id, Email: email, Detail: "no auth provider user"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUser.Id == id {
+		return nil, nil
+	}
+	return &Issue{Kind: IDMismatch, ID: id, Email: email, Detail: fmt.Sprintf("the email belongs to auth provider user %s", authUser.Id)}, nil
+}
@@ -0,0 +1,65 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+type AssignRoleUseCase struct {
+	roles    role.RoleRepository
+	auth     auth.AuthService
+	denylist denylist.DenylistService
+	logger   logger.Logger
+}
+
+type AssignRoleInput struct {
+	role.AssignRoleInput
+}
+
+func NewAssignRoleUseCase(roles role.RoleRepository, auth auth.AuthService, denylist denylist.DenylistService, logger logger.Logger) *AssignRoleUseCase {
+	return &AssignRoleUseCase{
+		roles:    roles,
+		auth:     auth,
+		denylist: denylist,
+		logger:   logger,
+	}
+}
+
+func (uc *AssignRoleUseCase) Execute(ctx context.Context, input AssignRoleInput) error {
+	if err := input.AssignRoleInput.Validate(); err != nil {
+		return err
+	}
+	if role.IsBuiltin(input.Name) {
+		return role.ErrBuiltinRole
+	}
+
+	found, err := uc.roles.GetByName(ctx, &role.GetRoleInput{Name: input.Name})
+	if err != nil {
+		return err
+	}
+
+	// The group may be missing if the role was created while another provider was configured.
+	if err := uc.auth.CreateGroup(ctx, auth.CreateGroupInput{
+		GroupName:   auth.UserGroup(found.Name),
+		Description: found.Description,
+	}); err != nil {
+		return err
+	}
+
+	if err := uc.auth.AddGroup(ctx, auth.AddGroupInput{
+		Username:  input.Username,
+		GroupName: auth.UserGroup(found.Name),
+	}); err != nil {
+		return err
+	}
+
+	// Force a new sign in so that the groups claim of the user's tokens includes the role.
+	if err := auth_usecases.AdminLogout(ctx, uc.auth, uc.denylist, input.Username); err != nil {
+		uc.logger.Error("Failed to admin logout: %v", err)
+	}
+	return nil
+}
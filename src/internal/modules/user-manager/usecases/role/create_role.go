@@ -0,0 +1,52 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+type CreateRoleUseCase struct {
+	roles       role.RoleRepository
+	roleService role.RoleService
+	auth        auth.AuthService
+	logger      logger.Logger
+}
+
+type CreateRoleInput struct {
+	role.CreateRoleInput
+}
+
+func NewCreateRoleUseCase(roles role.RoleRepository, roleService role.RoleService, auth auth.AuthService, logger logger.Logger) *CreateRoleUseCase {
+	return &CreateRoleUseCase{
+		roles:       roles,
+		roleService: roleService,
+		auth:        auth,
+		logger:      logger,
+	}
+}
+
+func (uc *CreateRoleUseCase) Execute(ctx context.Context, input CreateRoleInput) (*role.Role, error) {
+	if err := input.CreateRoleInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roles.Create(ctx, &input.CreateRoleInput); err != nil {
+		return nil, err
+	}
+
+	// Roles are assigned through the auth provider groups, so the group has to exist before anyone gets the role.
+	if err := uc.auth.CreateGroup(ctx, auth.CreateGroupInput{
+		GroupName:   auth.UserGroup(input.Name),
+		Description: input.Description,
+	}); err != nil {
+		if err := uc.roles.Delete(ctx, &role.DeleteRoleInput{Name: input.Name}); err != nil {
+			uc.logger.Error("Error rolling back role creation: %v", err)
+		}
+		return nil, err
+	}
+	uc.roleService.Invalidate()
+
+	return uc.roles.GetByName(ctx, &role.GetRoleInput{Name: input.Name})
+}
@@ -0,0 +1,45 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type DeleteRoleUseCase struct {
+	roles       role.RoleRepository
+	roleService role.RoleService
+	auth        auth.AuthService
+}
+
+type DeleteRoleInput struct {
+	role.DeleteRoleInput
+}
+
+func NewDeleteRoleUseCase(roles role.RoleRepository, roleService role.RoleService, auth auth.AuthService) *DeleteRoleUseCase {
+	return &DeleteRoleUseCase{
+		roles:       roles,
+		roleService: roleService,
+		auth:        auth,
+	}
+}
+
+// Execute removes the role and its provider group. Tokens still carrying the group lose its permissions right away on
+// this instance, other instances keep their cached roles for up to the role cache TTL.
+func (uc *DeleteRoleUseCase) Execute(ctx context.Context, input DeleteRoleInput) error {
+	if err := input.DeleteRoleInput.Validate(); err != nil {
+		return err
+	}
+	if role.IsBuiltin(input.Name) {
+		return role.ErrBuiltinRole
+	}
+
+	if err := uc.roles.Delete(ctx, &input.DeleteRoleInput); err != nil {
+		return err
+	}
+	uc.roleService.Invalidate()
+
+	return uc.auth.DeleteGroup(ctx, auth.DeleteGroupInput{
+		GroupName: auth.UserGroup(input.Name),
+	})
+}
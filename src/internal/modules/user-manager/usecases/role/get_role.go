@@ -0,0 +1,28 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type GetRoleUseCase struct {
+	roles role.RoleRepository
+}
+
+type GetRoleInput struct {
+	role.GetRoleInput
+}
+
+func NewGetRoleUseCase(roles role.RoleRepository) *GetRoleUseCase {
+	return &GetRoleUseCase{
+		roles: roles,
+	}
+}
+
+func (uc *GetRoleUseCase) Execute(ctx context.Context, input GetRoleInput) (*role.Role, error) {
+	if err := input.GetRoleInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.roles.GetByName(ctx, &input.GetRoleInput)
+}
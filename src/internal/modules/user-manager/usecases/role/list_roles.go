@@ -0,0 +1,20 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type ListRolesUseCase struct {
+	roles role.RoleRepository
+}
+
+func NewListRolesUseCase(roles role.RoleRepository) *ListRolesUseCase {
+	return &ListRolesUseCase{
+		roles: roles,
+	}
+}
+
+func (uc *ListRolesUseCase) Execute(ctx context.Context) ([]role.Role, error) {
+	return uc.roles.List(ctx)
+}
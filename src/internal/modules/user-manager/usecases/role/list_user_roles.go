@@ -0,0 +1,39 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type ListUserRolesUseCase struct {
+	roles role.RoleRepository
+	auth  auth.AuthService
+}
+
+type ListUserRolesInput struct {
+	role.ListUserRolesInput
+}
+
+func NewListUserRolesUseCase(roles role.RoleRepository, auth auth.AuthService) *ListUserRolesUseCase {
+	return &ListUserRolesUseCase{
+		roles: roles,
+		auth:  auth,
+	}
+}
+
+// Execute returns the roles matching the user's provider groups, groups without a role are left out.
+func (uc *ListUserRolesUseCase) Execute(ctx context.Context, input ListUserRolesInput) ([]role.Role, error) {
+	if err := input.ListUserRolesInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	groups, err := uc.auth.ListUserGroups(ctx, auth.ListUserGroupsInput{
+		Username: input.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.roles.ListByNames(ctx, groups)
+}
@@ -0,0 +1,32 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+)
+
+type UseCases struct {
+	CreateRole    *CreateRoleUseCase
+	GetRole       *GetRoleUseCase
+	ListRoles     *ListRolesUseCase
+	UpdateRole    *UpdateRoleUseCase
+	DeleteRole    *DeleteRoleUseCase
+	AssignRole    *AssignRoleUseCase
+	UnassignRole  *UnassignRoleUseCase
+	ListUserRoles *ListUserRolesUseCase
+}
+
+func NewUseCases(roles role.RoleRepository, roleService role.RoleService, authService auth.AuthService, denylistService denylist.DenylistService, logger logger.Logger) *UseCases {
+	return &UseCases{
+		CreateRole:    NewCreateRoleUseCase(roles, roleService, authService, logger),
+		GetRole:       NewGetRoleUseCase(roles),
+		ListRoles:     NewListRolesUseCase(roles),
+		UpdateRole:    NewUpdateRoleUseCase(roles, roleService),
+		DeleteRole:    NewDeleteRoleUseCase(roles, roleService, authService),
+		AssignRole:    NewAssignRoleUseCase(roles, authService, denylistService, logger),
+		UnassignRole:  NewUnassignRoleUseCase(authService, denylistService, logger),
+		ListUserRoles: NewListUserRolesUseCase(roles, authService),
+	}
+}
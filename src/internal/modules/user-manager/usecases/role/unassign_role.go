@@ -0,0 +1,50 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+type UnassignRoleUseCase struct {
+	auth     auth.AuthService
+	denylist denylist.DenylistService
+	logger   logger.Logger
+}
+
+type UnassignRoleInput struct {
+	role.UnassignRoleInput
+}
+
+func NewUnassignRoleUseCase(auth auth.AuthService, denylist denylist.DenylistService, logger logger.Logger) *UnassignRoleUseCase {
+	return &UnassignRoleUseCase{
+		auth:     auth,
+		denylist: denylist,
+		logger:   logger,
+	}
+}
+
+func (uc *UnassignRoleUseCase) Execute(ctx context.Context, input UnassignRoleInput) error {
+	if err := input.UnassignRoleInput.Validate(); err != nil {
+		return err
+	}
+	if role.IsBuiltin(input.Name) {
+		return role.ErrBuiltinRole
+	}
+
+	if err := uc.auth.RemoveGroup(ctx, auth.RemoveGroupInput{
+		Username:  input.Username,
+		GroupName: auth.UserGroup(input.Name),
+	}); err != nil {
+		return err
+	}
+
+	// Tokens issued before still carry the group, revoke them.
+	if err := auth_usecases.AdminLogout(ctx, uc.auth, uc.denylist, input.Username); err != nil {
+		uc.logger.Error("Failed to admin logout: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package role
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"context"
+)
+
+type UpdateRoleUseCase struct {
+	roles       role.RoleRepository
+	roleService role.RoleService
+}
+
+type UpdateRoleInput struct {
+	role.UpdateRoleInput
+}
+
+func NewUpdateRoleUseCase(roles role.RoleRepository, roleService role.RoleService) *UpdateRoleUseCase {
+	return &UpdateRoleUseCase{
+		roles:       roles,
+		roleService: roleService,
+	}
+}
+
+func (uc *UpdateRoleUseCase) Execute(ctx context.Context, input UpdateRoleInput) (*role.Role, error) {
+	if err := input.UpdateRoleInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roles.Update(ctx, &input.UpdateRoleInput); err != nil {
+		return nil, err
+	}
+	uc.roleService.Invalidate()
+
+	return uc.roles.GetByName(ctx, &role.GetRoleInput{Name: input.Name})
+}
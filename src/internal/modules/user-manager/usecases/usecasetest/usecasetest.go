@@ -0,0 +1,435 @@
+// Package usecasetest wires the user manager use cases to the fake Cognito user pool and in-memory services,
+// for the tests of the use cases and of the routes calling them.
+package usecasetest
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	auth_infra "auth-api/src/internal/modules/user-manager/infra/auth"
+	"auth-api/src/internal/modules/user-manager/infra/auth/cognito_fake"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/jwt_verify"
+	"auth-api/src/pkg/logger"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	Region     = "us-east-1"
+	UserPoolID = "us-east-1_test"
+	ClientID   = "test-client"
+	Password   = "Password123!"
+)
+
+func NewLogger(t testing.TB) logger.Logger {
+	t.Helper()
+	log, err := logger.NewLogger("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log
+}
+
+// NewAuthService returns the Cognito auth service running on a fresh fake user pool, the pool is returned to
+// inspect the users it holds.
+func NewAuthService(t testing.TB, log logger.Logger) (auth.AuthService, *cognito_fake.FakeCognito) {
+	t.Helper()
+	fake, err := cognito_fake.New(Region, UserPoolID, ClientID, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwtVerify := jwt_verify.NewAuthWithJWK(fake.JWK(), jwt_verify.ValidationRules{
+		Issuer:    fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", Region, UserPoolID),
+		ClientIDs: []string{ClientID},
+		TokenUse:  "access",
+	}, log)
+	return auth_infra.NewAuthService(fake, ClientID, jwtVerify, UserPoolID, log, nil, nil), fake
+}
+
+// SignUp signs a user up and confirms it, the user can log in with Password.
+func SignUp(t testing.TB, authService auth.AuthService, email, name string) *auth.SignUpOutput {
+	t.Helper()
+	ctx := context.Background()
+	out, err := authService.SignUp(ctx, auth.SignUpInput{Username: email, Password: Password, Name: name})
+	if err != nil {
+		t.Fatalf("SignUp %s: %v", email, err)
+	}
+	if _, err := authService.ConfirmSignUp(ctx, auth.ConfirmSignUpInput{Username: email}); err != nil {
+		t.Fatalf("ConfirmSignUp %s: %v", email, err)
+	}
+	return out
+}
+
+// Admins keeps the admin profiles in memory, the Err fields make the matching method fail.
+type Admins struct {
+	mu        sync.Mutex
+	admins    map[admin.AdminID]*admin.Admin
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+}
+
+func NewAdmins() *Admins {
+	return &Admins{admins: make(map[admin.AdminID]*admin.Admin)}
+}
+
+func (s *Admins) GetByID(ctx context.Context, input *admin.GetAdminInput) (*admin.Admin, error) {
+	id, err := admin.ParseAdminID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found, ok := s.admins[id]
+	if !ok {
+		return nil, admin.ErrAdminNotFound
+	}
+	copied := *found
+	return &copied, nil
+}
+
+func (s *Admins) GetByEmail(ctx context.Context, input *admin.GetAdminByEmailInput) (*admin.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, found := range s.admins {
+		if strings.EqualFold(found.Email, input.Email) {
+			copied := *found
+			return &copied, nil
+		}
+	}
+	return nil, admin.ErrAdminNotFound
+}
+
+func (s *Admins) List(ctx context.Context, input *admin.ListAdminsInput) (*admin.AdminPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page := &admin.AdminPage{Admins: []*admin.Admin{}}
+	for _, found := range s.admins {
+		copied := *found
+		page.Admins = append(page.Admins, &copied)
+	}
+	return page, nil
+}
+
+func (s *Admins) Create(ctx context.Context, input *admin.CreateAdminInput) (*admin.CreateAdminOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CreateErr != nil {
+		return nil, s.CreateErr
+	}
+	for _, found := range s.admins {
+		if found.ID == input.ID || strings.EqualFold(found.Email, input.Email) {
+			return nil, admin.ErrAdminAlreadyExists
+		}
+	}
+	s.admins[input.ID] = &admin.Admin{ID: input.ID, Name: input.Name, Email: input.Email}
+	id := input.ID
+	return admin.NewCreateAdminOutput(&id, s), nil
+}
+
+func (s *Admins) Update(ctx context.Context, input *admin.UpdateAdminInput) (*admin.UpdateAdminOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.UpdateErr != nil {
+		return nil, s.UpdateErr
+	}
+	found, ok := s.admins[input.ID]
+	if !ok {
+		return nil, admin.ErrAdminNotFound
+	}
+	backup := *found
+	if input.Name != nil {
+		found.Name = *input.Name
+	}
+	if input.Email != nil {
+		found.Email = *input.Email
+	}
+	return admin.NewUpdateAdminOutput(&backup, s), nil
+}
+
+func (s *Admins) Delete(ctx context.Context, input *admin.DeleteAdminInput) (*admin.DeleteAdminOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.DeleteErr != nil {
+		return nil, s.DeleteErr
+	}
+	found, ok := s.admins[input.ID]
+	if !ok {
+		return nil, admin.ErrAdminNotFound
+	}
+	delete(s.admins, input.ID)
+	return admin.NewDeleteAdminOutput(found, s), nil
+}
+
+// Users keeps the user profiles in memory, the Err fields make the matching method fail.
+type Users struct {
+	mu        sync.Mutex
+	users     map[user.UserID]*user.User
+	CreateErr error
+	UpdateErr error
+}
+
+func NewUsers() *Users {
+	return &Users{users: make(map[user.UserID]*user.User)}
+}
+
+func (s *Users) GetByID(ctx context.Context, input *user.GetUserInput) (*user.User, error) {
+	id, err := user.ParseUserID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found, ok := s.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	copied := *found
+	return &copied, nil
+}
+
+func (s *Users) List(ctx context.Context, input *user.ListUsersInput) (*user.UserPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page := &user.UserPage{Users: []*user.User{}}
+	for _, found := range s.users {
+		copied := *found
+		page.Users = append(page.Users, &copied)
+	}
+	return page, nil
+}
+
+func (s *Users) GetByEmail(ctx context.Context, input *user.GetUserByEmailInput) (*user.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, found := range s.users {
+		if strings.EqualFold(found.Email, input.Email) {
+			copied := *found
+			return &copied, nil
+		}
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (s *Users) Create(ctx context.Context, input *user.CreateUserInput) (*user.CreateUserOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CreateErr != nil {
+		return nil, s.CreateErr
+	}
+	for _, found := range s.users {
+		if found.ID == input.ID || strings.EqualFold(found.Email, input.Email) {
+			return nil, user.ErrUserAlreadyExists
+		}
+	}
+	s.users[input.ID] = &user.User{
+		ID:         input.ID,
+		Name:       input.Name,
+		Email:      input.Email,
+		Phone:      input.Phone,
+		Attributes: input.Attributes,
+		CreatedAt:  time.Now(),
+	}
+	id := input.ID
+	return user.NewCreateUserOutput(&id, s), nil
+}
+
+func (s *Users) Update(ctx context.Context, input *user.UpdateUserInput) (*user.UpdateUserOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.UpdateErr != nil {
+		return nil, s.UpdateErr
+	}
+	found, ok := s.users[input.ID]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	backup := *found
+	if input.Name != nil {
+		found.Name = *input.Name
+	}
+	if input.Email != nil {
+		found.Email = *input.Email
+	}
+	if input.Phone != nil {
+		found.Phone, found.PhoneVerified = input.Phone, false
+	}
+	return user.NewUpdateUserOutput(&backup, s), nil
+}
+
+func (s *Users) MarkPhoneVerified(ctx context.Context, input *user.MarkPhoneVerifiedInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if found, ok := s.users[input.ID]; ok && found.Phone != nil && *found.Phone == input.Phone {
+		found.PhoneVerified = true
+	}
+	return nil
+}
+
+func (s *Users) RecordLogin(ctx context.Context, input *user.RecordLoginInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, found := range s.users {
+		if strings.EqualFold(found.Email, input.Email) {
+			found.LastLoginAt = &now
+		}
+	}
+	return nil
+}
+
+func (s *Users) Delete(ctx context.Context, input *user.DeleteUserInput) (*user.DeleteUserOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found, ok := s.users[input.ID]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	delete(s.users, input.ID)
+	return user.NewDeleteUserOutput(found, s), nil
+}
+
+// Denylist records the denied tokens and subjects.
+type Denylist struct {
+	mu       sync.Mutex
+	tokens   map[string]struct{}
+	subjects map[string]struct{}
+}
+
+func NewDenylist() *Denylist {
+	return &Denylist{tokens: make(map[string]struct{}), subjects: make(map[string]struct{})}
+}
+
+func (d *Denylist) DenyToken(ctx context.Context, input denylist.DenyTokenInput) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tokens[input.Jti] = struct{}{}
+	return nil
+}
+
+func (d *Denylist) DenySubject(ctx context.Context, input denylist.DenySubjectInput) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subjects[input.Subject] = struct{}{}
+	return nil
+}
+
+func (d *Denylist) IsDenied(ctx context.Context, input denylist.IsDeniedInput) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, tokenDenied := d.tokens[input.Jti]
+	_, sessionDenied := d.tokens[input.OriginJti]
+	_, subjectDenied := d.subjects[input.Subject]
+	return tokenDenied || sessionDenied || subjectDenied, nil
+}
+
+// SubjectDenied tells whether every token of the subject was denied.
+func (d *Denylist) SubjectDenied(subject string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.subjects[subject]
+	return ok
+}
+
+// Roles keeps the roles in memory, it starts with the built-in roles of the initial migration.
+type Roles struct {
+	mu    sync.Mutex
+	roles map[string]role.Role
+}
+
+func NewRoles() *Roles {
+	builtins := []role.Role{
+		{Name: role.RoleAdmin, Permissions: []string{role.PermissionUsersRead, role.PermissionUsersWrite, role.PermissionAdminsWrite, role.PermissionGroupsWrite, role.PermissionMFAAdminRemove, role.PermissionClientsManage, role.PermissionRolesManage, role.PermissionSagasManage}},
+		{Name: role.RoleUser, Permissions: []string{}},
+		{Name: organization.RoleOrgAdmin, Permissions: []string{"org:write", "members:read", "members:write"}},
+		{Name: organization.RoleOrgMember, Permissions: []string{"members:read"}},
+	}
+	r := &Roles{roles: make(map[string]role.Role, len(builtins))}
+	for _, builtin := range builtins {
+		r.roles[builtin.Name] = builtin
+	}
+	return r
+}
+
+func (r *Roles) GetByName(ctx context.Context, input *role.GetRoleInput) (*role.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, ok := r.roles[input.Name]
+	if !ok {
+		return nil, role.ErrRoleNotFound
+	}
+	return &found, nil
+}
+
+func (r *Roles) List(ctx context.Context) ([]role.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	roles := make([]role.Role, 0, len(r.roles))
+	for _, found := range r.roles {
+		roles = append(roles, found)
+	}
+	return roles, nil
+}
+
+func (r *Roles) ListByNames(ctx context.Context, names []string) ([]role.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	roles := []role.Role{}
+	for _, name := range names {
+		if found, ok := r.roles[name]; ok {
+			roles = append(roles, found)
+		}
+	}
+	return roles, nil
+}
+
+func (r *Roles) Create(ctx context.Context, input *role.CreateRoleInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.roles[input.Name]; ok {
+		return role.ErrRoleAlreadyExists
+	}
+	now := time.Now()
+	r.roles[input.Name] = role.Role{Name: input.Name, Description: input.Description, Permissions: input.Permissions, CreatedAt: now, UpdatedAt: now}
+	return nil
+}
+
+func (r *Roles) Update(ctx context.Context, input *role.UpdateRoleInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, ok := r.roles[input.Name]
+	if !ok {
+		return role.ErrRoleNotFound
+	}
+	if input.Description != nil {
+		found.Description = *input.Description
+	}
+	if input.Permissions != nil {
+		found.Permissions = *input.Permissions
+	}
+	found.UpdatedAt = time.Now()
+	r.roles[input.Name] = found
+	return nil
+}
+
+func (r *Roles) Delete(ctx context.Context, input *role.DeleteRoleInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.roles[input.Name]; !ok {
+		return role.ErrRoleNotFound
+	}
+	delete(r.roles, input.Name)
+	return nil
+}
+
+var _ admin.AdminService = &Admins{}
+var _ user.UserService = &Users{}
+var _ denylist.DenylistService = &Denylist{}
+var _ role.RoleRepository = &Roles{}
@@ -0,0 +1,207 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"context"
+	"time"
+)
+
+const (
+	// dueDeletionsBatch is the number of deletions run by each ProcessAccountDeletions call.
+	dueDeletionsBatch = 50
+	// deletionLease keeps the other instances off a claimed deletion, a failed one is retried once it expires.
+	deletionLease = 10 * time.Minute
+)
+
+type DeleteAccountUseCase struct {
+	accounts    *accounts
+	deletions   user.DeletionRepository
+	gracePeriod time.Duration
+}
+
+type DeleteAccountInput struct {
+	user.DeleteAccountInput
+}
+
+func NewDeleteAccountUseCase(accounts *accounts, deletions user.DeletionRepository, gracePeriod time.Duration) *DeleteAccountUseCase {
+	return &DeleteAccountUseCase{
+		accounts:    accounts,
+		deletions:   deletions,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Execute deletes the account of the signed in user after checking their password and MFA code.
+// With a grace period the deletion is only scheduled and returned, it runs later unless cancelled.
+func (uc *DeleteAccountUseCase) Execute(ctx context.Context, input DeleteAccountInput) (*user.PendingDeletion, error) {
+	if err := input.DeleteAccountInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := reauthenticate(ctx, uc.accounts.authService, uc.accounts.logger, input.Username, input.Password, input.MFACode); err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.accounts.get(ctx, input.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.accounts.checkOrgAdmins(ctx, acc); err != nil {
+		return nil, err
+	}
+
+	if uc.gracePeriod <= 0 {
+		if err := uc.accounts.erase(ctx, acc); err != nil {
+			return nil, err
+		}
+		uc.accounts.dispatch(user.UserDeleted, acc, acc.id)
+		return nil, nil
+	}
+
+	now := time.Now()
+	deletion := &user.PendingDeletion{
+		UserID:       input.ID,
+		Email:        acc.email,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(uc.gracePeriod),
+	}
+	if err := uc.deletions.Create(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	uc.accounts.dispatch(user.UserDeletionRequested, acc, acc.id)
+	return deletion, nil
+}
+
+// reauthenticate signs in again with the password, and the MFA code when the account has MFA,
+// then drops the session it opened. Sensitive account changes call it first.
+func reauthenticate(ctx context.Context, authService auth.AuthService, logger logger.Logger, username, password, mfaCode string) error {
+	out, err := authService.Login(ctx, auth.LoginInput{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		if err == auth.ErrInvalidUsernameOrPassword {
+			return user.ErrReauthenticationFailed
+		}
+		return err
+	}
+
+	if out.AccessToken == nil {
+		if out.NextStep == nil || *out.NextStep != "SOFTWARE_TOKEN_MFA" || out.Session == nil {
+			return user.ErrReauthenticationFailed
+		}
+		if mfaCode == "" {
+			return user.ErrMFACodeRequired
+		}
+
+		out, err = authService.VerifyMFA(ctx, auth.VerifyMFAInput{
+			Code:     mfaCode,
+			Username: username,
+			Session:  *out.Session,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if out.RefreshToken != nil {
+		if err := authService.RevokeRefreshToken(ctx, auth.RevokeRefreshTokenInput{RefreshToken: *out.RefreshToken}); err != nil {
+			logger.Error("Error revoking re-authentication session: %v", err)
+		}
+	}
+	return nil
+}
+
+type GetAccountDeletionUseCase struct {
+	deletions user.DeletionRepository
+}
+
+func NewGetAccountDeletionUseCase(deletions user.DeletionRepository) *GetAccountDeletionUseCase {
+	return &GetAccountDeletionUseCase{
+		deletions: deletions,
+	}
+}
+
+func (uc *GetAccountDeletionUseCase) Execute(ctx context.Context, userID string) (*user.PendingDeletion, error) {
+	id, err := user.ParseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.deletions.Get(ctx, id)
+}
+
+type CancelAccountDeletionUseCase struct {
+	accounts  *accounts
+	deletions user.DeletionRepository
+}
+
+func NewCancelAccountDeletionUseCase(accounts *accounts, deletions user.DeletionRepository) *CancelAccountDeletionUseCase {
+	return &CancelAccountDeletionUseCase{
+		accounts:  accounts,
+		deletions: deletions,
+	}
+}
+
+func (uc *CancelAccountDeletionUseCase) Execute(ctx context.Context, userID string) error {
+	id, err := user.ParseUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	deletion, err := uc.deletions.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.deletions.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserDeletionCancelled, &account{id: userID, email: deletion.Email}, userID)
+	return nil
+}
+
+type ProcessAccountDeletionsUseCase struct {
+	accounts  *accounts
+	deletions user.DeletionRepository
+}
+
+func NewProcessAccountDeletionsUseCase(accounts *accounts, deletions user.DeletionRepository) *ProcessAccountDeletionsUseCase {
+	return &ProcessAccountDeletionsUseCase{
+		accounts:  accounts,
+		deletions: deletions,
+	}
+}
+
+// Execute runs the deletions whose grace period is over. The deletions are claimed first so that instances
+// sweeping together never run the same one. A failed deletion stays pending and is retried after its lease.
+func (uc *ProcessAccountDeletionsUseCase) Execute(ctx context.Context) error {
+	deletions, err := uc.deletions.ClaimDue(ctx, time.Now(), dueDeletionsBatch, deletionLease)
+	if err != nil {
+		return err
+	}
+
+	for _, deletion := range deletions {
+		acc, err := uc.accounts.get(ctx, deletion.UserID.String())
+		if err != nil && err != user.ErrUserNotFound {
+			uc.accounts.logger.Error("Error loading account %s for deletion: %v", deletion.UserID, err)
+			continue
+		}
+		if acc != nil {
+			if err := uc.accounts.erase(ctx, acc); err != nil {
+				uc.accounts.logger.Error("Error deleting account %s: %v", deletion.UserID, err)
+				continue
+			}
+			uc.accounts.dispatch(user.UserDeleted, acc, acc.id)
+		}
+
+		if err := uc.deletions.Delete(ctx, deletion.UserID); err != nil && err != user.ErrDeletionNotFound {
+			uc.accounts.logger.Error("Error clearing account deletion %s: %v", deletion.UserID, err)
+		}
+	}
+	return nil
+}
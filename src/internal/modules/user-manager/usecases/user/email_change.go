@@ -0,0 +1,302 @@
+package user
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"auth-api/src/pkg/logger"
+	"context"
+	"time"
+)
+
+type RequestEmailChangeUseCase struct {
+	userService user.UserService
+	authService auth.AuthService
+	changes     user.EmailChangeRepository
+	logger      logger.Logger
+}
+
+type RequestEmailChangeInput struct {
+	user.RequestEmailChangeInput
+}
+
+func NewRequestEmailChangeUseCase(userService user.UserService, authService auth.AuthService, changes user.EmailChangeRepository, logger logger.Logger) *RequestEmailChangeUseCase {
+	return &RequestEmailChangeUseCase{
+		userService: userService,
+		authService: authService,
+		changes:     changes,
+		logger:      logger,
+	}
+}
+
+// Execute re-authenticates the user, records the new email and sends a confirmation code to it, the current
+// email stays in use until confirmed.
+func (uc *RequestEmailChangeUseCase) Execute(ctx context.Context, input RequestEmailChangeInput) (*user.EmailChange, error) {
+	if err := input.RequestEmailChangeInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := reauthenticate(ctx, uc.authService, uc.logger, input.Username, input.Password, input.MFACode); err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: input.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	if profile.Email == input.NewEmail {
+		return nil, user.ErrEmailUnchanged
+	}
+	if err := uc.checkAvailable(ctx, input.NewEmail); err != nil {
+		return nil, err
+	}
+
+	change := &user.EmailChange{
+		UserID:      input.ID,
+		NewEmail:    input.NewEmail,
+		RequestedAt: time.Now(),
+	}
+	if err := uc.changes.Save(ctx, change); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.authService.GenerateAndSendCode(ctx, auth.GenerateAndSendCodeInput{
+		Username:   input.NewEmail,
+		Identifier: auth.EmailChangeCodeIdentifier,
+		Subject:    "Please confirm your new email",
+		Body:       "Your email change code is: %s",
+	}); err != nil {
+		uc.logger.Error("failed to generate code: %v", err)
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// checkAvailable fails with ErrUserAlreadyExists if a profile or an auth provider account already uses the email.
+func (uc *RequestEmailChangeUseCase) checkAvailable(ctx context.Context, email string) error {
+	existing, err := uc.userService.GetByEmail(ctx, &user.GetUserByEmailInput{Email: email})
+	if err != nil && err != user.ErrUserNotFound {
+		return err
+	}
+	if existing != nil {
+		return user.ErrUserAlreadyExists
+	}
+
+	_, err = uc.authService.GetUser(ctx, auth.GetUserInput{Username: email})
+	if err == nil {
+		return user.ErrUserAlreadyExists
+	}
+	if err != auth.ErrUserNotFound {
+		return err
+	}
+	return nil
+}
+
+// ConfirmEmailChangeSaga switches the auth provider account, then the profile and the organization memberships
+// to the new email, and signs the user out.
+const ConfirmEmailChangeSaga saga.Type = "user.confirm_email_change"
+
+type ConfirmEmailChangeUseCase struct {
+	userService     user.UserService
+	authService     auth.AuthService
+	denylistService denylist.DenylistService
+	changes         user.EmailChangeRepository
+	members         organization.MemberRepository
+	sagas           saga.SagaService
+	events          events.EventDispatcher
+	logger          logger.Logger
+}
+
+type ConfirmEmailChangeInput struct {
+	user.ConfirmEmailChangeInput
+}
+
+// emailChangePayload is the stored state of a confirmed email change.
+type emailChangePayload struct {
+	UserID   string `json:"userId"`
+	OldEmail string `json:"oldEmail"`
+	NewEmail string `json:"newEmail"`
+}
+
+func NewConfirmEmailChangeUseCase(userService user.UserService, authService auth.AuthService, denylistService denylist.DenylistService, changes user.EmailChangeRepository, members organization.MemberRepository, sagas saga.SagaService, events events.EventDispatcher, logger logger.Logger) *ConfirmEmailChangeUseCase {
+	uc := &ConfirmEmailChangeUseCase{
+		userService:     userService,
+		authService:     authService,
+		denylistService: denylistService,
+		changes:         changes,
+		members:         members,
+		sagas:           sagas,
+		events:          events,
+		logger:          logger,
+	}
+	sagas.Register(uc.saga())
+	return uc
+}
+
+// Execute checks the code sent to the new email, then runs the change as a saga. When the profile cannot be
+// updated the auth provider change is compensated, and a compensation that fails is retried by the saga worker.
+func (uc *ConfirmEmailChangeUseCase) Execute(ctx context.Context, input ConfirmEmailChangeInput) error {
+	if err := input.ConfirmEmailChangeInput.Validate(); err != nil {
+		return err
+	}
+
+	change, err := uc.changes.Get(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authService.VerifyCode(ctx, auth.VerifyCodeInput{
+		Code:       input.Code,
+		Identifier: auth.EmailChangeCodeIdentifier,
+		Username:   change.NewEmail,
+	}); err != nil {
+		return err
+	}
+
+	profile, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: input.ID.String()})
+	if err != nil {
+		return err
+	}
+
+	if err := uc.sagas.Start(ctx, saga.StartInput{
+		Type: ConfirmEmailChangeSaga,
+		Payload: emailChangePayload{
+			UserID:   input.ID.String(),
+			OldEmail: profile.Email,
+			NewEmail: change.NewEmail,
+		},
+	}); err != nil {
+		if err == auth.ErrUserAlreadyExists {
+			return user.ErrUserAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *ConfirmEmailChangeUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: ConfirmEmailChangeSaga,
+		Steps: []saga.Step{
+			{Name: "change_auth_email", Action: uc.changeAuthEmail, Compensate: uc.revertAuthEmail},
+			{Name: "update_profile", Action: uc.updateProfile, Transactional: true},
+			{Name: "logout", Action: uc.logout},
+			{Name: "dispatch_email_changed", Action: uc.dispatchEmailChanged},
+		},
+	}
+}
+
+func (uc *ConfirmEmailChangeUseCase) changeAuthEmail(ctx context.Context, exec *saga.Execution) error {
+	var payload emailChangePayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	return uc.switchAuthEmail(ctx, payload.OldEmail, payload.NewEmail)
+}
+
+func (uc *ConfirmEmailChangeUseCase) revertAuthEmail(ctx context.Context, exec *saga.Execution) error {
+	var payload emailChangePayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	return uc.switchAuthEmail(ctx, payload.NewEmail, payload.OldEmail)
+}
+
+// switchAuthEmail renames the auth provider account. An account already renamed by an earlier run is done.
+func (uc *ConfirmEmailChangeUseCase) switchAuthEmail(ctx context.Context, from, to string) error {
+	err := uc.authService.ChangeEmail(ctx, auth.ChangeEmailInput{
+		Username: from,
+		NewEmail: to,
+	})
+	if err != auth.ErrUserNotFound {
+		return err
+	}
+
+	if _, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: to}); err != nil {
+		if err == auth.ErrUserNotFound {
+			return auth.ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// updateProfile switches the profile and the organization memberships to the new email and clears the pending
+// change, a confirmed change cannot be confirmed again.
+func (uc *ConfirmEmailChangeUseCase) updateProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload emailChangePayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	userID, err := user.ParseUserID(payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.userService.Update(ctx, &user.UpdateUserInput{
+		ID:    userID,
+		Email: &payload.NewEmail,
+	}); err != nil {
+		return err
+	}
+	if err := uc.members.UpdateEmail(ctx, payload.UserID, payload.NewEmail); err != nil {
+		return err
+	}
+	if err := uc.changes.Delete(ctx, userID); err != nil && err != user.ErrEmailChangeNotFound {
+		return err
+	}
+	return nil
+}
+
+// logout revokes the sessions issued for the old email, a failure is only logged.
+func (uc *ConfirmEmailChangeUseCase) logout(ctx context.Context, exec *saga.Execution) error {
+	var payload emailChangePayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if err := auth_usecases.AdminLogout(ctx, uc.authService, uc.denylistService, payload.NewEmail); err != nil {
+		uc.logger.Error("Error revoking sessions of user %s after email change: %v", payload.UserID, err)
+	}
+	return nil
+}
+
+func (uc *ConfirmEmailChangeUseCase) dispatchEmailChanged(ctx context.Context, exec *saga.Execution) error {
+	var payload emailChangePayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if err := uc.events.Dispatch(&user.UserEmailChangedEvent{
+		UserID:   payload.UserID,
+		OldEmail: payload.OldEmail,
+		NewEmail: payload.NewEmail,
+	}); err != nil {
+		uc.logger.Error("Error dispatching user email changed event: %s", err)
+	}
+	return nil
+}
+
+type CancelEmailChangeUseCase struct {
+	changes user.EmailChangeRepository
+}
+
+func NewCancelEmailChangeUseCase(changes user.EmailChangeRepository) *CancelEmailChangeUseCase {
+	return &CancelEmailChangeUseCase{
+		changes: changes,
+	}
+}
+
+func (uc *CancelEmailChangeUseCase) Execute(ctx context.Context, userID string) error {
+	id, err := user.ParseUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.changes.Delete(ctx, id)
+}
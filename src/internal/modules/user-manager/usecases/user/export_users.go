@@ -0,0 +1,396 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/validator"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UserExportFormat string
+
+const (
+	UserExportCSV    UserExportFormat = "csv"
+	UserExportNDJSON UserExportFormat = "ndjson"
+)
+
+// UserExportFields are the columns of an export, in their default order.
+var UserExportFields = []string{"id", "name", "email", "phone", "phoneVerified", "attributes", "status", "enabled", "mfaEnabled", "groups", "createdAt", "lastLoginAt"}
+
+type ExportUsersUseCase struct {
+	userService user.UserService
+	authService auth.AuthService
+	logger      logger.Logger
+}
+
+// ExportUsersInput takes the filters of the admin directory, Fields defaults to every field.
+type ExportUsersInput struct {
+	Search        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Status        auth.UserStatus
+	Group         auth.UserGroup
+	Format        UserExportFormat
+	Fields        []string
+}
+
+// UserExport is a validated export, written page by page to keep the memory use flat. With a Status or a Group,
+// the auth provider is paged and the profiles of each page are read, as in the directory.
+type UserExport struct {
+	uc     *ExportUsersUseCase
+	list   user.ListUsersInput
+	status auth.UserStatus
+	group  auth.UserGroup
+	format UserExportFormat
+	fields []string
+}
+
+func NewExportUsersUseCase(userService user.UserService, authService auth.AuthService, logger logger.Logger) *ExportUsersUseCase {
+	return &ExportUsersUseCase{
+		userService: userService,
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// Execute validates the export so errors are reported before anything is written.
+func (uc *ExportUsersUseCase) Execute(ctx context.Context, input ExportUsersInput) (*UserExport, error) {
+	if input.Format == "" {
+		input.Format = UserExportCSV
+	}
+	if input.Format != UserExportCSV && input.Format != UserExportNDJSON {
+		return nil, user.ErrInvalidExportFormat
+	}
+
+	fields := input.Fields
+	if len(fields) == 0 {
+		fields = UserExportFields
+	}
+	for _, field := range fields {
+		if !isUserExportField(field) {
+			return nil, user.ErrInvalidExportField
+		}
+	}
+
+	list := user.ListUsersInput{
+		Limit:         user.MaxListLimit,
+		Search:        input.Search,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+	}
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	filter := auth.ListUsersInput{Group: input.Group, Status: input.Status}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &UserExport{uc: uc, list: list, status: input.Status, group: input.Group, format: input.Format, fields: fields}, nil
+}
+
+func isUserExportField(field string) bool {
+	for _, f := range UserExportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *UserExport) ContentType() string {
+	if e.format == UserExportNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+func (e *UserExport) FileName() string {
+	return "users." + string(e.format)
+}
+
+// WriteTo pages through the users, merges the auth provider data of each page and writes it, flushing w after every page.
+func (e *UserExport) WriteTo(ctx context.Context, w io.Writer) (err error) {
+	defer func() {
+		if err != nil {
+			e.uc.logger.Error("Error exporting users: %v", err)
+		}
+	}()
+
+	var groups map[string][]string
+	if e.selects("groups") {
+		if groups, err = e.uc.groupMembers(ctx); err != nil {
+			return err
+		}
+	}
+
+	var csvWriter *csv.Writer
+	write := e.ndjsonWriter(w)
+	if e.format == UserExportCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(e.fields); err != nil {
+			return err
+		}
+		write = e.csvWriter(csvWriter)
+	}
+
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows, next, err := e.page(ctx, cursor, groups)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (e *UserExport) selects(fields ...string) bool {
+	for _, selected := range e.fields {
+		for _, field := range fields {
+			if selected == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// page reads a page of users and returns it with the cursor of the next one. The auth provider is only called
+// for the selected fields, and its listings leave MFAEnabled out, which is then looked up.
+func (e *UserExport) page(ctx context.Context, cursor string, groups map[string][]string) ([]*userExportRow, string, error) {
+	list := e.list
+	var authUsers []*auth.User
+	next := ""
+
+	if e.status != "" || e.group != "" {
+		authPage, err := e.uc.authService.ListUsers(ctx, auth.ListUsersInput{
+			Cursor: cursor,
+			Limit:  auth.MaxListUsersLimit,
+			Group:  e.group,
+			Status: e.status,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if len(authPage.Users) == 0 {
+			return nil, authPage.NextCursor, nil
+		}
+
+		authUsers, next = authPage.Users, authPage.NextCursor
+		list.Limit = len(authUsers)
+		list.Emails = make([]string, len(authUsers))
+		for i, authUser := range authUsers {
+			list.Emails[i] = strings.ToLower(authUser.Email)
+		}
+	} else {
+		list.Cursor = cursor
+	}
+
+	page, err := e.uc.userService.List(ctx, &list)
+	if err != nil {
+		return nil, "", err
+	}
+	if list.Emails == nil {
+		next = page.NextCursor
+	}
+
+	if e.selects("mfaEnabled") || (authUsers == nil && e.selects("status", "enabled")) {
+		emails := make([]string, len(page.Users))
+		for i, usr := range page.Users {
+			emails[i] = usr.Email
+		}
+		if authUsers, err = e.uc.authService.GetUsers(ctx, auth.GetUsersInput{Usernames: emails}); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return exportRows(page.Users, authUsers, groups), next, nil
+}
+
+type userExportRow struct {
+	*user.User
+	status     auth.UserStatus
+	enabled    bool
+	mfaEnabled bool
+	groups     []string
+}
+
+func (r *userExportRow) value(field string) interface{} {
+	switch field {
+	case "id":
+		return r.ID.String()
+	case "name":
+		return r.Name
+	case "email":
+		return r.Email
+	case "phone":
+		return r.Phone
+	case "phoneVerified":
+		return r.PhoneVerified
+	case "attributes":
+		return r.Attributes
+	case "status":
+		return r.status
+	case "enabled":
+		return r.enabled
+	case "mfaEnabled":
+		return r.mfaEnabled
+	case "groups":
+		return r.groups
+	case "createdAt":
+		return r.CreatedAt
+	case "lastLoginAt":
+		return r.LastLoginAt
+	}
+	return nil
+}
+
+func (e *UserExport) ndjsonWriter(w io.Writer) func(*userExportRow) error {
+	encoder := json.NewEncoder(w)
+	return func(row *userExportRow) error {
+		record := make(map[string]interface{}, len(e.fields))
+		for _, field := range e.fields {
+			record[field] = row.value(field)
+		}
+		return encoder.Encode(record)
+	}
+}
+
+func (e *UserExport) csvWriter(w *csv.Writer) func(*userExportRow) error {
+	return func(row *userExportRow) error {
+		record := make([]string, len(e.fields))
+		for i, field := range e.fields {
+			record[i] = csvValue(field, row.value(field))
+		}
+		return w.Write(record)
+	}
+}
+
+// csvValue writes times in RFC 3339, groups separated by semicolons and attributes as a JSON object.
+// Values a spreadsheet would read as a formula are prefixed with a quote, except E.164 phones: their leading + only
+// makes them a number.
+func csvValue(field string, value interface{}) string {
+	text := csvText(value)
+	if field == "phone" && isE164(text) {
+		return text
+	}
+	return escapeFormula(text)
+}
+
+func isE164(phone string) bool {
+	normalized, err := validator.NormalizePhone(phone)
+	return err == nil && normalized == phone
+}
+
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func csvText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case auth.UserStatus:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ";")
+	case user.Attributes:
+		if len(v) == 0 {
+			return ""
+		}
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+	return ""
+}
+
+// exportRows merges the auth provider state into the profiles, users without an account are UNKNOWN.
+func exportRows(users []*user.User, authUsers []*auth.User, groups map[string][]string) []*userExportRow {
+	byEmail := make(map[string]*auth.User, len(authUsers))
+	for _, authUser := range authUsers {
+		byEmail[strings.ToLower(authUser.Email)] = authUser
+	}
+
+	rows := make([]*userExportRow, len(users))
+	for i, usr := range users {
+		email := strings.ToLower(usr.Email)
+		rows[i] = &userExportRow{User: usr, status: auth.Unknown, groups: []string{}}
+		if authUser, ok := byEmail[email]; ok {
+			rows[i].status, rows[i].enabled, rows[i].mfaEnabled = authUser.Status, authUser.Enabled, authUser.MFAEnabled
+		}
+		if memberOf, ok := groups[email]; ok {
+			rows[i].groups = memberOf
+		}
+	}
+	return rows
+}
+
+// groupMembers pages the members of every group once, instead of listing the groups of each user.
+func (uc *ExportUsersUseCase) groupMembers(ctx context.Context) (map[string][]string, error) {
+	groups := map[string][]string{}
+	for _, group := range []auth.UserGroup{auth.GroupAdmin, auth.GroupUser} {
+		cursor := ""
+		for {
+			page, err := uc.authService.ListUsers(ctx, auth.ListUsersInput{Cursor: cursor, Group: group})
+			if err != nil {
+				return nil, err
+			}
+			for _, authUser := range page.Users {
+				email := strings.ToLower(authUser.Email)
+				groups[email] = append(groups[email], string(group))
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+	}
+	return groups, nil
+}
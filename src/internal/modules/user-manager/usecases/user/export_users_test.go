@@ -0,0 +1,25 @@
+package user
+
+import "testing"
+
+func TestCSVValueEscapesFormulasButNotPhones(t *testing.T) {
+	phone := "+351912345678"
+	legacyPhone := "+1 (555) 010-9999"
+	tests := []struct {
+		field string
+		value interface{}
+		want  string
+	}{
+		{field: "phone", value: &phone, want: "+351912345678"},
+		{field: "phone", value: &legacyPhone, want: "'+1 (555) 010-9999"},
+		{field: "name", value: "+351912345678", want: "'+351912345678"},
+		{field: "name", value: "=HYPERLINK(\"http://example.com\")", want: "'=HYPERLINK(\"http://example.com\")"},
+		{field: "name", value: "Ada", want: "Ada"},
+		{field: "groups", value: []string{"-Admin", "User"}, want: "'-Admin;User"},
+	}
+	for _, tt := range tests {
+		if got := csvValue(tt.field, tt.value); got != tt.want {
+			t.Errorf("csvValue(%s, %v) = %q, want %q", tt.field, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/password"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ImportOptions configures the bulk user import.
+type ImportOptions struct {
+	// BatchSize is the number of users created concurrently.
+	BatchSize int
+	// PasswordResetURL is linked from the invitation emails.
+	PasswordResetURL string
+	// MaxRows is the default limit of rows per import, 0 disables it.
+	MaxRows int
+	// MaxBytes limits the size of the files uploaded through the API.
+	MaxBytes int64
+}
+
+// ImportUserSaga signs an imported user up, confirms it, creates the profile and sends the invitation.
+const ImportUserSaga saga.Type = "user.import"
+
+type ImportUsersUseCase struct {
+	userService  user.UserService
+	authService  auth.AuthService
+	emailService email.EmailService
+	schema       *user.AttributeSchema
+	sagas        saga.SagaService
+	options      ImportOptions
+	logger       logger.Logger
+}
+
+type ImportUsersInput struct {
+	File   io.Reader
+	Format user.ImportFormat
+	// DryRun only validates the rows and reports which ones would be created.
+	DryRun bool
+	// SendInvitations emails the created users, asking them to choose a password.
+	SendInvitations bool
+	// MaxRows overrides the default limit of rows when positive, a negative value disables the limit.
+	MaxRows int
+}
+
+// importRow is a validated row ready to be created.
+type importRow struct {
+	result          *user.ImportRowResult
+	signUp          auth.SignUpInput
+	create          user.CreateUserInput
+	generatedSecret bool
+}
+
+// importPayload is the stored state of an imported user, the password is only passed as a secret.
+type importPayload struct {
+	UserID          string          `json:"userId,omitempty"`
+	Username        string          `json:"username"`
+	Email           string          `json:"email"`
+	Name            string          `json:"name"`
+	Phone           *string         `json:"phone,omitempty"`
+	Attributes      user.Attributes `json:"attributes,omitempty"`
+	GeneratedSecret bool            `json:"generatedSecret"`
+	SendInvitation  bool            `json:"sendInvitation"`
+}
+
+func NewImportUsersUseCase(userService user.UserService, authService auth.AuthService, emailService email.EmailService, schema *user.AttributeSchema, sagas saga.SagaService, options ImportOptions, logger logger.Logger) *ImportUsersUseCase {
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1
+	}
+	uc := &ImportUsersUseCase{
+		userService:  userService,
+		authService:  authService,
+		emailService: emailService,
+		schema:       schema,
+		sagas:        sagas,
+		options:      options,
+		logger:       logger,
+	}
+	sagas.Register(uc.saga())
+	return uc
+}
+
+// MaxBytes is the largest file the API accepts.
+func (uc *ImportUsersUseCase) MaxBytes() int64 {
+	return uc.options.MaxBytes
+}
+
+// Execute validates every row with the sign up rules, then creates the valid users, confirmed and with a verified email,
+// in the auth provider and the users table. A failed row is reported and does not stop the import.
+func (uc *ImportUsersUseCase) Execute(ctx context.Context, input ImportUsersInput) (*user.ImportReport, error) {
+	maxRows := uc.options.MaxRows
+	if input.MaxRows != 0 {
+		maxRows = input.MaxRows
+	}
+	rows, err := user.ParseImport(input.File, input.Format, maxRows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &user.ImportReport{
+		DryRun: input.DryRun,
+		Total:  len(rows),
+		Rows:   make([]user.ImportRowResult, len(rows)),
+	}
+
+	seen := make(map[string]int, len(rows))
+	var valid []*importRow
+	for i, row := range rows {
+		report.Rows[i] = user.ImportRowResult{Line: row.Line, Email: row.Email}
+		result := &report.Rows[i]
+
+		prepared, err := uc.prepare(row)
+		if err == nil {
+			if line, duplicate := seen[prepared.signUp.Username]; duplicate {
+				err = fmt.Errorf("duplicate of line %d", line)
+			}
+		}
+		if err != nil {
+			result.Status, result.Error = user.ImportRowFailed, err.Error()
+			continue
+		}
+
+		seen[prepared.signUp.Username] = row.Line
+		prepared.result = result
+		result.Email = prepared.signUp.Username
+		valid = append(valid, prepared)
+	}
+
+	valid = uc.checkAvailable(ctx, valid)
+
+	if input.DryRun {
+		for _, row := range valid {
+			row.result.Status = user.ImportRowValid
+		}
+	} else {
+		uc.createAll(ctx, valid, input.SendInvitations)
+	}
+
+	for _, result := range report.Rows {
+		switch result.Status {
+		case user.ImportRowCreated:
+			report.Created++
+		case user.ImportRowValid:
+			report.Valid++
+		case user.ImportRowFailed:
+			report.Failed++
+		}
+	}
+
+	uc.logger.Info("User import: %d rows, %d created, %d valid, %d failed (dry run: %t)", report.Total, report.Created, report.Valid, report.Failed, report.DryRun)
+	return report, nil
+}
+
+// prepare validates a row with the sign up rules.
+func (uc *ImportUsersUseCase) prepare(row user.ImportRow) (*importRow, error) {
+	if row.Err != nil {
+		return nil, row.Err
+	}
+
+	prepared := &importRow{
+		signUp: auth.SignUpInput{
+			Username: row.Email,
+			Password: row.Password,
+			Name:     row.Name,
+		},
+		create: user.CreateUserInput{
+			ID:         user.UserID(uuid.New()),
+			Name:       row.Name,
+			Email:      row.Email,
+			Phone:      row.Phone,
+			Attributes: row.Attributes,
+			Schema:     uc.schema,
+			Admin:      true,
+		},
+	}
+	if prepared.signUp.Password == "" {
+		generated, err := password.Generate()
+		if err != nil {
+			return nil, err
+		}
+		prepared.signUp.Password, prepared.generatedSecret = generated, true
+	}
+
+	if err := prepared.signUp.Validate(); err != nil {
+		return nil, err
+	}
+	if err := prepared.create.Validate(); err != nil {
+		return nil, err
+	}
+	return prepared, nil
+}
+
+// checkAvailable fails the rows whose email is already used by a profile or an auth provider account and returns
+// the others. The emails are looked up in batches, a batch that cannot be checked fails its rows.
+func (uc *ImportUsersUseCase) checkAvailable(ctx context.Context, rows []*importRow) []*importRow {
+	batchSize := min(auth.MaxGetUsers, user.MaxListLimit)
+
+	var available []*importRow
+	for start := 0; start < len(rows); start += batchSize {
+		batch := rows[start:min(start+batchSize, len(rows))]
+
+		taken, err := uc.takenEmails(ctx, batch)
+		for _, row := range batch {
+			switch {
+			case err != nil:
+				row.result.Status, row.result.Error = user.ImportRowFailed, err.Error()
+			case taken[row.signUp.Username]:
+				row.result.Status, row.result.Error = user.ImportRowFailed, user.ErrUserAlreadyExists.Error()
+			default:
+				available = append(available, row)
+			}
+		}
+	}
+	return available
+}
+
+// takenEmails returns the emails of the rows used by a profile or an auth provider account.
+func (uc *ImportUsersUseCase) takenEmails(ctx context.Context, rows []*importRow) (map[string]bool, error) {
+	emails := make([]string, len(rows))
+	for i, row := range rows {
+		emails[i] = row.signUp.Username
+	}
+
+	taken := make(map[string]bool, len(rows))
+	page, err := uc.userService.List(ctx, &user.ListUsersInput{Limit: len(emails), Emails: emails})
+	if err != nil {
+		return nil, err
+	}
+	for _, usr := range page.Users {
+		taken[strings.ToLower(usr.Email)] = true
+	}
+
+	authUsers, err := uc.authService.GetUsers(ctx, auth.GetUsersInput{Usernames: append([]string(nil), emails...)})
+	if err != nil {
+		return nil, err
+	}
+	for _, authUser := range authUsers {
+		taken[strings.ToLower(authUser.Email)] = true
+	}
+	return taken, nil
+}
+
+// createAll creates the rows BatchSize at a time, so the auth provider rate limits are not hit by large files.
+func (uc *ImportUsersUseCase) createAll(ctx context.Context, rows []*importRow, sendInvitations bool) {
+	for start := 0; start < len(rows); start += uc.options.BatchSize {
+		end := start + uc.options.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var wg sync.WaitGroup
+		for _, row := range rows[start:end] {
+			wg.Add(1)
+			go func(row *importRow) {
+				defer wg.Done()
+
+				if err := ctx.Err(); err != nil {
+					row.result.Status, row.result.Error = user.ImportRowFailed, err.Error()
+					return
+				}
+				if err := uc.create(ctx, row, sendInvitations); err != nil {
+					row.result.Status, row.result.Error = user.ImportRowFailed, err.Error()
+					return
+				}
+				row.result.Status = user.ImportRowCreated
+			}(row)
+		}
+		wg.Wait()
+	}
+}
+
+func (uc *ImportUsersUseCase) create(ctx context.Context, row *importRow, sendInvitation bool) error {
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type: ImportUserSaga,
+		Payload: importPayload{
+			Username:        row.signUp.Username,
+			Email:           row.create.Email,
+			Name:            row.create.Name,
+			Phone:           row.create.Phone,
+			Attributes:      row.create.Attributes,
+			GeneratedSecret: row.generatedSecret,
+			SendInvitation:  sendInvitation,
+		},
+		Secrets: map[string]string{"password": row.signUp.Password},
+	})
+}
+
+func (uc *ImportUsersUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: ImportUserSaga,
+		Steps: []saga.Step{
+			{Name: "sign_up", Action: uc.signUp, Compensate: uc.deleteSignUp, Inline: true},
+			{Name: "confirm", Action: uc.confirm},
+			{Name: "create_profile", Action: uc.createProfile, Compensate: uc.deleteProfile, Transactional: true},
+			{Name: "send_invitation", Action: uc.sendInvitation},
+		},
+	}
+}
+
+func (uc *ImportUsersUseCase) signUp(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	password, _ := exec.Secret("password")
+
+	signUpOutput, err := uc.authService.SignUp(ctx, auth.SignUpInput{
+		Username: payload.Username,
+		Password: password,
+		Name:     payload.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	payload.UserID = signUpOutput.Id
+	return exec.Encode(payload)
+}
+
+// deleteSignUp deletes the auth provider user created by the saga.
+func (uc *ImportUsersUseCase) deleteSignUp(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	return auth_usecases.DeleteSignedUpUser(ctx, uc.authService, payload.Username, payload.UserID)
+}
+
+// confirm confirms the user and verifies the email, a user already confirmed by an earlier attempt is not confirmed again.
+func (uc *ImportUsersUseCase) confirm(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	authUser, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: payload.Username})
+	if err != nil {
+		return err
+	}
+	if authUser.Status != auth.Confirmed {
+		if _, err := uc.authService.ConfirmSignUp(ctx, auth.ConfirmSignUpInput{Username: payload.Username}); err != nil {
+			return err
+		}
+	}
+	return uc.authService.VerifyEmail(ctx, auth.VerifyEmailInput{Username: payload.Username})
+}
+
+func (uc *ImportUsersUseCase) createProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+
+	if _, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: payload.UserID}); err == nil {
+		return nil
+	} else if err != user.ErrUserNotFound {
+		return err
+	}
+
+	userID, err := user.ParseUserID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	_, err = uc.userService.Create(ctx, &user.CreateUserInput{
+		ID:         userID,
+		Name:       payload.Name,
+		Email:      payload.Email,
+		Phone:      payload.Phone,
+		Attributes: payload.Attributes,
+		Schema:     uc.schema,
+		Admin:      true,
+	})
+	return err
+}
+
+func (uc *ImportUsersUseCase) deleteProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	userID, err := user.ParseUserID(payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.userService.Delete(ctx, &user.DeleteUserInput{ID: userID}); err != nil && err != user.ErrUserNotFound {
+		return err
+	}
+	return nil
+}
+
+// sendInvitation does not fail the saga, a failed email is only logged.
+func (uc *ImportUsersUseCase) sendInvitation(ctx context.Context, exec *saga.Execution) error {
+	var payload importPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if !payload.SendInvitation {
+		return nil
+	}
+
+	if err := uc.emailService.SendEmail(ctx, uc.invitation(payload)); err != nil {
+		uc.logger.Error("Error sending the invitation of imported user %s: %v", payload.Username, err)
+	}
+	return nil
+}
+
+func (uc *ImportUsersUseCase) invitation(payload importPayload) email.Email {
+	body := fmt.Sprintf("Hello %s, an account was created for you with this email.", payload.Name)
+	if payload.GeneratedSecret {
+		body += " Choose your password with the forgot password flow"
+		if uc.options.PasswordResetURL != "" {
+			body += fmt.Sprintf(": %s", uc.options.PasswordResetURL)
+		}
+		body += "."
+	}
+	return email.Email{
+		To:      payload.Username,
+		Subject: "Your account is ready",
+		Body:    body,
+	}
+}
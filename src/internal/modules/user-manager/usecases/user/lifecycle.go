@@ -0,0 +1,347 @@
+package user
+
+import (
+	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/admin"
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+// AccountInput targets a user or admin profile by ID, PerformedBy is the ID of the acting admin and
+// PerformerRoles the roles of its access token.
+type AccountInput struct {
+	ID             string
+	PerformedBy    string
+	PerformerRoles []string
+}
+
+type account struct {
+	id    string
+	email string
+	user  *user.User
+	admin *admin.Admin
+}
+
+// accounts resolves profile IDs to accounts and records the lifecycle events, it is shared by the lifecycle use cases.
+type accounts struct {
+	userService     user.UserService
+	adminService    admin.AdminService
+	authService     auth.AuthService
+	denylistService denylist.DenylistService
+	roleService     role.RoleService
+	members         organization.MemberRepository
+	events          events.EventDispatcher
+	logger          logger.Logger
+}
+
+// find resolves the target of an admin action, admins cannot act on their own account and only holders
+// of admins:write act on admin accounts.
+func (a *accounts) find(ctx context.Context, input AccountInput) (*account, error) {
+	if input.ID == input.PerformedBy {
+		return nil, user.ErrOwnAccount
+	}
+
+	acc, err := a.get(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorize(ctx, acc, input.PerformerRoles); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// authorize keeps holders of users:write alone from disabling, resetting or deleting the admins above them.
+func (a *accounts) authorize(ctx context.Context, acc *account, performerRoles []string) error {
+	if acc.admin == nil {
+		return nil
+	}
+
+	allowed, err := a.roleService.HasPermission(ctx, performerRoles, role.PermissionAdminsWrite)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return user.ErrAdminAccount
+	}
+	return nil
+}
+
+func (a *accounts) get(ctx context.Context, id string) (*account, error) {
+	getUserInput := &user.GetUserInput{ID: id}
+	if err := getUserInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	acc := &account{id: id}
+	usr, err := a.userService.GetByID(ctx, getUserInput)
+	if err != nil && err != user.ErrUserNotFound {
+		return nil, err
+	}
+	if usr != nil {
+		acc.user, acc.email = usr, usr.Email
+	}
+
+	adm, err := a.adminService.GetByID(ctx, &admin.GetAdminInput{ID: id})
+	if err != nil && err != admin.ErrAdminNotFound {
+		return nil, err
+	}
+	if adm != nil {
+		acc.admin, acc.email = adm, adm.Email
+	}
+
+	if acc.email == "" {
+		return nil, user.ErrUserNotFound
+	}
+	return acc, nil
+}
+
+// checkOrgAdmins fails when the account is the last OrgAdmin of an organization, which would be left
+// without anyone to manage it. The role has to be handed over first.
+func (a *accounts) checkOrgAdmins(ctx context.Context, acc *account) error {
+	memberships, err := a.members.ListMemberships(ctx, &organization.ListMembershipsInput{UserID: acc.id})
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.Role != organization.RoleOrgAdmin {
+			continue
+		}
+		count, err := a.members.CountWithRole(ctx, membership.Organization.ID, organization.RoleOrgAdmin)
+		if err != nil {
+			return err
+		}
+		if count <= 1 {
+			return organization.ErrLastOrgAdmin
+		}
+	}
+	return nil
+}
+
+// erase revokes the sessions, purges the email codes and deletes the auth provider account and the
+// user and admin profiles. Profiles left without an auth provider account are still deleted.
+// The last OrgAdmin of an organization is not erased.
+func (a *accounts) erase(ctx context.Context, acc *account) error {
+	if err := a.checkOrgAdmins(ctx, acc); err != nil {
+		return err
+	}
+
+	if err := auth_usecases.AdminLogout(ctx, a.authService, a.denylistService, acc.email); err != nil && err != auth.ErrUserNotFound {
+		return err
+	}
+	if err := a.authService.PurgeCodes(ctx, auth.PurgeCodesInput{Username: acc.email}); err != nil {
+		return err
+	}
+	if err := a.authService.DeleteUser(ctx, auth.DeleteUserInput{Username: acc.email}); err != nil && err != auth.ErrUserNotFound {
+		return err
+	}
+
+	if acc.user != nil {
+		if _, err := a.userService.Delete(ctx, &user.DeleteUserInput{ID: acc.user.ID}); err != nil {
+			return err
+		}
+	}
+	if acc.admin != nil {
+		if _, err := a.adminService.Delete(ctx, &admin.DeleteAdminInput{ID: acc.admin.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *accounts) dispatch(eventType events.EventType, acc *account, performedBy string) {
+	event := &user.UserAccountEvent{
+		Type:        eventType,
+		UserID:      acc.id,
+		Email:       acc.email,
+		PerformedBy: performedBy,
+	}
+	if err := a.events.Dispatch(event); err != nil {
+		a.logger.Error("Error dispatching %s event: %s", eventType, err)
+	}
+}
+
+type DisableUserUseCase struct {
+	accounts        *accounts
+	authService     auth.AuthService
+	denylistService denylist.DenylistService
+}
+
+func NewDisableUserUseCase(accounts *accounts, authService auth.AuthService, denylistService denylist.DenylistService) *DisableUserUseCase {
+	return &DisableUserUseCase{
+		accounts:        accounts,
+		authService:     authService,
+		denylistService: denylistService,
+	}
+}
+
+// Execute disables the account and revokes its sessions.
+func (uc *DisableUserUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authService.DisableUser(ctx, auth.DisableUserInput{Username: acc.email}); err != nil {
+		return err
+	}
+	if err := auth_usecases.AdminLogout(ctx, uc.authService, uc.denylistService, acc.email); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserDisabled, acc, input.PerformedBy)
+	return nil
+}
+
+type EnableUserUseCase struct {
+	accounts    *accounts
+	authService auth.AuthService
+}
+
+func NewEnableUserUseCase(accounts *accounts, authService auth.AuthService) *EnableUserUseCase {
+	return &EnableUserUseCase{
+		accounts:    accounts,
+		authService: authService,
+	}
+}
+
+func (uc *EnableUserUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authService.EnableUser(ctx, auth.EnableUserInput{Username: acc.email}); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserEnabled, acc, input.PerformedBy)
+	return nil
+}
+
+type ForcePasswordResetUseCase struct {
+	accounts        *accounts
+	authService     auth.AuthService
+	denylistService denylist.DenylistService
+}
+
+func NewForcePasswordResetUseCase(accounts *accounts, authService auth.AuthService, denylistService denylist.DenylistService) *ForcePasswordResetUseCase {
+	return &ForcePasswordResetUseCase{
+		accounts:        accounts,
+		authService:     authService,
+		denylistService: denylistService,
+	}
+}
+
+// Execute signs the user out, the next sign in requires going through the forgot password flow.
+func (uc *ForcePasswordResetUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authService.ForcePasswordReset(ctx, auth.ForcePasswordResetInput{Username: acc.email}); err != nil {
+		return err
+	}
+	if err := auth_usecases.AdminLogout(ctx, uc.authService, uc.denylistService, acc.email); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserPasswordResetForced, acc, input.PerformedBy)
+	return nil
+}
+
+type ConfirmUserUseCase struct {
+	accounts    *accounts
+	authService auth.AuthService
+}
+
+func NewConfirmUserUseCase(accounts *accounts, authService auth.AuthService) *ConfirmUserUseCase {
+	return &ConfirmUserUseCase{
+		accounts:    accounts,
+		authService: authService,
+	}
+}
+
+// Execute confirms the sign up and the email without a confirmation code.
+func (uc *ConfirmUserUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	authUser, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: acc.email})
+	if err != nil {
+		return err
+	}
+	if authUser.Status != auth.Unconfirmed {
+		return auth.ErrUserAlreadyConfirmed
+	}
+
+	if _, err := uc.authService.ConfirmSignUp(ctx, auth.ConfirmSignUpInput{Username: acc.email}); err != nil {
+		return err
+	}
+	if err := uc.authService.VerifyEmail(ctx, auth.VerifyEmailInput{Username: acc.email}); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserConfirmedByAdmin, acc, input.PerformedBy)
+	return nil
+}
+
+type ResendConfirmationUseCase struct {
+	accounts             *accounts
+	sendConfirmationCode *auth_usecases.SendConfirmationCodeUseCase
+}
+
+func NewResendConfirmationUseCase(accounts *accounts, authService auth.AuthService, logger logger.Logger) *ResendConfirmationUseCase {
+	return &ResendConfirmationUseCase{
+		accounts:             accounts,
+		sendConfirmationCode: auth_usecases.NewSendConfirmationCodeUseCase(logger, authService),
+	}
+}
+
+func (uc *ResendConfirmationUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.sendConfirmationCode.Execute(ctx, auth_usecases.SendConfirmationCodeInput{Username: acc.email}); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserConfirmationResent, acc, input.PerformedBy)
+	return nil
+}
+
+type DeleteUserUseCase struct {
+	accounts *accounts
+}
+
+func NewDeleteUserUseCase(accounts *accounts) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		accounts: accounts,
+	}
+}
+
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, input AccountInput) error {
+	acc, err := uc.accounts.find(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.accounts.erase(ctx, acc); err != nil {
+		return err
+	}
+
+	uc.accounts.dispatch(user.UserDeleted, acc, input.PerformedBy)
+	return nil
+}
@@ -0,0 +1,183 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/pkg/logger"
+	"context"
+	"strings"
+)
+
+type ListUsersUseCase struct {
+	userService user.UserService
+	authService auth.AuthService
+	logger      logger.Logger
+}
+
+// ListUsersInput pages through the profiles, newest first. With a Status or a Group, the auth provider is
+// paged instead and Cursor is its cursor.
+type ListUsersInput struct {
+	user.ListUsersInput
+	Status auth.UserStatus
+	Group  auth.UserGroup
+}
+
+type UserSummary struct {
+	*user.User
+	Status  auth.UserStatus `json:"status"`
+	Enabled bool            `json:"enabled"`
+}
+
+type ListUsersOutput struct {
+	Users      []*UserSummary `json:"users"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+func NewListUsersUseCase(userService user.UserService, authService auth.AuthService, logger logger.Logger) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userService: userService,
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error) {
+	input.ListUsersInput.Emails = nil
+	if input.Status != "" || input.Group != "" {
+		return uc.listByAuthUser(ctx, input)
+	}
+
+	if err := input.ListUsersInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	page, err := uc.userService.List(ctx, &input.ListUsersInput)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, len(page.Users))
+	for i, usr := range page.Users {
+		emails[i] = usr.Email
+	}
+	authUsers, err := uc.authService.GetUsers(ctx, auth.GetUsersInput{Usernames: emails})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersOutput{
+		Users:      withStatus(page.Users, authUsers),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// listByAuthUser pages the auth provider with the filter and keeps the profiles of the page matching the
+// other filters, so a page can hold fewer than Limit users and still have a next one.
+func (uc *ListUsersUseCase) listByAuthUser(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error) {
+	list := input.ListUsersInput
+	list.Cursor = ""
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+
+	authPage, err := uc.authService.ListUsers(ctx, auth.ListUsersInput{
+		Cursor: input.Cursor,
+		Limit:  min(list.Limit, auth.MaxListUsersLimit),
+		Group:  input.Group,
+		Status: input.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ListUsersOutput{Users: []*UserSummary{}, NextCursor: authPage.NextCursor}
+	if len(authPage.Users) == 0 {
+		return out, nil
+	}
+
+	list.Emails = make([]string, len(authPage.Users))
+	for i, authUser := range authPage.Users {
+		list.Emails[i] = strings.ToLower(authUser.Email)
+	}
+	page, err := uc.userService.List(ctx, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Users = withStatus(page.Users, authPage.Users)
+	return out, nil
+}
+
+// withStatus merges the auth provider state into the profiles, users without an account are UNKNOWN.
+func withStatus(users []*user.User, authUsers []*auth.User) []*UserSummary {
+	byEmail := make(map[string]*auth.User, len(authUsers))
+	for _, authUser := range authUsers {
+		byEmail[strings.ToLower(authUser.Email)] = authUser
+	}
+
+	summaries := make([]*UserSummary, len(users))
+	for i, usr := range users {
+		summaries[i] = &UserSummary{User: usr, Status: auth.Unknown}
+		if authUser, ok := byEmail[strings.ToLower(usr.Email)]; ok {
+			summaries[i].Status = authUser.Status
+			summaries[i].Enabled = authUser.Enabled
+		}
+	}
+	return summaries
+}
+
+type GetUserUseCase struct {
+	userService user.UserService
+	authService auth.AuthService
+}
+
+type GetUserInput struct {
+	user.GetUserInput
+}
+
+type GetUserOutput struct {
+	*user.User
+	Status     auth.UserStatus `json:"status"`
+	Enabled    bool            `json:"enabled"`
+	MFAEnabled bool            `json:"mfaEnabled"`
+	Groups     []string        `json:"groups"`
+}
+
+func NewGetUserUseCase(userService user.UserService, authService auth.AuthService) *GetUserUseCase {
+	return &GetUserUseCase{
+		userService: userService,
+		authService: authService,
+	}
+}
+
+func (uc *GetUserUseCase) Execute(ctx context.Context, input GetUserInput) (*GetUserOutput, error) {
+	if err := input.GetUserInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	usr, err := uc.userService.GetByID(ctx, &input.GetUserInput)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GetUserOutput{User: usr, Status: auth.Unknown, Groups: []string{}}
+
+	authUser, err := uc.authService.GetUser(ctx, auth.GetUserInput{Username: usr.Email})
+	if err != nil {
+		if err == auth.ErrUserNotFound {
+			return out, nil
+		}
+		return nil, err
+	}
+	out.Status = authUser.Status
+	out.Enabled = authUser.Enabled
+	out.MFAEnabled = authUser.MFAEnabled
+
+	groups, err := uc.authService.ListUserGroups(ctx, auth.ListUserGroupsInput{Username: usr.Email})
+	if err != nil {
+		return nil, err
+	}
+	out.Groups = groups
+
+	return out, nil
+}
@@ -0,0 +1,138 @@
+package user
+
+import (
+	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/code/domain/code"
+	"auth-api/src/internal/shared/notification/domain/sms"
+	"auth-api/src/pkg/logger"
+	"auth-api/src/pkg/validator"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// PhoneVerificationCodeIdentifier codes are stored under "<identifier>#<user id>#<phone>", so changing the phone voids them.
+	PhoneVerificationCodeIdentifier = "PHONE_VERIFICATION_CODE"
+	phoneVerificationCodeTTL        = 10 * time.Minute
+	// maxPhoneVerificationSends codes are texted per user and per phone within phoneVerificationSendWindow.
+	maxPhoneVerificationSends   = 5
+	phoneVerificationSendWindow = time.Hour
+)
+
+func phoneCodeIdentifier(usr *user.User) string {
+	return fmt.Sprintf("%s#%s#%s", PhoneVerificationCodeIdentifier, usr.ID, *usr.Phone)
+}
+
+// phoneToVerify returns the user if it has a phone that is not verified yet. Phones saved before they were
+// normalized must be updated to the E.164 format first.
+func phoneToVerify(ctx context.Context, userService user.UserService, id user.UserID) (*user.User, error) {
+	usr, err := userService.GetByID(ctx, &user.GetUserInput{ID: id.String()})
+	if err != nil {
+		return nil, err
+	}
+	if usr.Phone == nil || *usr.Phone == "" {
+		return nil, user.ErrPhoneMissing
+	}
+	if normalized, err := validator.NormalizePhone(*usr.Phone); err != nil || normalized != *usr.Phone {
+		return nil, user.ErrPhoneNotE164
+	}
+	if usr.PhoneVerified {
+		return nil, user.ErrPhoneAlreadyVerified
+	}
+	return usr, nil
+}
+
+type SendPhoneVerificationUseCase struct {
+	userService user.UserService
+	codeService code.CodeService
+	smsService  sms.SmsService
+	sends       user.PhoneVerificationRepository
+	logger      logger.Logger
+}
+
+func NewSendPhoneVerificationUseCase(userService user.UserService, codeService code.CodeService, smsService sms.SmsService, sends user.PhoneVerificationRepository, logger logger.Logger) *SendPhoneVerificationUseCase {
+	return &SendPhoneVerificationUseCase{
+		userService: userService,
+		codeService: codeService,
+		smsService:  smsService,
+		sends:       sends,
+		logger:      logger,
+	}
+}
+
+// Execute texts a verification code to the phone of the user. The texts are throttled per user and per phone.
+func (uc *SendPhoneVerificationUseCase) Execute(ctx context.Context, userID string) error {
+	id, err := user.ParseUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	usr, err := phoneToVerify(ctx, uc.userService, id)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := uc.sends.RecordSend(ctx, usr.ID, *usr.Phone, phoneVerificationSendWindow, maxPhoneVerificationSends)
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		return user.ErrTooManyPhoneVerifications
+	}
+
+	verificationCode, err := uc.codeService.GenerateAndSave(ctx, code.GenerateAndSaveInput{
+		Identifier:        phoneCodeIdentifier(usr),
+		ExpiresAt:         time.Now().Add(phoneVerificationCodeTTL),
+		Length:            6,
+		CanContainLetters: false,
+	})
+	if err != nil {
+		uc.logger.Error("failed to generate code: %v", err)
+		return err
+	}
+
+	return uc.smsService.SendSms(ctx, sms.Sms{
+		To:   *usr.Phone,
+		Body: fmt.Sprintf("Your phone verification code is: %s", verificationCode.Value),
+	})
+}
+
+type VerifyPhoneUseCase struct {
+	userService user.UserService
+	codeService code.CodeService
+}
+
+type VerifyPhoneInput struct {
+	user.VerifyPhoneInput
+}
+
+func NewVerifyPhoneUseCase(userService user.UserService, codeService code.CodeService) *VerifyPhoneUseCase {
+	return &VerifyPhoneUseCase{
+		userService: userService,
+		codeService: codeService,
+	}
+}
+
+func (uc *VerifyPhoneUseCase) Execute(ctx context.Context, input VerifyPhoneInput) error {
+	if err := input.VerifyPhoneInput.Validate(); err != nil {
+		return err
+	}
+
+	usr, err := phoneToVerify(ctx, uc.userService, input.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.codeService.VerifyCode(ctx, code.VerifyCodeInput{
+		Identifier: phoneCodeIdentifier(usr),
+		Code:       input.Code,
+	}); err != nil {
+		return err
+	}
+
+	return uc.userService.MarkPhoneVerified(ctx, &user.MarkPhoneVerifiedInput{
+		ID:    usr.ID,
+		Phone: *usr.Phone,
+	})
+}
@@ -4,13 +4,22 @@ import (
 	"auth-api/src/internal/events"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
 	"auth-api/src/internal/modules/user-manager/domain/user"
+	auth_usecases "auth-api/src/internal/modules/user-manager/usecases/auth"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
+
+	"github.com/google/uuid"
 )
 
+// RegisterUserSaga signs the user up in the auth provider, creates the profile and announces the registration.
+const RegisterUserSaga saga.Type = "user.register"
+
 type RegisterUserUseCase struct {
 	userService user.UserService
 	auth        auth.AuthService
+	schema      *user.AttributeSchema
+	sagas       saga.SagaService
 	logger      logger.Logger
 	events      events.EventDispatcher
 }
@@ -20,19 +29,34 @@ type RegisterUserInput struct {
 	user.CreateUserInput
 }
 
-func NewRegisterUserUseCase(userService user.UserService, auth auth.AuthService, logger logger.Logger, events events.EventDispatcher) *RegisterUserUseCase {
-	return &RegisterUserUseCase{
+// registerPayload is the stored state of a registration, the password is only passed as a secret.
+type registerPayload struct {
+	UserID     string          `json:"userId,omitempty"`
+	Username   string          `json:"username"`
+	Email      string          `json:"email"`
+	Name       string          `json:"name"`
+	Phone      *string         `json:"phone,omitempty"`
+	Attributes user.Attributes `json:"attributes,omitempty"`
+}
+
+func NewRegisterUserUseCase(userService user.UserService, auth auth.AuthService, schema *user.AttributeSchema, sagas saga.SagaService, logger logger.Logger, events events.EventDispatcher) *RegisterUserUseCase {
+	uc := &RegisterUserUseCase{
 		userService: userService,
 		auth:        auth,
+		schema:      schema,
+		sagas:       sagas,
 		logger:      logger,
 		events:      events,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
-func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserInput) (execErr error) {
+func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserInput) error {
 	if err := input.SignUpInput.Validate(); err != nil {
 		return err
 	}
+	input.CreateUserInput.Schema = uc.schema
 
 	getByEmailInput := &user.GetUserByEmailInput{
 		Email: input.CreateUserInput.Email,
@@ -40,7 +64,7 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserIn
 	if err := getByEmailInput.Validate(); err != nil {
 		return err
 	}
-	if exists, err := uc.userService.GetByEmail(getByEmailInput); err != nil {
+	if exists, err := uc.userService.GetByEmail(ctx, getByEmailInput); err != nil {
 		if err != user.ErrUserNotFound {
 			return err
 		}
@@ -48,49 +72,127 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserIn
 		return user.ErrUserAlreadyExists
 	}
 
-	signUpOutput, err := uc.auth.SignUp(ctx, input.SignUpInput)
-	if err != nil {
+	// The ID is only known once the auth provider signed the user up, a placeholder validates the rest up front,
+	// the attributes against the schema included.
+	createInput := input.CreateUserInput
+	createInput.ID = user.UserID(uuid.New())
+	if err := createInput.Validate(); err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := signUpOutput.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back sign up: %s", err)
-			}
-		}
-	}()
 
-	userId, err := user.ParseUserID(signUpOutput.Id)
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type: RegisterUserSaga,
+		Payload: registerPayload{
+			Username:   input.SignUpInput.Username,
+			Email:      input.CreateUserInput.Email,
+			Name:       input.CreateUserInput.Name,
+			Phone:      input.CreateUserInput.Phone,
+			Attributes: input.CreateUserInput.Attributes,
+		},
+		Secrets: map[string]string{"password": input.SignUpInput.Password},
+	})
+}
+
+func (uc *RegisterUserUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: RegisterUserSaga,
+		Steps: []saga.Step{
+			{Name: "sign_up", Action: uc.signUp, Compensate: uc.deleteSignUp, Inline: true},
+			{Name: "create_profile", Action: uc.createProfile, Compensate: uc.deleteProfile, Transactional: true},
+			{Name: "dispatch_registered", Action: uc.dispatchRegistered},
+		},
+	}
+}
+
+func (uc *RegisterUserUseCase) signUp(ctx context.Context, exec *saga.Execution) error {
+	var payload registerPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	password, _ := exec.Secret("password")
+
+	signUpOutput, err := uc.auth.SignUp(ctx, auth.SignUpInput{
+		Username: payload.Username,
+		Password: password,
+		Name:     payload.Name,
+	})
 	if err != nil {
 		return err
 	}
 
-	input.CreateUserInput.ID = userId
+	payload.UserID = signUpOutput.Id
+	return exec.Encode(payload)
+}
+
+// deleteSignUp deletes the auth provider user created by the saga.
+func (uc *RegisterUserUseCase) deleteSignUp(ctx context.Context, exec *saga.Execution) error {
+	var payload registerPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	return auth_usecases.DeleteSignedUpUser(ctx, uc.auth, payload.Username, payload.UserID)
+}
+
+func (uc *RegisterUserUseCase) createProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload registerPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
 
-	if err := input.CreateUserInput.Validate(); err != nil {
+	if _, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: payload.UserID}); err == nil {
+		return nil
+	} else if err != user.ErrUserNotFound {
 		return err
 	}
 
-	createOut, err := uc.userService.Create(&input.CreateUserInput)
+	userId, err := user.ParseUserID(payload.UserID)
+	if err != nil {
+		return err
+	}
+	createInput := &user.CreateUserInput{
+		ID:         userId,
+		Name:       payload.Name,
+		Email:      payload.Email,
+		Phone:      payload.Phone,
+		Attributes: payload.Attributes,
+		Schema:     uc.schema,
+	}
+	if err := createInput.Validate(); err != nil {
+		return err
+	}
+	_, err = uc.userService.Create(ctx, createInput)
+	return err
+}
+
+func (uc *RegisterUserUseCase) deleteProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload registerPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	userId, err := user.ParseUserID(payload.UserID)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := createOut.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back create user: %s", err)
-			}
-		}
-	}()
+
+	if _, err := uc.userService.Delete(ctx, &user.DeleteUserInput{ID: userId}); err != nil && err != user.ErrUserNotFound {
+		return err
+	}
+	return nil
+}
+
+// dispatchRegistered does not fail the saga, a failed dispatch is only logged.
+func (uc *RegisterUserUseCase) dispatchRegistered(ctx context.Context, exec *saga.Execution) error {
+	var payload registerPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
 
 	userRegisteredEvent := &user.UserRegisteredEvent{
-		Email:             input.CreateUserInput.Email,
+		Email:             payload.Email,
 		NeedsVerification: true,
 	}
-
 	if err := uc.events.Dispatch(userRegisteredEvent); err != nil {
 		uc.logger.Error("Error dispatching user registered event: %s", err)
 	}
-
 	return nil
 }
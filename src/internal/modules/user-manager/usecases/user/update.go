@@ -1,13 +1,21 @@
 package user
 
 import (
+	"auth-api/src/internal/modules/user-manager/domain/auth"
 	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/saga/domain/saga"
 	"auth-api/src/pkg/logger"
 	"context"
 )
 
+// UpdateUserSaga renames the user in the auth provider, then updates the profile.
+const UpdateUserSaga saga.Type = "user.update"
+
 type UpdateUserUseCase struct {
 	userService user.UserService
+	auth        auth.AuthService
+	schema      *user.AttributeSchema
+	sagas       saga.SagaService
 	logger      logger.Logger
 }
 
@@ -15,29 +23,152 @@ type UpdateUserInput struct {
 	user.UpdateUserInput
 }
 
-func NewUpdateUserUseCase(userService user.UserService, logger logger.Logger) *UpdateUserUseCase {
-	return &UpdateUserUseCase{
+// updateUserPayload is the stored state of a profile update, PreviousName restores the auth provider name.
+type updateUserPayload struct {
+	UserID       string          `json:"userId"`
+	Username     string          `json:"username"`
+	Name         *string         `json:"name,omitempty"`
+	Phone        *string         `json:"phone,omitempty"`
+	Attributes   user.Attributes `json:"attributes,omitempty"`
+	PreviousName string          `json:"previousName"`
+}
+
+func NewUpdateUserUseCase(userService user.UserService, auth auth.AuthService, schema *user.AttributeSchema, sagas saga.SagaService, logger logger.Logger) *UpdateUserUseCase {
+	uc := &UpdateUserUseCase{
 		userService: userService,
+		auth:        auth,
+		schema:      schema,
+		sagas:       sagas,
 		logger:      logger,
 	}
+	sagas.Register(uc.saga())
+	return uc
 }
 
-func (uc *UpdateUserUseCase) Execute(ctx context.Context, input UpdateUserInput) (execErr error) {
+// Execute updates the profile, the email is changed through RequestEmailChange so the auth provider stays in sync.
+func (uc *UpdateUserUseCase) Execute(ctx context.Context, input UpdateUserInput) error {
+	input.Schema, input.Admin = uc.schema, false
 	if err := input.UpdateUserInput.Validate(); err != nil {
 		return err
 	}
+	if input.Email != nil {
+		return user.ErrEmailChangeNotAllowed
+	}
+
+	current, err := uc.userService.GetByID(ctx, &user.GetUserInput{ID: input.ID.String()})
+	if err != nil {
+		return err
+	}
+
+	return uc.sagas.Start(ctx, saga.StartInput{
+		Type: UpdateUserSaga,
+		Payload: updateUserPayload{
+			UserID:       input.ID.String(),
+			Username:     current.Email,
+			Name:         input.Name,
+			Phone:        input.Phone,
+			Attributes:   input.Attributes,
+			PreviousName: current.Name,
+		},
+	})
+}
+
+func (uc *UpdateUserUseCase) saga() saga.Definition {
+	return saga.Definition{
+		Type: UpdateUserSaga,
+		Steps: []saga.Step{
+			{Name: "update_auth_name", Action: uc.updateAuthName, Compensate: uc.restoreAuthName},
+			// The profile is updated last, in the transaction recording the step, so it needs no compensation.
+			{Name: "update_profile", Action: uc.updateProfile, Transactional: true},
+		},
+	}
+}
+
+func (uc *UpdateUserUseCase) updateAuthName(ctx context.Context, exec *saga.Execution) error {
+	var payload updateUserPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Name == nil || *payload.Name == payload.PreviousName {
+		return nil
+	}
+	return uc.auth.UpdateName(ctx, auth.UpdateNameInput{Username: payload.Username, Name: *payload.Name})
+}
+
+func (uc *UpdateUserUseCase) restoreAuthName(ctx context.Context, exec *saga.Execution) error {
+	var payload updateUserPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	if payload.Name == nil || *payload.Name == payload.PreviousName {
+		return nil
+	}
+	return uc.auth.UpdateName(ctx, auth.UpdateNameInput{Username: payload.Username, Name: payload.PreviousName})
+}
 
-	updateOut, err := uc.userService.Update(&input.UpdateUserInput)
+func (uc *UpdateUserUseCase) updateProfile(ctx context.Context, exec *saga.Execution) error {
+	var payload updateUserPayload
+	if err := exec.Decode(&payload); err != nil {
+		return err
+	}
+	userId, err := user.ParseUserID(payload.UserID)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if execErr != nil {
-			if err := updateOut.Rollback(ctx); err != nil {
-				uc.logger.Error("Error rolling back update user: %s", err)
-			}
-		}
-	}()
 
-	return nil
+	_, err = uc.userService.Update(ctx, &user.UpdateUserInput{
+		ID:         userId,
+		Name:       payload.Name,
+		Phone:      payload.Phone,
+		Attributes: payload.Attributes,
+		Schema:     uc.schema,
+	})
+	return err
+}
+
+type AdminUpdateUserUseCase struct {
+	accounts    *accounts
+	userService user.UserService
+	schema      *user.AttributeSchema
+}
+
+// AdminUpdateUserInput updates the profile of UpdateUserInput.ID, PerformedBy is the ID of the acting admin and
+// PerformerRoles the roles of its access token.
+type AdminUpdateUserInput struct {
+	user.UpdateUserInput
+	PerformedBy    string
+	PerformerRoles []string
+}
+
+func NewAdminUpdateUserUseCase(accounts *accounts, userService user.UserService, schema *user.AttributeSchema) *AdminUpdateUserUseCase {
+	return &AdminUpdateUserUseCase{
+		accounts:    accounts,
+		userService: userService,
+		schema:      schema,
+	}
+}
+
+// Execute updates the profile of any user, including the admin-only attributes. Like the lifecycle actions,
+// admins cannot update their own profile here and only holders of admins:write update an admin's.
+func (uc *AdminUpdateUserUseCase) Execute(ctx context.Context, input AdminUpdateUserInput) (*user.User, error) {
+	input.Schema, input.Admin = uc.schema, true
+	if err := input.UpdateUserInput.Validate(); err != nil {
+		return nil, err
+	}
+	if input.Email != nil {
+		return nil, user.ErrEmailChangeNotAllowed
+	}
+
+	if _, err := uc.accounts.find(ctx, AccountInput{
+		ID:             input.ID.String(),
+		PerformedBy:    input.PerformedBy,
+		PerformerRoles: input.PerformerRoles,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.userService.Update(ctx, &input.UpdateUserInput); err != nil {
+		return nil, err
+	}
+	return uc.userService.GetByID(ctx, &user.GetUserInput{ID: input.ID.String()})
 }
@@ -2,19 +2,99 @@ package user
 
 import (
 	"auth-api/src/internal/events"
+	"auth-api/src/internal/modules/user-manager/domain/admin"
 	"auth-api/src/internal/modules/user-manager/domain/auth"
+	"auth-api/src/internal/modules/user-manager/domain/organization"
+	"auth-api/src/internal/modules/user-manager/domain/role"
 	"auth-api/src/internal/modules/user-manager/domain/user"
+	"auth-api/src/internal/shared/code/domain/code"
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/internal/shared/notification/domain/email"
+	"auth-api/src/internal/shared/notification/domain/sms"
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
 	"auth-api/src/pkg/logger"
+	"time"
 )
 
 type UseCases struct {
-	Register *RegisterUserUseCase
-	Update   *UpdateUserUseCase
+	Register    *RegisterUserUseCase
+	Update      *UpdateUserUseCase
+	AdminUpdate *AdminUpdateUserUseCase
+	List        *ListUsersUseCase
+	Get         *GetUserUseCase
+
+	Disable            *DisableUserUseCase
+	Enable             *EnableUserUseCase
+	ForcePasswordReset *ForcePasswordResetUseCase
+	Confirm            *ConfirmUserUseCase
+	ResendConfirmation *ResendConfirmationUseCase
+	Delete             *DeleteUserUseCase
+
+	DeleteAccount           *DeleteAccountUseCase
+	GetAccountDeletion      *GetAccountDeletionUseCase
+	CancelAccountDeletion   *CancelAccountDeletionUseCase
+	ProcessAccountDeletions *ProcessAccountDeletionsUseCase
+
+	RequestEmailChange *RequestEmailChangeUseCase
+	ConfirmEmailChange *ConfirmEmailChangeUseCase
+	CancelEmailChange  *CancelEmailChangeUseCase
+
+	SendPhoneVerification *SendPhoneVerificationUseCase
+	VerifyPhone           *VerifyPhoneUseCase
+
+	Import *ImportUsersUseCase
+	Export *ExportUsersUseCase
+}
+
+type Options struct {
+	// DeletionGracePeriod delays self-service account deletions, 0 deletes right away.
+	DeletionGracePeriod time.Duration
+	// AttributeSchema validates the custom profile attributes.
+	AttributeSchema *user.AttributeSchema
+	// Import configures the bulk user import.
+	Import ImportOptions
 }
 
-func NewUseCases(userService user.UserService, authService auth.AuthService, logger logger.Logger, events events.EventDispatcher) *UseCases {
+func NewUseCases(userService user.UserService, adminService admin.AdminService, authService auth.AuthService, denylistService denylist.DenylistService, codeService code.CodeService, smsService sms.SmsService, emailService email.EmailService, sagaService saga.SagaService, roleService role.RoleService, members organization.MemberRepository, transactions transaction.UnitOfWork, deletions user.DeletionRepository, emailChanges user.EmailChangeRepository, phoneSends user.PhoneVerificationRepository, options Options, logger logger.Logger, events events.EventDispatcher) *UseCases {
+	accounts := &accounts{
+		userService:     userService,
+		adminService:    adminService,
+		authService:     authService,
+		denylistService: denylistService,
+		roleService:     roleService,
+		members:         members,
+		events:          events,
+		logger:          logger,
+	}
+
 	return &UseCases{
-		Register: NewRegisterUserUseCase(userService, authService, logger, events),
-		Update:   NewUpdateUserUseCase(userService, logger),
+		Register:    NewRegisterUserUseCase(userService, authService, options.AttributeSchema, sagaService, logger, events),
+		Update:      NewUpdateUserUseCase(userService, authService, options.AttributeSchema, sagaService, logger),
+		AdminUpdate: NewAdminUpdateUserUseCase(accounts, userService, options.AttributeSchema),
+		List:        NewListUsersUseCase(userService, authService, logger),
+		Get:         NewGetUserUseCase(userService, authService),
+
+		Disable:            NewDisableUserUseCase(accounts, authService, denylistService),
+		Enable:             NewEnableUserUseCase(accounts, authService),
+		ForcePasswordReset: NewForcePasswordResetUseCase(accounts, authService, denylistService),
+		Confirm:            NewConfirmUserUseCase(accounts, authService),
+		ResendConfirmation: NewResendConfirmationUseCase(accounts, authService, logger),
+		Delete:             NewDeleteUserUseCase(accounts),
+
+		DeleteAccount:           NewDeleteAccountUseCase(accounts, deletions, options.DeletionGracePeriod),
+		GetAccountDeletion:      NewGetAccountDeletionUseCase(deletions),
+		CancelAccountDeletion:   NewCancelAccountDeletionUseCase(accounts, deletions),
+		ProcessAccountDeletions: NewProcessAccountDeletionsUseCase(accounts, deletions),
+
+		RequestEmailChange: NewRequestEmailChangeUseCase(userService, authService, emailChanges, logger),
+		ConfirmEmailChange: NewConfirmEmailChangeUseCase(userService, authService, denylistService, emailChanges, members, sagaService, events, logger),
+		CancelEmailChange:  NewCancelEmailChangeUseCase(emailChanges),
+
+		SendPhoneVerification: NewSendPhoneVerificationUseCase(userService, codeService, smsService, phoneSends, logger),
+		VerifyPhone:           NewVerifyPhoneUseCase(userService, codeService),
+
+		Import: NewImportUsersUseCase(userService, authService, emailService, options.AttributeSchema, sagaService, options.Import, logger),
+		Export: NewExportUsersUseCase(userService, authService, logger),
 	}
 }
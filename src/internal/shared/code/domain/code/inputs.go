@@ -42,3 +42,14 @@ func (input *VerifyCodeInput) Validate() error {
 	}
 	return nil
 }
+
+type PurgeInput struct {
+	Identifier string
+}
+
+func (input *PurgeInput) Validate() error {
+	if len(input.Identifier) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Identifier is required", fmt.Sprintf("Field: %s", "Identifier"))
+	}
+	return nil
+}
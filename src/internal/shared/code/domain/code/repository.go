@@ -6,4 +6,5 @@ type CodeRepository interface {
 	Save(ctx context.Context, code *Code) error
 	FindCode(ctx context.Context, identifier, code string) (*Code, error)
 	Delete(ctx context.Context, code *Code) error
+	DeleteAll(ctx context.Context, identifier string) error
 }
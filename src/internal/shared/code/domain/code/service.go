@@ -5,4 +5,6 @@ import "context"
 type CodeService interface {
 	GenerateAndSave(ctx context.Context, input GenerateAndSaveInput) (*Code, error)
 	VerifyCode(ctx context.Context, input VerifyCodeInput) error
+	// Purge deletes every outstanding code of the identifier.
+	Purge(ctx context.Context, input PurgeInput) error
 }
@@ -91,3 +91,36 @@ func (r *CodeRepositoryDynamoDB) Delete(ctx context.Context, code *code.Code) er
 	})
 	return err
 }
+
+func (r *CodeRepositoryDynamoDB) DeleteAll(ctx context.Context, identifier string) error {
+	paginator := dynamodb.NewQueryPaginator(r.dynamoDBClient, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("identifier = :identifier"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":identifier": &types.AttributeValueMemberS{Value: identifier},
+		},
+		ProjectionExpression: aws.String("identifier, code"),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error("failed to query codes: %v", err)
+			return err
+		}
+		for _, item := range page.Items {
+			_, err := r.dynamoDBClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(r.tableName),
+				Key: map[string]types.AttributeValue{
+					"identifier": item["identifier"],
+					"code":       item["code"],
+				},
+			})
+			if err != nil {
+				r.logger.Error("failed to delete code: %v", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
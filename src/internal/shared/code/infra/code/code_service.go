@@ -68,3 +68,11 @@ func (s *CodeServiceImpl) VerifyCode(ctx context.Context, input code.VerifyCodeI
 
 	return nil
 }
+
+func (s *CodeServiceImpl) Purge(ctx context.Context, input code.PurgeInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return s.codeRepo.DeleteAll(ctx, input.Identifier)
+}
@@ -0,0 +1,30 @@
+package denylist
+
+import "time"
+
+// Entry denies the tokens matching Key that were issued before IssuedBefore, until ExpiresAt.
+type Entry struct {
+	Key          string
+	IssuedBefore time.Time
+	ExpiresAt    time.Time
+}
+
+func (e *Entry) IsExpired() bool {
+	return e.ExpiresAt.Before(time.Now())
+}
+
+// Denies compares at the second precision of the iat claim, IssuedBefore is rounded up to the next second when saved so
+// the tokens issued in the second of the revocation are denied too.
+func (e *Entry) Denies(issuedAt time.Time) bool {
+	return !e.IsExpired() && issuedAt.Truncate(time.Second).Before(e.IssuedBefore.Truncate(time.Second))
+}
+
+// JtiKey matches a token by its jti, or all the tokens of a session by their origin_jti.
+func JtiKey(jti string) string {
+	return "jti#" + jti
+}
+
+// SubjectKey matches every token of a user.
+func SubjectKey(subject string) string {
+	return "sub#" + subject
+}
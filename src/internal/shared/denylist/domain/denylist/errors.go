@@ -0,0 +1,7 @@
+package denylist
+
+import "auth-api/src/pkg/app_error"
+
+var (
+	ErrTokenRevoked = app_error.NewApiError(401, "Token revoked")
+)
@@ -0,0 +1,61 @@
+package denylist
+
+import (
+	"auth-api/src/pkg/app_error"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type DenyTokenInput struct {
+	// Jti is the jti of a single token or the origin_jti of a whole session.
+	Jti string
+	// ExpiresAt defaults to the longest access token lifetime, for sessions whose tokens expire at different times.
+	ExpiresAt time.Time
+}
+
+func (input *DenyTokenInput) Validate() error {
+	if len(input.Jti) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Jti is required", fmt.Sprintf("Field: %s", "Jti"))
+	}
+	return nil
+}
+
+type DenySubjectInput struct {
+	Subject string
+}
+
+func (input *DenySubjectInput) Validate() error {
+	if len(input.Subject) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Subject is required", fmt.Sprintf("Field: %s", "Subject"))
+	}
+	return nil
+}
+
+type IsDeniedInput struct {
+	Jti       string
+	OriginJti string
+	Subject   string
+	IssuedAt  time.Time
+}
+
+func (input *IsDeniedInput) Validate() error {
+	if len(input.Jti) == 0 && len(input.OriginJti) == 0 && len(input.Subject) == 0 {
+		return app_error.NewApiError(http.StatusBadRequest, "Jti, OriginJti or Subject is required")
+	}
+	return nil
+}
+
+func (input *IsDeniedInput) Keys() []string {
+	keys := make([]string, 0, 3)
+	if input.Jti != "" {
+		keys = append(keys, JtiKey(input.Jti))
+	}
+	if input.OriginJti != "" {
+		keys = append(keys, JtiKey(input.OriginJti))
+	}
+	if input.Subject != "" {
+		keys = append(keys, SubjectKey(input.Subject))
+	}
+	return keys
+}
@@ -0,0 +1,10 @@
+package denylist
+
+import "context"
+
+type DenylistRepository interface {
+	// Save keeps the entry with the latest IssuedBefore and ExpiresAt when the key is already denied.
+	Save(ctx context.Context, entry *Entry) error
+	// Find returns the live entries among keys.
+	Find(ctx context.Context, keys []string) ([]Entry, error)
+}
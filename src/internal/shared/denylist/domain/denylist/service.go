@@ -0,0 +1,10 @@
+package denylist
+
+import "context"
+
+type DenylistService interface {
+	DenyToken(ctx context.Context, input DenyTokenInput) error
+	// DenySubject denies every token already issued to the user, for as long as an access token can live.
+	DenySubject(ctx context.Context, input DenySubjectInput) error
+	IsDenied(ctx context.Context, input IsDeniedInput) (bool, error)
+}
@@ -0,0 +1,141 @@
+package denylist
+
+import (
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DenylistRepositoryDynamoDB expects a table keyed by "key", with TTL enabled on "expires_at".
+type DenylistRepositoryDynamoDB struct {
+	dynamoDBClient *dynamodb.Client
+	tableName      string
+	logger         logger.Logger
+}
+
+func NewDenylistRepositoryDynamoDB(tableName string, dynamoClient *dynamodb.Client, logger logger.Logger) denylist.DenylistRepository {
+	return &DenylistRepositoryDynamoDB{
+		dynamoDBClient: dynamoClient,
+		tableName:      tableName,
+		logger:         logger,
+	}
+}
+
+func (r *DenylistRepositoryDynamoDB) Save(ctx context.Context, entry *denylist.Entry) error {
+	issuedBefore := &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", entry.IssuedBefore.Unix())}
+	expiresAt := &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", entry.ExpiresAt.Unix())}
+
+	// Only move the bounds forward, a later and shorter denial must not shorten an existing one
+	_, err := r.dynamoDBClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"key": &types.AttributeValueMemberS{Value: entry.Key},
+		},
+		UpdateExpression:    aws.String("SET issued_before = :issued_before, expires_at = :expires_at"),
+		ConditionExpression: aws.String("attribute_not_exists(#key) OR (issued_before <= :issued_before AND expires_at <= :expires_at)"),
+		ExpressionAttributeNames: map[string]string{
+			"#key": "key",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":issued_before": issuedBefore,
+			":expires_at":    expiresAt,
+		},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return nil
+		}
+		r.logger.Error("Error saving denylist entry: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *DenylistRepositoryDynamoDB) Find(ctx context.Context, keys []string) ([]denylist.Entry, error) {
+	if len(keys) == 0 {
+		return []denylist.Entry{}, nil
+	}
+
+	requestKeys := make([]map[string]types.AttributeValue, 0, len(keys))
+	for _, key := range keys {
+		requestKeys = append(requestKeys, map[string]types.AttributeValue{
+			"key": &types.AttributeValueMemberS{Value: key},
+		})
+	}
+
+	result, err := r.dynamoDBClient.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]types.KeysAndAttributes{
+			r.tableName: {
+				Keys:           requestKeys,
+				ConsistentRead: aws.Bool(true),
+			},
+		},
+	})
+	if err != nil {
+		r.logger.Error("Error finding denylist entries: %v", err)
+		return nil, err
+	}
+	if len(result.UnprocessedKeys) > 0 {
+		err := fmt.Errorf("denylist lookup throttled")
+		r.logger.Error("Error finding denylist entries: %v", err)
+		return nil, err
+	}
+
+	entries := []denylist.Entry{}
+	for _, item := range result.Responses[r.tableName] {
+		entry, err := r.entryFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		// DynamoDB TTL deletion is lazy, expired items can still be returned
+		if !entry.IsExpired() {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *DenylistRepositoryDynamoDB) entryFromItem(item map[string]types.AttributeValue) (*denylist.Entry, error) {
+	key, ok := item["key"].(*types.AttributeValueMemberS)
+	if !ok {
+		r.logger.Error("Error reading denylist entry: missing key")
+		return nil, fmt.Errorf("denylist entry without key")
+	}
+	issuedBefore, err := r.unixFromItem(item, "issued_before")
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := r.unixFromItem(item, "expires_at")
+	if err != nil {
+		return nil, err
+	}
+
+	return &denylist.Entry{
+		Key:          key.Value,
+		IssuedBefore: issuedBefore,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (r *DenylistRepositoryDynamoDB) unixFromItem(item map[string]types.AttributeValue, name string) (time.Time, error) {
+	value, ok := item[name].(*types.AttributeValueMemberN)
+	if !ok {
+		r.logger.Error("Error reading denylist entry: missing %s", name)
+		return time.Time{}, fmt.Errorf("denylist entry without %s", name)
+	}
+	seconds, err := strconv.ParseInt(value.Value, 10, 64)
+	if err != nil {
+		r.logger.Error("failed to parse time: %v", err)
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,62 @@
+package denylist
+
+import (
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"context"
+	"sync"
+)
+
+// DenylistRepositoryMemory keeps the entries in the process, only suitable for a single instance.
+type DenylistRepositoryMemory struct {
+	mu      sync.RWMutex
+	entries map[string]denylist.Entry
+}
+
+func NewDenylistRepositoryMemory() denylist.DenylistRepository {
+	return &DenylistRepositoryMemory{
+		entries: make(map[string]denylist.Entry),
+	}
+}
+
+func (r *DenylistRepositoryMemory) Save(ctx context.Context, entry *denylist.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteExpired()
+
+	saved, ok := r.entries[entry.Key]
+	if !ok {
+		r.entries[entry.Key] = *entry
+		return nil
+	}
+	if entry.IssuedBefore.After(saved.IssuedBefore) {
+		saved.IssuedBefore = entry.IssuedBefore
+	}
+	if entry.ExpiresAt.After(saved.ExpiresAt) {
+		saved.ExpiresAt = entry.ExpiresAt
+	}
+	r.entries[entry.Key] = saved
+	return nil
+}
+
+func (r *DenylistRepositoryMemory) Find(ctx context.Context, keys []string) ([]denylist.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []denylist.Entry{}
+	for _, key := range keys {
+		if entry, ok := r.entries[key]; ok && !entry.IsExpired() {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// deleteExpired must be called with mu held.
+func (r *DenylistRepositoryMemory) deleteExpired() {
+	for key, entry := range r.entries {
+		if entry.IsExpired() {
+			delete(r.entries, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package denylist
+
+import (
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+type DenylistRepositoryPostgres struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewDenylistRepositoryPostgres(db *sql.DB, logger logger.Logger) denylist.DenylistRepository {
+	return &DenylistRepositoryPostgres{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *DenylistRepositoryPostgres) Save(ctx context.Context, entry *denylist.Entry) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM token_denylist WHERE expires_at < NOW()`); err != nil {
+		r.logger.Error("Error deleting expired denylist entries: %v", err)
+		return err
+	}
+
+	query := `INSERT INTO token_denylist (key, issued_before, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			issued_before = GREATEST(token_denylist.issued_before, EXCLUDED.issued_before),
+			expires_at = GREATEST(token_denylist.expires_at, EXCLUDED.expires_at)`
+	if _, err := r.db.ExecContext(ctx, query, entry.Key, entry.IssuedBefore, entry.ExpiresAt); err != nil {
+		r.logger.Error("Error saving denylist entry: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *DenylistRepositoryPostgres) Find(ctx context.Context, keys []string) ([]denylist.Entry, error) {
+	query := `SELECT key, issued_before, expires_at FROM token_denylist WHERE key = ANY($1) AND expires_at > NOW()`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(keys))
+	if err != nil {
+		r.logger.Error("Error finding denylist entries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []denylist.Entry{}
+	for rows.Next() {
+		var entry denylist.Entry
+		if err := rows.Scan(&entry.Key, &entry.IssuedBefore, &entry.ExpiresAt); err != nil {
+			r.logger.Error("Error scanning denylist entry: %v", err)
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,74 @@
+package denylist
+
+import (
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"time"
+)
+
+type DenylistServiceImpl struct {
+	repo denylist.DenylistRepository
+	// tokenTTL is the longest lifetime of an access token, entries are useless after it.
+	tokenTTL time.Duration
+	logger   logger.Logger
+}
+
+func NewDenylistServiceImpl(repo denylist.DenylistRepository, tokenTTL time.Duration, logger logger.Logger) denylist.DenylistService {
+	return &DenylistServiceImpl{
+		repo:     repo,
+		tokenTTL: tokenTTL,
+		logger:   logger,
+	}
+}
+
+func (s *DenylistServiceImpl) DenyToken(ctx context.Context, input denylist.DenyTokenInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := input.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(s.tokenTTL)
+	}
+
+	// Every token carrying the jti was issued before it expires, whatever its iat
+	return s.repo.Save(ctx, &denylist.Entry{
+		Key:          denylist.JtiKey(input.Jti),
+		IssuedBefore: expiresAt,
+		ExpiresAt:    expiresAt,
+	})
+}
+
+func (s *DenylistServiceImpl) DenySubject(ctx context.Context, input denylist.DenySubjectInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	// The iat claim only has a second precision, rounding up denies the tokens issued earlier in the same second
+	now := time.Now()
+	return s.repo.Save(ctx, &denylist.Entry{
+		Key:          denylist.SubjectKey(input.Subject),
+		IssuedBefore: now.Truncate(time.Second).Add(time.Second),
+		ExpiresAt:    now.Add(s.tokenTTL),
+	})
+}
+
+func (s *DenylistServiceImpl) IsDenied(ctx context.Context, input denylist.IsDeniedInput) (bool, error) {
+	if err := input.Validate(); err != nil {
+		return false, err
+	}
+
+	entries, err := s.repo.Find(ctx, input.Keys())
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if entry.Denies(input.IssuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,60 @@
+package denylist_test
+
+import (
+	"auth-api/src/internal/shared/denylist/domain/denylist"
+	denylist_infra "auth-api/src/internal/shared/denylist/infra/denylist"
+	"auth-api/src/pkg/logger"
+	"context"
+	"testing"
+	"time"
+)
+
+func newService(t *testing.T) denylist.DenylistService {
+	t.Helper()
+	log, err := logger.NewLogger("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return denylist_infra.NewDenylistServiceImpl(denylist_infra.NewDenylistRepositoryMemory(), time.Hour, log)
+}
+
+func isDenied(t *testing.T, service denylist.DenylistService, subject string, issuedAt time.Time) bool {
+	t.Helper()
+	denied, err := service.IsDenied(context.Background(), denylist.IsDeniedInput{Subject: subject, IssuedAt: issuedAt})
+	if err != nil {
+		t.Fatalf("IsDenied: %v", err)
+	}
+	return denied
+}
+
+func TestDenySubjectDeniesTheTokensIssuedInTheSameSecond(t *testing.T) {
+	service := newService(t)
+	// The iat claim of a token minted just before the logout, in the same second.
+	issuedAt := time.Now().Truncate(time.Second)
+
+	if err := service.DenySubject(context.Background(), denylist.DenySubjectInput{Subject: "user-1"}); err != nil {
+		t.Fatalf("DenySubject: %v", err)
+	}
+
+	if !isDenied(t, service, "user-1", issuedAt) {
+		t.Error("a token issued in the second of the revocation is still valid")
+	}
+	if !isDenied(t, service, "user-1", issuedAt.Add(-time.Minute)) {
+		t.Error("a token issued before the revocation is still valid")
+	}
+	if isDenied(t, service, "user-2", issuedAt) {
+		t.Error("the token of another subject is denied")
+	}
+}
+
+func TestDenySubjectKeepsTheTokensIssuedAfterTheRevocation(t *testing.T) {
+	service := newService(t)
+
+	if err := service.DenySubject(context.Background(), denylist.DenySubjectInput{Subject: "user-1"}); err != nil {
+		t.Fatalf("DenySubject: %v", err)
+	}
+
+	if isDenied(t, service, "user-1", time.Now().Truncate(time.Second).Add(time.Second)) {
+		t.Error("a token issued in the next second is denied")
+	}
+}
@@ -0,0 +1,8 @@
+package sms
+
+import "auth-api/src/pkg/app_error"
+
+var (
+	ErrSmsToEmpty   = app_error.NewApiError(400, "Sms to is empty")
+	ErrSmsBodyEmpty = app_error.NewApiError(400, "Sms body is empty")
+)
@@ -0,0 +1,17 @@
+package sms
+
+type Sms struct {
+	// To is an E.164 phone number.
+	To   string
+	Body string
+}
+
+func (s Sms) Validate() error {
+	if s.To == "" {
+		return ErrSmsToEmpty
+	}
+	if s.Body == "" {
+		return ErrSmsBodyEmpty
+	}
+	return nil
+}
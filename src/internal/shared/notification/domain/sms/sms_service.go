@@ -0,0 +1,7 @@
+package sms
+
+import "context"
+
+type SmsService interface {
+	SendSms(ctx context.Context, sms Sms) error
+}
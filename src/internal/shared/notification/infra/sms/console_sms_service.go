@@ -0,0 +1,27 @@
+package sms
+
+import (
+	"auth-api/src/internal/shared/notification/domain/sms"
+	"auth-api/src/pkg/logger"
+	"context"
+)
+
+// ConsoleSmsService writes the messages to the logs instead of sending them, for local use.
+type ConsoleSmsService struct {
+	logger logger.Logger
+}
+
+func NewConsoleSmsService(logger logger.Logger) sms.SmsService {
+	return &ConsoleSmsService{
+		logger: logger,
+	}
+}
+
+func (s *ConsoleSmsService) SendSms(ctx context.Context, input sms.Sms) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	s.logger.Info("sms to %s: %s", input.To, input.Body)
+	return nil
+}
@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"auth-api/src/internal/shared/notification/domain/sms"
+	"auth-api/src/pkg/logger"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSmsService appends the messages to a file as JSON lines instead of sending them, for local use and end to end tests.
+type FileSmsService struct {
+	mu     sync.Mutex
+	path   string
+	logger logger.Logger
+}
+
+type fileSms struct {
+	To     string    `json:"to"`
+	Body   string    `json:"body"`
+	SentAt time.Time `json:"sentAt"`
+}
+
+func NewFileSmsService(path string, logger logger.Logger) sms.SmsService {
+	return &FileSmsService{
+		path:   path,
+		logger: logger,
+	}
+}
+
+func (s *FileSmsService) SendSms(ctx context.Context, input sms.Sms) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(fileSms{To: input.To, Body: input.Body, SentAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		s.logger.Error("failed to open sms file: %v", err)
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		s.logger.Error("failed to write sms: %v", err)
+		return err
+	}
+	return nil
+}
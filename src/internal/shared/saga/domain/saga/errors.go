@@ -0,0 +1,17 @@
+package saga
+
+import "auth-api/src/pkg/app_error"
+
+var (
+	ErrSagaNotFound       = app_error.NewApiError(404, "Saga not found")
+	ErrInvalidSagaID      = app_error.NewApiError(400, "Invalid saga ID", "Field: id")
+	ErrInvalidSagaStatus  = app_error.NewApiError(400, "Invalid saga status", "Field: status")
+	ErrInvalidCursor      = app_error.NewApiError(400, "Invalid cursor", "Field: cursor")
+	ErrUnknownSagaType    = app_error.NewApiError(500, "Unknown saga type")
+	ErrSagaNotResumable   = app_error.NewApiError(409, "Only running, compensating or failed sagas that are not being executed can be resumed")
+	ErrSagaNotCompensable = app_error.NewApiError(409, "Only running or failed sagas that are not being executed can be compensated")
+	// ErrSagaLeaseLost stops a process whose lease expired and was claimed by another one, which now runs the saga.
+	ErrSagaLeaseLost = app_error.NewApiError(409, "The saga was taken over by another process")
+	// ErrSecretsRequired stops the worker before an inline step, the saga is compensated instead.
+	ErrSecretsRequired = app_error.NewApiError(500, "The step needs the secrets of the call starting the saga")
+)
@@ -0,0 +1,100 @@
+package saga
+
+import (
+	"auth-api/src/pkg/app_error"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+type StartInput struct {
+	Type Type
+	// Payload is stored as JSON and shared by the steps.
+	Payload interface{}
+	// Secrets are only given to the steps run by Start, they are never stored.
+	Secrets map[string]string
+}
+
+func (input *StartInput) Validate() error {
+	if input.Type == "" {
+		return ErrUnknownSagaType
+	}
+	return nil
+}
+
+type GetSagaInput struct {
+	ID string
+}
+
+func (input *GetSagaInput) Validate() error {
+	return validateID(input.ID)
+}
+
+type ListSagasInput struct {
+	Status Status
+	Type   Type
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+func (input *ListSagasInput) Validate() error {
+	if input.Limit == 0 {
+		input.Limit = DefaultListLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxListLimit {
+		return app_error.NewApiError(http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", MaxListLimit), fmt.Sprintf("Field: %s", "Limit"))
+	}
+	switch input.Status {
+	case "", StatusRunning, StatusCompensating, StatusCompleted, StatusCompensated, StatusFailed:
+	default:
+		return ErrInvalidSagaStatus
+	}
+	if input.Cursor != "" {
+		if _, err := ParseCursor(input.Cursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResumeSagaInput retries a saga right away, from where it stopped.
+type ResumeSagaInput struct {
+	ID string
+}
+
+func (input *ResumeSagaInput) Validate() error {
+	return validateID(input.ID)
+}
+
+// CompensateSagaInput gives up on a saga and undoes its completed steps.
+type CompensateSagaInput struct {
+	ID string
+}
+
+func (input *CompensateSagaInput) Validate() error {
+	return validateID(input.ID)
+}
+
+func validateID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrInvalidSagaID
+	}
+	return nil
+}
+
+// ParseCursor reads the creation time of the last saga of a page.
+func ParseCursor(cursor string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, cursor)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+type SagaRepository interface {
+	// Create stores the saga with its steps, locked by the caller for lease under a new LockedBy.
+	Create(ctx context.Context, saga *Saga, lease time.Duration) error
+	Get(ctx context.Context, input *GetSagaInput) (*Saga, error)
+	List(ctx context.Context, input *ListSagasInput) (*SagaPage, error)
+	// Save stores the state of the saga and locks it for lease, a zero lease releases it. Save and SaveStep
+	// fail with ErrSagaLeaseLost once the saga is no longer locked by saga.LockedBy.
+	Save(ctx context.Context, saga *Saga, lease time.Duration) error
+	SaveStep(ctx context.Context, saga *Saga, step *StepState) error
+	// Claim locks the next running or compensating saga whose lock expired and whose retry is due under a new
+	// LockedBy, it fails with ErrSagaNotFound when there is none.
+	Claim(ctx context.Context, lease time.Duration) (*Saga, error)
+	// Reset makes a saga that is not locked due right away with the status, if it currently has one of from.
+	// It fails with ErrSagaNotFound when the saga does not match.
+	Reset(ctx context.Context, id string, status Status, from []Status) error
+	// DeleteFinished deletes the completed and compensated sagas last updated before the time.
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,134 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type Type string
+
+type Status string
+
+const (
+	// StatusRunning sagas are executing their steps, or waiting for the worker to retry one.
+	StatusRunning Status = "running"
+	// StatusCompensating sagas are undoing their completed steps in reverse order.
+	StatusCompensating Status = "compensating"
+	StatusCompleted    Status = "completed"
+	StatusCompensated  Status = "compensated"
+	// StatusFailed sagas ran out of attempts and wait for an operator to resume or compensate them.
+	StatusFailed Status = "failed"
+)
+
+type StepStatus string
+
+const (
+	StepPending StepStatus = "pending"
+	// StepRunning is recorded before the action runs, a step left running has an unknown outcome.
+	StepRunning StepStatus = "running"
+	StepDone    StepStatus = "done"
+	StepFailed  StepStatus = "failed"
+	// StepCompensating is recorded before the compensation runs, and kept when it fails.
+	StepCompensating StepStatus = "compensating"
+	StepCompensated  StepStatus = "compensated"
+)
+
+// Saga is a multi-step operation recorded with the state of each step. Payload is the JSON state shared by the steps.
+type Saga struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	Status      Status          `json:"status"`
+	Payload     json.RawMessage `json:"payload"`
+	CurrentStep int             `json:"currentStep"`
+	Attempts    int             `json:"attempts"`
+	LastError   string          `json:"lastError,omitempty"`
+	LockedUntil *time.Time      `json:"lockedUntil,omitempty"`
+	// LockedBy identifies the claim holding the lease, only its holder saves the saga.
+	LockedBy      string      `json:"-"`
+	NextAttemptAt time.Time   `json:"nextAttemptAt"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+	Steps         []StepState `json:"steps,omitempty"`
+}
+
+type StepState struct {
+	Position  int        `json:"position"`
+	Name      string     `json:"name"`
+	Status    StepStatus `json:"status"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+// SagaPage is a page of sagas from the newest, NextCursor is empty on the last page.
+type SagaPage struct {
+	Sagas      []*Saga `json:"sagas"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// Step is one operation of a saga. Action and Compensate must be idempotent: the worker runs them again after a crash,
+// and Compensate also runs for a step left running, whose action may never have happened.
+type Step struct {
+	Name   string
+	Action func(ctx context.Context, exec *Execution) error
+	// Compensate undoes Action, nil when there is nothing to undo.
+	Compensate func(ctx context.Context, exec *Execution) error
+	// Inline steps need secrets, which are never stored. They only run in the call starting the saga: the worker
+	// compensates a saga stopped before one of them instead of running it.
+	Inline bool
+	// Transactional steps only write to the database. Their action and its compensation run in the transaction
+	// recording the step, so they happen exactly once.
+	Transactional bool
+}
+
+// CompensationStarted reports whether the saga started undoing its steps.
+func (s *Saga) CompensationStarted() bool {
+	for _, step := range s.Steps {
+		if step.Status == StepCompensating || step.Status == StepCompensated {
+			return true
+		}
+	}
+	return false
+}
+
+type Definition struct {
+	Type  Type
+	Steps []Step
+}
+
+// Execution gives the steps access to the payload of the saga and to the secrets of the call starting it.
+type Execution struct {
+	Saga    *Saga
+	secrets map[string]string
+}
+
+// NewExecution runs a saga with the secrets of the call starting it, or detached with nil secrets.
+func NewExecution(saga *Saga, secrets map[string]string) *Execution {
+	return &Execution{Saga: saga, secrets: secrets}
+}
+
+// Decode reads the payload into v.
+func (e *Execution) Decode(v interface{}) error {
+	return json.Unmarshal(e.Saga.Payload, v)
+}
+
+// Encode replaces the payload, it is stored when the step completes.
+func (e *Execution) Encode(v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e.Saga.Payload = payload
+	return nil
+}
+
+// Detached reports whether the execution runs without the secrets of the call starting the saga.
+func (e *Execution) Detached() bool {
+	return e.secrets == nil
+}
+
+// Secret returns a secret of the call starting the saga, the worker has none.
+func (e *Execution) Secret(name string) (string, bool) {
+	secret, ok := e.secrets[name]
+	return secret, ok
+}
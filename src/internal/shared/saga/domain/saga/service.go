@@ -0,0 +1,17 @@
+package saga
+
+import "context"
+
+type SagaService interface {
+	// Register adds a saga type, the definitions must be registered before the worker runs.
+	Register(definition Definition)
+	// Start records a saga and runs its steps. When a step fails the completed steps are compensated and the error
+	// of the step is returned, compensations that fail are retried by the worker.
+	Start(ctx context.Context, input StartInput) error
+	Get(ctx context.Context, input GetSagaInput) (*Saga, error)
+	List(ctx context.Context, input ListSagasInput) (*SagaPage, error)
+	Resume(ctx context.Context, input ResumeSagaInput) (*Saga, error)
+	Compensate(ctx context.Context, input CompensateSagaInput) (*Saga, error)
+	// ProcessDue runs the sagas left by stopped processes and retries the failed steps and compensations.
+	ProcessDue(ctx context.Context) error
+}
@@ -0,0 +1,258 @@
+package saga
+
+import (
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	transaction_infra "auth-api/src/internal/shared/transaction/infra/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SagaRepository struct {
+	db           *sql.DB
+	transactions transaction.UnitOfWork
+	logger       logger.Logger
+}
+
+func NewSagaRepository(db *sql.DB, transactions transaction.UnitOfWork, logger logger.Logger) saga.SagaRepository {
+	return &SagaRepository{
+		db:           db,
+		transactions: transactions,
+		logger:       logger,
+	}
+}
+
+const sagaColumns = `id, type, status, payload, current_step, attempts, last_error, locked_until, next_attempt_at, created_at, updated_at, locked_by`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSaga(row rowScanner) (*saga.Saga, error) {
+	var sg saga.Saga
+	var payload []byte
+	var lockedUntil sql.NullTime
+	var lockedBy sql.NullString
+	if err := row.Scan(&sg.ID, &sg.Type, &sg.Status, &payload, &sg.CurrentStep, &sg.Attempts, &sg.LastError, &lockedUntil, &sg.NextAttemptAt, &sg.CreatedAt, &sg.UpdatedAt, &lockedBy); err != nil {
+		return nil, err
+	}
+	sg.Payload, sg.LockedBy = payload, lockedBy.String
+	if lockedUntil.Valid {
+		sg.LockedUntil = &lockedUntil.Time
+	}
+	return &sg, nil
+}
+
+// executor joins the transaction of the context, if any.
+func (r *SagaRepository) executor(ctx context.Context) transaction_infra.Executor {
+	return transaction_infra.GetExecutor(ctx, r.db)
+}
+
+func (r *SagaRepository) Create(ctx context.Context, sg *saga.Saga, lease time.Duration) error {
+	sg.LockedBy = uuid.NewString()
+	return r.transactions.WithTx(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO sagas (id, type, status, payload, locked_until, locked_by) VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5), $6)
+			RETURNING current_step, attempts, next_attempt_at, created_at, updated_at, locked_until`
+		var lockedUntil time.Time
+		if err := r.executor(ctx).QueryRowContext(ctx, query, sg.ID, sg.Type, sg.Status, []byte(sg.Payload), lease.Seconds(), sg.LockedBy).
+			Scan(&sg.CurrentStep, &sg.Attempts, &sg.NextAttemptAt, &sg.CreatedAt, &sg.UpdatedAt, &lockedUntil); err != nil {
+			r.logger.Error("Error creating saga: %v", err)
+			return err
+		}
+		sg.LockedUntil = &lockedUntil
+
+		for _, step := range sg.Steps {
+			query := `INSERT INTO saga_steps (saga_id, position, name, status) VALUES ($1, $2, $3, $4)`
+			if _, err := r.executor(ctx).ExecContext(ctx, query, sg.ID, step.Position, step.Name, step.Status); err != nil {
+				r.logger.Error("Error creating saga step: %v", err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SagaRepository) Get(ctx context.Context, input *saga.GetSagaInput) (*saga.Saga, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + sagaColumns + ` FROM sagas WHERE id = $1`
+	sg, err := scanSaga(r.executor(ctx).QueryRowContext(ctx, query, input.ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, saga.ErrSagaNotFound
+		}
+		r.logger.Error("Error getting saga: %v", err)
+		return nil, err
+	}
+	if err := r.loadSteps(ctx, sg); err != nil {
+		return nil, err
+	}
+	return sg, nil
+}
+
+func (r *SagaRepository) loadSteps(ctx context.Context, sg *saga.Saga) error {
+	query := `SELECT position, name, status, attempts, last_error FROM saga_steps WHERE saga_id = $1 ORDER BY position`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, sg.ID)
+	if err != nil {
+		r.logger.Error("Error listing saga steps: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	sg.Steps = []saga.StepState{}
+	for rows.Next() {
+		var step saga.StepState
+		if err := rows.Scan(&step.Position, &step.Name, &step.Status, &step.Attempts, &step.LastError); err != nil {
+			r.logger.Error("Error scanning saga step: %v", err)
+			return err
+		}
+		sg.Steps = append(sg.Steps, step)
+	}
+	return rows.Err()
+}
+
+func (r *SagaRepository) List(ctx context.Context, input *saga.ListSagasInput) (*saga.SagaPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var before *time.Time
+	if input.Cursor != "" {
+		cursor, err := saga.ParseCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		before = &cursor
+	}
+
+	// Fetch one extra row to know whether there is a next page.
+	query := `SELECT ` + sagaColumns + ` FROM sagas
+		WHERE ($1::timestamptz IS NULL OR created_at < $1) AND ($2 = '' OR status = $2) AND ($3 = '' OR type = $3)
+		ORDER BY created_at DESC LIMIT $4`
+	rows, err := r.executor(ctx).QueryContext(ctx, query, before, string(input.Status), string(input.Type), input.Limit+1)
+	if err != nil {
+		r.logger.Error("Error listing sagas: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &saga.SagaPage{Sagas: []*saga.Saga{}}
+	for rows.Next() {
+		sg, err := scanSaga(rows)
+		if err != nil {
+			r.logger.Error("Error scanning saga: %v", err)
+			return nil, err
+		}
+		page.Sagas = append(page.Sagas, sg)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error listing sagas: %v", err)
+		return nil, err
+	}
+
+	if len(page.Sagas) > input.Limit {
+		page.Sagas = page.Sagas[:input.Limit]
+		page.NextCursor = page.Sagas[input.Limit-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	return page, nil
+}
+
+func (r *SagaRepository) Save(ctx context.Context, sg *saga.Saga, lease time.Duration) error {
+	query := `UPDATE sagas SET status = $1, payload = $2, current_step = $3, attempts = $4, last_error = $5, next_attempt_at = $6,
+		locked_until = CASE WHEN $7::float8 > 0 THEN NOW() + make_interval(secs => $7) END,
+		locked_by = CASE WHEN $7::float8 > 0 THEN locked_by END, updated_at = NOW()
+		WHERE id = $8 AND locked_by = $9`
+	res, err := r.executor(ctx).ExecContext(ctx, query, sg.Status, []byte(sg.Payload), sg.CurrentStep, sg.Attempts, sg.LastError, sg.NextAttemptAt, lease.Seconds(), sg.ID, sg.LockedBy)
+	if err != nil {
+		r.logger.Error("Error saving saga: %v", err)
+		return err
+	}
+	return checkOwned(res)
+}
+
+func (r *SagaRepository) SaveStep(ctx context.Context, sg *saga.Saga, step *saga.StepState) error {
+	query := `UPDATE saga_steps SET status = $1, attempts = $2, last_error = $3, updated_at = NOW()
+		WHERE saga_id = $4 AND position = $5 AND EXISTS (SELECT 1 FROM sagas WHERE id = $4 AND locked_by = $6)`
+	res, err := r.executor(ctx).ExecContext(ctx, query, step.Status, step.Attempts, step.LastError, sg.ID, step.Position, sg.LockedBy)
+	if err != nil {
+		r.logger.Error("Error saving saga step: %v", err)
+		return err
+	}
+	return checkOwned(res)
+}
+
+func (r *SagaRepository) Claim(ctx context.Context, lease time.Duration) (*saga.Saga, error) {
+	// The new owner makes the saves of a process whose lease expired fail.
+	query := `UPDATE sagas SET locked_until = NOW() + make_interval(secs => $1), locked_by = $4, updated_at = NOW()
+		WHERE id = (SELECT id FROM sagas WHERE status IN ($2, $3) AND (locked_until IS NULL OR locked_until < NOW()) AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING ` + sagaColumns
+	sg, err := scanSaga(r.executor(ctx).QueryRowContext(ctx, query, lease.Seconds(), saga.StatusRunning, saga.StatusCompensating, uuid.NewString()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, saga.ErrSagaNotFound
+		}
+		r.logger.Error("Error claiming saga: %v", err)
+		return nil, err
+	}
+	if err := r.loadSteps(ctx, sg); err != nil {
+		return nil, err
+	}
+	return sg, nil
+}
+
+func (r *SagaRepository) Reset(ctx context.Context, id string, status saga.Status, from []saga.Status) error {
+	statuses := make([]string, len(from))
+	for i, s := range from {
+		statuses[i] = string(s)
+	}
+
+	query := `UPDATE sagas SET status = $1, attempts = 0, last_error = '', next_attempt_at = NOW(), locked_until = NULL, locked_by = NULL, updated_at = NOW()
+		WHERE id = $2 AND status = ANY($3) AND (locked_until IS NULL OR locked_until < NOW())`
+	res, err := r.executor(ctx).ExecContext(ctx, query, status, id, pq.Array(statuses))
+	if err != nil {
+		r.logger.Error("Error resetting saga: %v", err)
+		return err
+	}
+	return checkAffected(res)
+}
+
+func (r *SagaRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM sagas WHERE status IN ($1, $2) AND updated_at < $3`
+	res, err := r.executor(ctx).ExecContext(ctx, query, saga.StatusCompleted, saga.StatusCompensated, before)
+	if err != nil {
+		r.logger.Error("Error deleting finished sagas: %v", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// checkOwned reports a save matching no row as a lost lease, the saga having been claimed by another process.
+func checkOwned(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return saga.ErrSagaLeaseLost
+	}
+	return nil
+}
+
+func checkAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return saga.ErrSagaNotFound
+	}
+	return nil
+}
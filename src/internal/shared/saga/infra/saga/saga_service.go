@@ -0,0 +1,354 @@
+package saga
+
+import (
+	"auth-api/src/internal/shared/saga/domain/saga"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRetryDelay caps the exponential backoff between the attempts of the worker.
+const maxRetryDelay = time.Hour
+
+// retentionLock keeps the instances from deleting the finished sagas at the same time.
+const retentionLock = "saga_retention"
+
+type Options struct {
+	// Lease is how long a saga stays locked by the process running it, it must outlast the requests.
+	Lease time.Duration
+	// RetryDelay is the first delay between two attempts of the worker, it doubles after each attempt.
+	RetryDelay time.Duration
+	// MaxAttempts marks the saga as failed once the worker made that many attempts.
+	MaxAttempts int
+	// BatchSize is the number of sagas run by each ProcessDue call.
+	BatchSize int
+	// Retention is how long the completed and compensated sagas are kept, 0 keeps them.
+	Retention time.Duration
+}
+
+type SagaService struct {
+	repo         saga.SagaRepository
+	transactions transaction.UnitOfWork
+	locker       transaction.Locker
+	options      Options
+	logger       logger.Logger
+	mu           sync.RWMutex
+	definitions  map[saga.Type]saga.Definition
+}
+
+func NewSagaService(repo saga.SagaRepository, transactions transaction.UnitOfWork, locker transaction.Locker, options Options, logger logger.Logger) saga.SagaService {
+	return &SagaService{
+		repo:         repo,
+		transactions: transactions,
+		locker:       locker,
+		options:      options,
+		logger:       logger,
+		definitions:  make(map[saga.Type]saga.Definition),
+	}
+}
+
+func (s *SagaService) Register(definition saga.Definition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.definitions[definition.Type] = definition
+}
+
+func (s *SagaService) definition(sagaType saga.Type) (saga.Definition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	definition, ok := s.definitions[sagaType]
+	return definition, ok
+}
+
+func (s *SagaService) Start(ctx context.Context, input saga.StartInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	definition, ok := s.definition(input.Type)
+	if !ok {
+		return saga.ErrUnknownSagaType
+	}
+
+	payload, err := json.Marshal(input.Payload)
+	if err != nil {
+		return err
+	}
+	sg := &saga.Saga{
+		ID:      uuid.NewString(),
+		Type:    input.Type,
+		Status:  saga.StatusRunning,
+		Payload: payload,
+		Steps:   make([]saga.StepState, len(definition.Steps)),
+	}
+	for i, step := range definition.Steps {
+		sg.Steps[i] = saga.StepState{Position: i, Name: step.Name, Status: saga.StepPending}
+	}
+	if err := s.repo.Create(ctx, sg, s.options.Lease); err != nil {
+		return err
+	}
+
+	secrets := input.Secrets
+	if secrets == nil {
+		secrets = map[string]string{}
+	}
+	exec := saga.NewExecution(sg, secrets)
+
+	stepErr := s.runSteps(ctx, definition, exec)
+	if stepErr == nil {
+		return nil
+	}
+
+	// The compensation outlives a cancelled request, the worker retries it if it fails.
+	ctx = context.WithoutCancel(ctx)
+	if err := s.compensate(ctx, definition, exec); err != nil {
+		s.logger.Error("Error compensating saga %s %s: %v", sg.Type, sg.ID, err)
+		s.retryLater(ctx, sg, err)
+	}
+	return stepErr
+}
+
+// runSteps runs the steps from the current one, each step is recorded as running before its action.
+func (s *SagaService) runSteps(ctx context.Context, definition saga.Definition, exec *saga.Execution) error {
+	sg := exec.Saga
+	for sg.CurrentStep < len(definition.Steps) {
+		step, state := definition.Steps[sg.CurrentStep], &sg.Steps[sg.CurrentStep]
+		if step.Inline && exec.Detached() {
+			return saga.ErrSecretsRequired
+		}
+
+		state.Status, state.Attempts = saga.StepRunning, state.Attempts+1
+		if err := s.repo.SaveStep(ctx, sg, state); err != nil {
+			return err
+		}
+
+		var actionErr error
+		position := sg.CurrentStep
+		err := s.inTx(ctx, step.Transactional, func(ctx context.Context) error {
+			if actionErr = step.Action(ctx, exec); actionErr != nil {
+				return actionErr
+			}
+			// The step and the saga move forward together, the worker never runs a done step again.
+			return s.transactions.WithTx(ctx, func(ctx context.Context) error {
+				state.Status, state.LastError = saga.StepDone, ""
+				if err := s.repo.SaveStep(ctx, sg, state); err != nil {
+					return err
+				}
+				sg.CurrentStep++
+				return s.repo.Save(ctx, sg, s.options.Lease)
+			})
+		})
+		if err != nil {
+			sg.CurrentStep, state.Status = position, saga.StepRunning
+			if actionErr == nil {
+				return err
+			}
+			state.Status, state.LastError = saga.StepFailed, actionErr.Error()
+			if err := s.repo.SaveStep(context.WithoutCancel(ctx), sg, state); err != nil {
+				s.logger.Error("Error saving failed step %s of saga %s: %v", state.Name, sg.ID, err)
+			}
+			return actionErr
+		}
+	}
+
+	sg.Status, sg.LastError = saga.StatusCompleted, ""
+	return s.repo.Save(ctx, sg, 0)
+}
+
+// compensate undoes the steps in reverse order. Steps left running or compensating are compensated too,
+// their action may have happened, while failed steps are skipped.
+func (s *SagaService) compensate(ctx context.Context, definition saga.Definition, exec *saga.Execution) error {
+	sg := exec.Saga
+	sg.Status = saga.StatusCompensating
+	if err := s.repo.Save(ctx, sg, s.options.Lease); err != nil {
+		return err
+	}
+
+	for i := min(sg.CurrentStep, len(definition.Steps)-1); i >= 0; i-- {
+		step, state := definition.Steps[i], &sg.Steps[i]
+		switch state.Status {
+		case saga.StepDone, saga.StepRunning, saga.StepCompensating:
+		default:
+			continue
+		}
+
+		state.Status = saga.StepCompensating
+		if err := s.repo.SaveStep(ctx, sg, state); err != nil {
+			return err
+		}
+		if err := s.inTx(ctx, step.Transactional, func(ctx context.Context) error {
+			if step.Compensate != nil {
+				if err := step.Compensate(ctx, exec); err != nil {
+					return err
+				}
+			}
+			state.Status, state.LastError = saga.StepCompensated, ""
+			return s.repo.SaveStep(ctx, sg, state)
+		}); err != nil {
+			state.Status, state.LastError = saga.StepCompensating, err.Error()
+			if err := s.repo.SaveStep(ctx, sg, state); err != nil {
+				s.logger.Error("Error saving failed compensation %s of saga %s: %v", state.Name, sg.ID, err)
+			}
+			return err
+		}
+	}
+
+	sg.Status, sg.LastError = saga.StatusCompensated, ""
+	return s.repo.Save(ctx, sg, 0)
+}
+
+// inTx runs fn in a transaction for transactional steps, and as is otherwise.
+func (s *SagaService) inTx(ctx context.Context, transactional bool, fn func(ctx context.Context) error) error {
+	if !transactional {
+		return fn(ctx)
+	}
+	return s.transactions.WithTx(ctx, fn)
+}
+
+// retryLater schedules the next attempt of the worker with an exponential backoff, or fails the saga.
+// A saga whose lease was lost is left to the process that claimed it.
+func (s *SagaService) retryLater(ctx context.Context, sg *saga.Saga, cause error) {
+	if cause == saga.ErrSagaLeaseLost {
+		s.logger.Warning("Saga %s %s was claimed by another process, leaving it", sg.Type, sg.ID)
+		return
+	}
+
+	sg.Attempts++
+	sg.LastError = cause.Error()
+	if sg.Attempts >= s.options.MaxAttempts {
+		sg.Status = saga.StatusFailed
+		s.logger.Error("Saga %s %s failed after %d attempts: %v", sg.Type, sg.ID, sg.Attempts, cause)
+	} else {
+		delay := s.options.RetryDelay << (sg.Attempts - 1)
+		if delay <= 0 || delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		sg.NextAttemptAt = time.Now().Add(delay)
+	}
+
+	if err := s.repo.Save(ctx, sg, 0); err != nil {
+		s.logger.Error("Error saving saga %s: %v", sg.ID, err)
+	}
+}
+
+func (s *SagaService) ProcessDue(ctx context.Context) error {
+	for i := 0; i < s.options.BatchSize; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sg, err := s.repo.Claim(ctx, s.options.Lease)
+		if err == saga.ErrSagaNotFound {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.process(ctx, sg)
+	}
+
+	if s.options.Retention > 0 {
+		// One instance deletes at a time, the others skip it until their next run.
+		if _, err := s.locker.TryWithLock(ctx, retentionLock, func(ctx context.Context) error {
+			deleted, err := s.repo.DeleteFinished(ctx, time.Now().Add(-s.options.Retention))
+			if err != nil {
+				return err
+			}
+			if deleted > 0 {
+				s.logger.Info("Deleted %d finished sagas", deleted)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SagaService) process(ctx context.Context, sg *saga.Saga) {
+	definition, ok := s.definition(sg.Type)
+	if !ok {
+		sg.Status, sg.LastError = saga.StatusFailed, saga.ErrUnknownSagaType.Error()
+		if err := s.repo.Save(ctx, sg, 0); err != nil {
+			s.logger.Error("Error saving saga %s: %v", sg.ID, err)
+		}
+		return
+	}
+
+	exec := saga.NewExecution(sg, nil)
+	var err error
+	switch sg.Status {
+	case saga.StatusRunning:
+		s.logger.Info("Resuming saga %s %s at step %d", sg.Type, sg.ID, sg.CurrentStep)
+		err = s.runSteps(ctx, definition, exec)
+		if err == saga.ErrSecretsRequired {
+			s.logger.Warning("Saga %s %s stopped before a step needing secrets, compensating it", sg.Type, sg.ID)
+			err = s.compensate(ctx, definition, exec)
+		}
+	case saga.StatusCompensating:
+		s.logger.Info("Compensating saga %s %s", sg.Type, sg.ID)
+		err = s.compensate(ctx, definition, exec)
+	}
+	if err != nil {
+		s.logger.Error("Error running saga %s %s: %v", sg.Type, sg.ID, err)
+		s.retryLater(ctx, sg, err)
+	}
+}
+
+func (s *SagaService) Get(ctx context.Context, input saga.GetSagaInput) (*saga.Saga, error) {
+	return s.repo.Get(ctx, &input)
+}
+
+func (s *SagaService) List(ctx context.Context, input saga.ListSagasInput) (*saga.SagaPage, error) {
+	return s.repo.List(ctx, &input)
+}
+
+// Resume makes the worker pick the saga up on its next run, forward or compensating depending on where it stopped.
+func (s *SagaService) Resume(ctx context.Context, input saga.ResumeSagaInput) (*saga.Saga, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	sg, err := s.repo.Get(ctx, &saga.GetSagaInput{ID: input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	status := sg.Status
+	if status == saga.StatusFailed {
+		status = saga.StatusRunning
+		if sg.CompensationStarted() {
+			status = saga.StatusCompensating
+		}
+	}
+	err = s.repo.Reset(ctx, sg.ID, status, []saga.Status{saga.StatusRunning, saga.StatusCompensating, saga.StatusFailed})
+	if err == saga.ErrSagaNotFound {
+		return nil, saga.ErrSagaNotResumable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, &saga.GetSagaInput{ID: sg.ID})
+}
+
+// Compensate makes the worker undo the completed steps of the saga on its next run.
+func (s *SagaService) Compensate(ctx context.Context, input saga.CompensateSagaInput) (*saga.Saga, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.Reset(ctx, input.ID, saga.StatusCompensating, []saga.Status{saga.StatusRunning, saga.StatusFailed})
+	if err == saga.ErrSagaNotFound {
+		if _, err := s.repo.Get(ctx, &saga.GetSagaInput{ID: input.ID}); err != nil {
+			return nil, err
+		}
+		return nil, saga.ErrSagaNotCompensable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, &saga.GetSagaInput{ID: input.ID})
+}
@@ -0,0 +1,204 @@
+package saga_test
+
+import (
+	"auth-api/src/internal/shared/saga/domain/saga"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/saga/sagatest"
+	"auth-api/src/pkg/logger"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testSaga saga.Type = "test.saga"
+
+func newService(t *testing.T, repo *sagatest.Repository, locker *sagatest.Locker, options saga_infra.Options) saga.SagaService {
+	t.Helper()
+	log, err := logger.NewLogger("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options.Lease, options.MaxAttempts, options.BatchSize = time.Minute, 3, 10
+	return saga_infra.NewSagaService(repo, sagatest.UnitOfWork{}, locker, options, log)
+}
+
+// recorder builds steps appending their name to calls, and "undo <name>" when compensated.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) step(name string, actionErr error) saga.Step {
+	return saga.Step{
+		Name: name,
+		Action: func(ctx context.Context, exec *saga.Execution) error {
+			r.calls = append(r.calls, name)
+			return actionErr
+		},
+		Compensate: func(ctx context.Context, exec *saga.Execution) error {
+			r.calls = append(r.calls, "undo "+name)
+			return nil
+		},
+	}
+}
+
+func onlySaga(t *testing.T, repo *sagatest.Repository) *saga.Saga {
+	t.Helper()
+	sagas := repo.Sagas()
+	if len(sagas) != 1 {
+		t.Fatalf("got %d sagas, want 1", len(sagas))
+	}
+	return sagas[0]
+}
+
+func TestStartRunsEveryStep(t *testing.T) {
+	repo, rec := sagatest.NewRepository(), &recorder{}
+	service := newService(t, repo, &sagatest.Locker{}, saga_infra.Options{})
+	service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{rec.step("first", nil), rec.step("second", nil)}})
+
+	if err := service.Start(context.Background(), saga.StartInput{Type: testSaga}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+	sg := onlySaga(t, repo)
+	if sg.Status != saga.StatusCompleted || sg.LockedBy != "" {
+		t.Errorf("saga is %s locked by %q, want completed and released", sg.Status, sg.LockedBy)
+	}
+	for _, step := range sg.Steps {
+		if step.Status != saga.StepDone {
+			t.Errorf("step %s is %s, want done", step.Name, step.Status)
+		}
+	}
+}
+
+func TestStartCompensatesTheCompletedStepsInReverse(t *testing.T) {
+	repo, rec := sagatest.NewRepository(), &recorder{}
+	service := newService(t, repo, &sagatest.Locker{}, saga_infra.Options{})
+	stepErr := errors.New("third failed")
+	service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{
+		rec.step("first", nil), rec.step("second", nil), rec.step("third", stepErr),
+	}})
+
+	if err := service.Start(context.Background(), saga.StartInput{Type: testSaga}); err != stepErr {
+		t.Fatalf("Start = %v, want the error of the step", err)
+	}
+
+	if want := []string{"first", "second", "third", "undo second", "undo first"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+	sg := onlySaga(t, repo)
+	if sg.Status != saga.StatusCompensated {
+		t.Errorf("saga is %s, want compensated", sg.Status)
+	}
+	if sg.Steps[2].Status != saga.StepFailed {
+		t.Errorf("failed step is %s, want failed", sg.Steps[2].Status)
+	}
+}
+
+func TestStartStopsOnceAnotherProcessClaimedTheSaga(t *testing.T) {
+	repo, rec := sagatest.NewRepository(), &recorder{}
+	service := newService(t, repo, &sagatest.Locker{}, saga_infra.Options{})
+	takeOver := saga.Step{
+		Name: "slow",
+		Action: func(ctx context.Context, exec *saga.Execution) error {
+			// The lease expires during the step and the worker of another instance claims the saga.
+			repo.Expire(exec.Saga.ID)
+			if _, err := repo.Claim(ctx, time.Minute); err != nil {
+				t.Fatalf("Claim: %v", err)
+			}
+			return nil
+		},
+	}
+	service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{rec.step("first", nil), takeOver, rec.step("last", nil)}})
+
+	if err := service.Start(context.Background(), saga.StartInput{Type: testSaga}); err != saga.ErrSagaLeaseLost {
+		t.Fatalf("Start = %v, want ErrSagaLeaseLost", err)
+	}
+
+	if want := []string{"first"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v, the new owner runs the rest", rec.calls, want)
+	}
+	sg := onlySaga(t, repo)
+	if sg.Status != saga.StatusRunning || sg.CurrentStep != 1 || sg.Attempts != 0 {
+		t.Errorf("saga is %s at step %d after %d attempts, want it left running at step 1", sg.Status, sg.CurrentStep, sg.Attempts)
+	}
+}
+
+func TestProcessDueCompensatesASagaStoppedBeforeAnInlineStep(t *testing.T) {
+	repo, rec := sagatest.NewRepository(), &recorder{}
+	service := newService(t, repo, &sagatest.Locker{}, saga_infra.Options{})
+	inline := rec.step("inline", nil)
+	inline.Inline = true
+	service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{rec.step("first", nil), inline}})
+
+	// The process stopped after the first step, the secrets of the inline step are gone with it.
+	sg := &saga.Saga{ID: "00000000-0000-0000-0000-000000000002", Type: testSaga, Status: saga.StatusRunning, Payload: []byte(`{}`), CurrentStep: 1,
+		Steps: []saga.StepState{{Position: 0, Name: "first", Status: saga.StepDone}, {Position: 1, Name: "inline", Status: saga.StepPending}}}
+	if err := repo.Create(context.Background(), sg, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	repo.Expire(sg.ID)
+
+	if err := service.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	if want := []string{"undo first"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+	if sg := onlySaga(t, repo); sg.Status != saga.StatusCompensated {
+		t.Errorf("saga is %s, want compensated", sg.Status)
+	}
+}
+
+func TestProcessDueResumesTheSagasOfStoppedProcesses(t *testing.T) {
+	repo, rec := sagatest.NewRepository(), &recorder{}
+	service := newService(t, repo, &sagatest.Locker{}, saga_infra.Options{})
+	service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{rec.step("first", nil), rec.step("second", nil)}})
+
+	// A saga recorded by a process that stopped before running any step.
+	sg := &saga.Saga{ID: "00000000-0000-0000-0000-000000000001", Type: testSaga, Status: saga.StatusRunning, Payload: []byte(`{}`),
+		Steps: []saga.StepState{{Position: 0, Name: "first", Status: saga.StepPending}, {Position: 1, Name: "second", Status: saga.StepPending}}}
+	if err := repo.Create(context.Background(), sg, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	repo.Expire(sg.ID)
+
+	if err := service.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+	if sg := onlySaga(t, repo); sg.Status != saga.StatusCompleted {
+		t.Errorf("saga is %s, want completed", sg.Status)
+	}
+}
+
+func TestProcessDueDeletesFinishedSagasUnderTheLock(t *testing.T) {
+	for _, held := range []bool{false, true} {
+		repo, rec := sagatest.NewRepository(), &recorder{}
+		locker := &sagatest.Locker{Held: held}
+		service := newService(t, repo, locker, saga_infra.Options{Retention: time.Nanosecond})
+		service.Register(saga.Definition{Type: testSaga, Steps: []saga.Step{rec.step("only", nil)}})
+		if err := service.Start(context.Background(), saga.StartInput{Type: testSaga}); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+
+		if err := service.ProcessDue(context.Background()); err != nil {
+			t.Fatalf("ProcessDue: %v", err)
+		}
+
+		if remaining := len(repo.Sagas()); held && remaining != 1 {
+			t.Errorf("with the lock held by another instance, %d sagas remain, want 1", remaining)
+		} else if !held && remaining != 0 {
+			t.Errorf("%d sagas remain, want the finished saga deleted", remaining)
+		}
+	}
+}
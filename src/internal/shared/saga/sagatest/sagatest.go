@@ -0,0 +1,216 @@
+// Package sagatest runs the saga service in memory, for the tests of the sagas and of the use cases starting them.
+package sagatest
+
+import (
+	"auth-api/src/internal/shared/saga/domain/saga"
+	saga_infra "auth-api/src/internal/shared/saga/infra/saga"
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewService runs the sagas on a Repository, with transactions and locks that always succeed.
+func NewService(repo *Repository, options saga_infra.Options, logger logger.Logger) saga.SagaService {
+	if options.Lease == 0 {
+		options.Lease = time.Minute
+	}
+	if options.MaxAttempts == 0 {
+		options.MaxAttempts = 3
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = 10
+	}
+	return saga_infra.NewSagaService(repo, UnitOfWork{}, &Locker{}, options, logger)
+}
+
+// Repository keeps the sagas in memory. Transactions are not isolated, a transactional step that fails keeps
+// the writes of its action.
+type Repository struct {
+	mu    sync.Mutex
+	sagas map[string]*saga.Saga
+}
+
+func NewRepository() *Repository {
+	return &Repository{sagas: make(map[string]*saga.Saga)}
+}
+
+// Sagas returns a copy of the stored sagas, from the oldest.
+func (r *Repository) Sagas() []*saga.Saga {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sagas := make([]*saga.Saga, 0, len(r.sagas))
+	for _, sg := range r.sagas {
+		sagas = append(sagas, clone(sg))
+	}
+	sort.Slice(sagas, func(i, j int) bool {
+		return sagas[i].CreatedAt.Before(sagas[j].CreatedAt)
+	})
+	return sagas
+}
+
+// Expire ends the lease of the saga, as when the process running it stops.
+func (r *Repository) Expire(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sg, ok := r.sagas[id]; ok {
+		expired := time.Now().Add(-time.Second)
+		sg.LockedUntil, sg.NextAttemptAt = &expired, expired
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, sg *saga.Saga, lease time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	lockedUntil := now.Add(lease)
+	sg.LockedBy, sg.LockedUntil = uuid.NewString(), &lockedUntil
+	sg.NextAttemptAt, sg.CreatedAt, sg.UpdatedAt = now, now, now
+	r.sagas[sg.ID] = clone(sg)
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, input *saga.GetSagaInput) (*saga.Saga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sg, ok := r.sagas[input.ID]
+	if !ok {
+		return nil, saga.ErrSagaNotFound
+	}
+	return clone(sg), nil
+}
+
+func (r *Repository) List(ctx context.Context, input *saga.ListSagasInput) (*saga.SagaPage, error) {
+	page := &saga.SagaPage{Sagas: []*saga.Saga{}}
+	for _, sg := range r.Sagas() {
+		if (input.Status == "" || sg.Status == input.Status) && (input.Type == "" || sg.Type == input.Type) {
+			page.Sagas = append(page.Sagas, sg)
+		}
+	}
+	return page, nil
+}
+
+func (r *Repository) Save(ctx context.Context, sg *saga.Saga, lease time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sagas[sg.ID]
+	if !ok || stored.LockedBy == "" || stored.LockedBy != sg.LockedBy {
+		return saga.ErrSagaLeaseLost
+	}
+
+	saved := clone(sg)
+	saved.Steps = stored.Steps
+	saved.UpdatedAt = time.Now()
+	if lease > 0 {
+		lockedUntil := time.Now().Add(lease)
+		saved.LockedUntil = &lockedUntil
+	} else {
+		saved.LockedUntil, saved.LockedBy = nil, ""
+	}
+	r.sagas[sg.ID] = saved
+	return nil
+}
+
+func (r *Repository) SaveStep(ctx context.Context, sg *saga.Saga, step *saga.StepState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sagas[sg.ID]
+	if !ok || stored.LockedBy == "" || stored.LockedBy != sg.LockedBy {
+		return saga.ErrSagaLeaseLost
+	}
+	stored.Steps[step.Position] = *step
+	return nil
+}
+
+func (r *Repository) Claim(ctx context.Context, lease time.Duration) (*saga.Saga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, sg := range r.sagas {
+		if sg.Status != saga.StatusRunning && sg.Status != saga.StatusCompensating {
+			continue
+		}
+		if (sg.LockedUntil != nil && sg.LockedUntil.After(now)) || sg.NextAttemptAt.After(now) {
+			continue
+		}
+		lockedUntil := now.Add(lease)
+		sg.LockedBy, sg.LockedUntil = uuid.NewString(), &lockedUntil
+		return clone(sg), nil
+	}
+	return nil, saga.ErrSagaNotFound
+}
+
+func (r *Repository) Reset(ctx context.Context, id string, status saga.Status, from []saga.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sg, ok := r.sagas[id]
+	if !ok || (sg.LockedUntil != nil && sg.LockedUntil.After(time.Now())) {
+		return saga.ErrSagaNotFound
+	}
+	for _, s := range from {
+		if sg.Status == s {
+			sg.Status, sg.Attempts, sg.LastError = status, 0, ""
+			sg.NextAttemptAt, sg.LockedUntil, sg.LockedBy = time.Now(), nil, ""
+			return nil
+		}
+	}
+	return saga.ErrSagaNotFound
+}
+
+func (r *Repository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, sg := range r.sagas {
+		if (sg.Status == saga.StatusCompleted || sg.Status == saga.StatusCompensated) && sg.UpdatedAt.Before(before) {
+			delete(r.sagas, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func clone(sg *saga.Saga) *saga.Saga {
+	copied := *sg
+	copied.Payload = append([]byte(nil), sg.Payload...)
+	copied.Steps = append([]saga.StepState(nil), sg.Steps...)
+	if sg.LockedUntil != nil {
+		lockedUntil := *sg.LockedUntil
+		copied.LockedUntil = &lockedUntil
+	}
+	return &copied
+}
+
+// UnitOfWork runs the functions without a transaction.
+type UnitOfWork struct{}
+
+func (UnitOfWork) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Locker grants every lock unless Held is set, as when another instance holds it.
+type Locker struct {
+	Held bool
+}
+
+func (l *Locker) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	if l.Held {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+var _ transaction.UnitOfWork = UnitOfWork{}
+var _ transaction.Locker = &Locker{}
@@ -0,0 +1,9 @@
+package transaction
+
+import "context"
+
+type Locker interface {
+	// TryWithLock runs fn while holding the lock named name across every instance. It returns false without
+	// running fn when another holder has the lock.
+	TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
@@ -0,0 +1,10 @@
+package transaction
+
+import "context"
+
+type UnitOfWork interface {
+	// WithTx runs fn in a transaction carried by the context given to fn, the repositories called with that context
+	// join it. The transaction is committed when fn returns nil and rolled back otherwise. Nested calls join the
+	// outer transaction.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
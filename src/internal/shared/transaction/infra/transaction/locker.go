@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// Locker takes Postgres session advisory locks, keyed by the hash of the lock name.
+type Locker struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewLocker(db *sql.DB, logger logger.Logger) transaction.Locker {
+	return &Locker{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (l *Locker) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	// The lock belongs to the session, so it is taken and released on the same connection.
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&locked); err != nil {
+		l.logger.Error("Error acquiring the %s lock: %v", name, err)
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, name); err != nil {
+			l.logger.Error("Error releasing the %s lock: %v", name, err)
+		}
+	}()
+
+	return true, fn(ctx)
+}
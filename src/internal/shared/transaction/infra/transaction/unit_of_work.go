@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"auth-api/src/internal/shared/transaction/domain/transaction"
+	"auth-api/src/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// Executor runs the queries of a repository, on the database or on the transaction of the context.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// GetExecutor returns the transaction started by WithTx when ctx carries one, and db otherwise.
+func GetExecutor(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+type UnitOfWork struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewUnitOfWork(db *sql.DB, logger logger.Logger) transaction.UnitOfWork {
+	return &UnitOfWork{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (u *UnitOfWork) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		u.logger.Error("Error starting transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil && rollbackErr != sql.ErrTxDone {
+				u.logger.Error("Error rolling back transaction: %v", rollbackErr)
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		u.logger.Error("Error committing transaction: %v", err)
+	}
+	return err
+}
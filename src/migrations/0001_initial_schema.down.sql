@@ -0,0 +1,2 @@
+-- The baseline is irreversible: it takes over databases created from the former schema.sql, whose tables it did not
+-- create. The migrator refuses to revert it.
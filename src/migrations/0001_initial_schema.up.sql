@@ -0,0 +1,207 @@
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    phone VARCHAR(15),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS admins (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS auth_users (
+    id VARCHAR(36) PRIMARY KEY,
+    username VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    password_hash TEXT NOT NULL,
+    status VARCHAR(30) NOT NULL,
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    mfa_secret TEXT,
+    mfa_pending_secret TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS auth_user_groups (
+    user_id VARCHAR(36) NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    group_name VARCHAR(50) NOT NULL,
+    PRIMARY KEY (user_id, group_name)
+);
+
+CREATE TABLE IF NOT EXISTS auth_refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    origin_jti VARCHAR(36) UNIQUE NOT NULL,
+    auth_time TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS auth_challenges (
+    session_hash VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES auth_users(id) ON DELETE CASCADE,
+    challenge_name VARCHAR(50) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    client_id VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    secret_hash TEXT,
+    redirect_uris TEXT[] NOT NULL DEFAULT '{}',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    access_token_ttl INTEGER NOT NULL DEFAULT 3600,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    code_hash VARCHAR(64) PRIMARY KEY,
+    client_id VARCHAR(100) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    redirect_uri TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    nonce TEXT NOT NULL DEFAULT '',
+    code_challenge VARCHAR(128) NOT NULL,
+    code_challenge_method VARCHAR(10) NOT NULL,
+    subject VARCHAR(36) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    access_token TEXT NOT NULL,
+    refresh_token TEXT NOT NULL DEFAULT '',
+    auth_time TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS token_denylist (
+    key VARCHAR(128) PRIMARY KEY,
+    issued_before TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO roles (name, description, permissions) VALUES
+    ('Admin', 'Built-in administrators group', ARRAY['users:read', 'users:write', 'admins:write', 'groups:write', 'mfa:admin-remove', 'clients:manage', 'roles:manage', 'orgs:manage']),
+    ('User', 'Built-in users group', '{}'),
+    ('OrgAdmin', 'Manages an organization and its members', ARRAY['org:write', 'members:read', 'members:write']),
+    ('OrgMember', 'Member of an organization', ARRAY['members:read'])
+ON CONFLICT (name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id VARCHAR(36) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS organization_members_user_id_idx ON organization_members (user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    invited_by VARCHAR(36) NOT NULL,
+    version INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_invitations_pending_idx ON organization_invitations (organization_id, email) WHERE status = 'pending';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS users_created_at_idx ON users (created_at DESC, id DESC);
+
+ALTER TABLE auth_users ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(100) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    scheduled_for TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_deletions_scheduled_for_idx ON account_deletions (scheduled_for);
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    notify_email VARCHAR(100) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    data BYTEA,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON data_exports (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS data_exports_pending_idx ON data_exports (user_id) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS email_changes (
+    user_id VARCHAR(36) PRIMARY KEY,
+    new_email VARCHAR(100) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE users ALTER COLUMN phone TYPE VARCHAR(16);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS sagas (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    current_step INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMP,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS sagas_due_idx ON sagas (next_attempt_at) WHERE status IN ('running', 'compensating');
+CREATE INDEX IF NOT EXISTS sagas_created_at_idx ON sagas (created_at DESC);
+
+CREATE TABLE IF NOT EXISTS saga_steps (
+    saga_id VARCHAR(36) NOT NULL REFERENCES sagas (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (saga_id, position)
+);
+
+UPDATE roles SET permissions = array_append(permissions, 'sagas:manage') WHERE name = 'Admin' AND NOT ('sagas:manage' = ANY (permissions));
@@ -0,0 +1,18 @@
+-- The legacy tables get the profiles as they are now.
+DELETE FROM legacy_users;
+
+INSERT INTO legacy_users (id, name, email, phone, phone_verified, metadata, created_at, last_login_at)
+SELECT p.id, p.name, p.email, p.phone, p.phone_verified, p.metadata, p.created_at, p.last_login_at
+FROM principals p JOIN principal_roles r ON r.principal_id = p.id AND r.role_name = 'User';
+
+DELETE FROM legacy_admins;
+
+INSERT INTO legacy_admins (id, name, email)
+SELECT p.id, p.name, p.email
+FROM principals p JOIN principal_roles r ON r.principal_id = p.id AND r.role_name = 'Admin';
+
+ALTER TABLE legacy_users RENAME TO users;
+ALTER TABLE legacy_admins RENAME TO admins;
+
+DROP TABLE principal_roles;
+DROP TABLE principals;
@@ -0,0 +1,52 @@
+CREATE TABLE principals (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    phone VARCHAR(16),
+    phone_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP
+);
+
+CREATE INDEX principals_created_at_idx ON principals (created_at DESC, id DESC);
+
+CREATE TABLE principal_roles (
+    principal_id VARCHAR(36) NOT NULL REFERENCES principals(id) ON DELETE CASCADE,
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (principal_id, role_name)
+);
+
+CREATE INDEX principal_roles_role_name_idx ON principal_roles (role_name);
+
+INSERT INTO principals (id, name, email, phone, phone_verified, metadata, created_at, last_login_at)
+SELECT id, name, email, phone, phone_verified, metadata, created_at, last_login_at FROM users;
+
+INSERT INTO principal_roles (principal_id, role_name)
+SELECT id, 'User' FROM users;
+
+-- An admin whose email belongs to a user with another auth subject cannot share its profile. The migration stops and
+-- lists them, the README describes the repair.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(format('%s (admin %s, user %s)', a.email, a.id, u.id), ', ')
+    INTO conflicts
+    FROM admins a JOIN users u ON u.email = a.email AND u.id <> a.id;
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'Admins sharing their email with another user: %', conflicts;
+    END IF;
+END $$;
+
+-- An admin sharing its auth subject with a user keeps the users row as its profile.
+INSERT INTO principals (id, name, email)
+SELECT id, name, email FROM admins
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO principal_roles (principal_id, role_name)
+SELECT a.id, 'Admin' FROM admins a JOIN principals p ON p.id = a.id;
+
+ALTER TABLE users RENAME TO legacy_users;
+ALTER TABLE admins RENAME TO legacy_admins;
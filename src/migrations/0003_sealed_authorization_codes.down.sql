@@ -0,0 +1,7 @@
+DELETE FROM oauth_authorization_codes;
+
+DROP INDEX oauth_authorization_codes_expires_at_idx;
+
+ALTER TABLE oauth_authorization_codes DROP COLUMN sealed_refresh_token;
+ALTER TABLE oauth_authorization_codes ADD COLUMN access_token TEXT NOT NULL;
+ALTER TABLE oauth_authorization_codes ADD COLUMN refresh_token TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+-- Pending codes carry plaintext tokens, they are dropped and the clients start the authorization again.
+DELETE FROM oauth_authorization_codes;
+
+ALTER TABLE oauth_authorization_codes DROP COLUMN access_token;
+ALTER TABLE oauth_authorization_codes DROP COLUMN refresh_token;
+ALTER TABLE oauth_authorization_codes ADD COLUMN sealed_refresh_token TEXT NOT NULL;
+
+CREATE INDEX oauth_authorization_codes_expires_at_idx ON oauth_authorization_codes (expires_at);
@@ -0,0 +1,11 @@
+CREATE TABLE oauth_refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    client_id VARCHAR(100) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    subject VARCHAR(36) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    origin_jti VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX oauth_refresh_tokens_expires_at_idx ON oauth_refresh_tokens (expires_at);
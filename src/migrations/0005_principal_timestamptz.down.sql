@@ -0,0 +1,3 @@
+ALTER TABLE principal_roles ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE principals ALTER COLUMN last_login_at TYPE TIMESTAMP;
+ALTER TABLE principals ALTER COLUMN created_at TYPE TIMESTAMP;
@@ -0,0 +1,4 @@
+-- The timestamps were written with NOW() in the session time zone, which the conversion assumes as well.
+ALTER TABLE principals ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE principals ALTER COLUMN last_login_at TYPE TIMESTAMPTZ;
+ALTER TABLE principal_roles ALTER COLUMN created_at TYPE TIMESTAMPTZ;
@@ -0,0 +1 @@
+ALTER TABLE account_deletions DROP COLUMN locked_until;
@@ -0,0 +1 @@
+ALTER TABLE account_deletions ADD COLUMN locked_until TIMESTAMPTZ;
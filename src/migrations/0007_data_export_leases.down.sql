@@ -0,0 +1,2 @@
+ALTER TABLE data_exports DROP COLUMN attempts;
+ALTER TABLE data_exports DROP COLUMN locked_until;
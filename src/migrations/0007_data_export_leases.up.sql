@@ -0,0 +1,2 @@
+ALTER TABLE data_exports ADD COLUMN locked_until TIMESTAMPTZ;
+ALTER TABLE data_exports ADD COLUMN attempts INT NOT NULL DEFAULT 0;
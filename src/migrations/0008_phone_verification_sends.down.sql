@@ -0,0 +1 @@
+DROP TABLE IF EXISTS phone_verification_sends;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS phone_verification_sends (
+    user_id VARCHAR(36) NOT NULL,
+    phone VARCHAR(16) NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS phone_verification_sends_user_id_idx ON phone_verification_sends (user_id);
+CREATE INDEX IF NOT EXISTS phone_verification_sends_phone_idx ON phone_verification_sends (phone);
+CREATE INDEX IF NOT EXISTS phone_verification_sends_sent_at_idx ON phone_verification_sends (sent_at);
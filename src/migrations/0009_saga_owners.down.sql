@@ -0,0 +1 @@
+ALTER TABLE sagas DROP COLUMN locked_by;
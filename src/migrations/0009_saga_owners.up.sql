@@ -0,0 +1 @@
+ALTER TABLE sagas ADD COLUMN locked_by VARCHAR(36);
@@ -0,0 +1,33 @@
+ALTER TABLE saga_steps ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE sagas ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE sagas ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE sagas ALTER COLUMN next_attempt_at TYPE TIMESTAMP;
+ALTER TABLE sagas ALTER COLUMN locked_until TYPE TIMESTAMP;
+ALTER TABLE email_changes ALTER COLUMN requested_at TYPE TIMESTAMP;
+ALTER TABLE data_exports ALTER COLUMN completed_at TYPE TIMESTAMP;
+ALTER TABLE data_exports ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE data_exports ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE account_deletions ALTER COLUMN scheduled_for TYPE TIMESTAMP;
+ALTER TABLE account_deletions ALTER COLUMN requested_at TYPE TIMESTAMP;
+ALTER TABLE organization_invitations ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE organization_invitations ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE organization_invitations ALTER COLUMN accepted_at TYPE TIMESTAMP;
+ALTER TABLE organization_invitations ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE organization_members ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE organization_members ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE organizations ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE organizations ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE roles ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE roles ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE token_denylist ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE token_denylist ALTER COLUMN issued_before TYPE TIMESTAMP;
+ALTER TABLE oauth_authorization_codes ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE oauth_authorization_codes ALTER COLUMN auth_time TYPE TIMESTAMP;
+ALTER TABLE oauth_clients ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE oauth_clients ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE auth_challenges ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN revoked_at TYPE TIMESTAMP;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN expires_at TYPE TIMESTAMP;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN auth_time TYPE TIMESTAMP;
+ALTER TABLE auth_users ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE auth_users ALTER COLUMN created_at TYPE TIMESTAMP;
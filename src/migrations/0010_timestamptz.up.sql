@@ -0,0 +1,35 @@
+-- lib/pq drops the offset of the times written into TIMESTAMP columns, which are then compared with NOW() in the
+-- session time zone. Like 0005, the conversion assumes the stored times are in the session time zone.
+ALTER TABLE auth_users ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE auth_users ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN auth_time TYPE TIMESTAMPTZ;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE auth_refresh_tokens ALTER COLUMN revoked_at TYPE TIMESTAMPTZ;
+ALTER TABLE auth_challenges ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE oauth_clients ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE oauth_clients ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE oauth_authorization_codes ALTER COLUMN auth_time TYPE TIMESTAMPTZ;
+ALTER TABLE oauth_authorization_codes ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE token_denylist ALTER COLUMN issued_before TYPE TIMESTAMPTZ;
+ALTER TABLE token_denylist ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE roles ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE roles ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE organizations ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE organizations ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_members ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_members ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_invitations ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_invitations ALTER COLUMN accepted_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_invitations ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE organization_invitations ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE account_deletions ALTER COLUMN requested_at TYPE TIMESTAMPTZ;
+ALTER TABLE account_deletions ALTER COLUMN scheduled_for TYPE TIMESTAMPTZ;
+ALTER TABLE data_exports ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE data_exports ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE data_exports ALTER COLUMN completed_at TYPE TIMESTAMPTZ;
+ALTER TABLE email_changes ALTER COLUMN requested_at TYPE TIMESTAMPTZ;
+ALTER TABLE sagas ALTER COLUMN locked_until TYPE TIMESTAMPTZ;
+ALTER TABLE sagas ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ;
+ALTER TABLE sagas ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE sagas ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE saga_steps ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
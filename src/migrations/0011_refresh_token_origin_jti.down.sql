@@ -0,0 +1 @@
+DROP INDEX IF EXISTS oauth_refresh_tokens_origin_jti_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS oauth_refresh_tokens_origin_jti_idx ON oauth_refresh_tokens (origin_jti);
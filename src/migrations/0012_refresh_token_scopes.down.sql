@@ -0,0 +1,2 @@
+ALTER TABLE oauth_refresh_tokens DROP COLUMN auth_time;
+ALTER TABLE oauth_refresh_tokens DROP COLUMN scope;
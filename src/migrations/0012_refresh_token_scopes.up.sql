@@ -0,0 +1,3 @@
+-- Bindings made before the scope was recorded have none, refreshing them no longer issues an ID token.
+ALTER TABLE oauth_refresh_tokens ADD COLUMN scope TEXT NOT NULL DEFAULT '';
+ALTER TABLE oauth_refresh_tokens ADD COLUMN auth_time TIMESTAMPTZ;
@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// Files holds the NNNN_name.up.sql and NNNN_name.down.sql migrations of the database. Applied files must not be
+// edited, schema changes go in a new migration.
+//
+//go:embed *.sql
+var Files embed.FS
@@ -23,3 +23,25 @@ func NewApiError(statusCode int, message string, description ...string) *ApiErro
 	}
 	return apiError
 }
+
+// OAuthError is the RFC 6749 error response returned by the OAuth2/OIDC endpoints.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	StatusCode  int    `json:"-"`
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("error: %s, error_description: %s, status_code: %d", e.Code, e.Description, e.StatusCode)
+}
+
+func NewOAuthError(statusCode int, code string, description ...string) *OAuthError {
+	oauthError := &OAuthError{
+		Code:       code,
+		StatusCode: statusCode,
+	}
+	if len(description) > 0 {
+		oauthError.Description = description[0]
+	}
+	return oauthError
+}
@@ -0,0 +1,97 @@
+package jwt_issuer
+
+import (
+	"auth-api/src/pkg/jwt_verify"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type JWTIssuer interface {
+	Sign(claims jwt.Claims) (string, error)
+	Issuer() string
+	JWK() *jwt_verify.JWK
+}
+
+type jwtIssuer struct {
+	issuer string
+	key    *rsa.PrivateKey
+	kid    string
+}
+
+func NewIssuer(issuer string, key *rsa.PrivateKey) JWTIssuer {
+	return &jwtIssuer{
+		issuer: issuer,
+		key:    key,
+		kid:    keyID(&key.PublicKey),
+	}
+}
+
+func (i *jwtIssuer) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = i.kid
+	return token.SignedString(i.key)
+}
+
+func (i *jwtIssuer) Issuer() string {
+	return i.issuer
+}
+
+func (i *jwtIssuer) JWK() *jwt_verify.JWK {
+	return &jwt_verify.JWK{
+		Keys: []jwt_verify.JWKKey{
+			{
+				Alg: "RS256",
+				Kty: "RSA",
+				Use: "sig",
+				Kid: i.kid,
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.PublicKey.E)).Bytes()),
+				N:   base64.RawURLEncoding.EncodeToString(i.key.PublicKey.N.Bytes()),
+			},
+		},
+	}
+}
+
+// LoadPrivateKey reads a PEM encoded RSA key (PKCS#1 or PKCS#8). An empty path generates an ephemeral key.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	if path == "" {
+		return rsa.GenerateKey(rand.Reader, 2048)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM block found in signing key file")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an RSA key")
+	}
+	return key, nil
+}
+
+func keyID(key *rsa.PublicKey) string {
+	sum := sha256.Sum256(key.N.Bytes())
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
@@ -12,6 +12,7 @@ type Claims struct {
 	Kid             string   `json:"kid"`
 	Aud             string   `json:"aud"`
 	AuthTime        int64    `json:"auth_time"`
+	ClientId        string   `json:"client_id"`
 	CognitoUsername string   `json:"cognito:username"`
 	UserGroups      []string `json:"cognito:groups"`
 	Email           string   `json:"email"`
@@ -22,9 +23,12 @@ type Claims struct {
 	Iss             string   `json:"iss"`
 	Jti             string   `json:"jti"`
 	Name            string   `json:"name"`
+	OrgId           string   `json:"org_id"`
 	OriginJti       string   `json:"origin_jti"`
+	Scope           string   `json:"scope"`
 	Sub             string   `json:"sub"`
 	TokenUse        string   `json:"token_use"`
+	Username        string   `json:"username"`
 }
 
 func (c *Claims) GetExpirationTime() (*jwt.NumericDate, error) {
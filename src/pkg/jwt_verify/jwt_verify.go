@@ -2,47 +2,96 @@ package jwt_verify
 
 import (
 	"auth-api/src/pkg/logger"
+	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	DefaultRefreshInterval    = time.Hour
+	DefaultMinRefetchInterval = time.Minute
+)
+
+var ErrUnknownKey = errors.New("no JWK matches the token kid")
+
 type JWTVerify interface {
 	CacheJWK() error
+	// StartRefresh refetches the key set every refresh interval until ctx is done.
+	StartRefresh(ctx context.Context)
 	ParseJWT(tokenString string) (*jwt.Token, *Claims, error)
 	JWK() *JWK
 	JWKURL() string
 }
 
+type Options struct {
+	// RefreshInterval is how often the key set is refetched in the background.
+	RefreshInterval time.Duration
+	// MinRefetchInterval limits the refetches triggered by tokens signed with an unknown kid.
+	MinRefetchInterval time.Duration
+	HTTPClient         *http.Client
+	Rules              ValidationRules
+}
+
 type jwtVerify struct {
-	jwk               *JWK
+	mu   sync.RWMutex
+	jwk  *JWK
+	keys map[string]*rsa.PublicKey
+
+	fetchMu     sync.Mutex
+	lastFetchAt time.Time
+
 	jwkURL            string
 	cognitoRegion     string
 	cognitoUserPoolID string
+	options           Options
+	rules             ValidationRules
 	log               logger.Logger
 }
 
 type JWK struct {
-	Keys []struct {
-		Alg string `json:"alg"`
-		E   string `json:"e"`
-		Kid string `json:"kid"`
-		Kty string `json:"kty"`
-		N   string `json:"n"`
-	} `json:"keys"`
+	Keys []JWKKey `json:"keys"`
+}
+
+type JWKKey struct {
+	Alg string `json:"alg"`
+	E   string `json:"e"`
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	Use string `json:"use,omitempty"`
 }
 
 func NewAuth(cognitoRegion, cognitoUserPoolID string, logger logger.Logger) JWTVerify {
+	return NewAuthWithOptions(cognitoRegion, cognitoUserPoolID, Options{}, logger)
+}
+
+func NewAuthWithOptions(cognitoRegion, cognitoUserPoolID string, options Options, logger logger.Logger) JWTVerify {
+	if options.RefreshInterval <= 0 {
+		options.RefreshInterval = DefaultRefreshInterval
+	}
+	if options.MinRefetchInterval <= 0 {
+		options.MinRefetchInterval = DefaultMinRefetchInterval
+	}
+	if options.HTTPClient == nil {
+		options.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
 	a := &jwtVerify{
 		cognitoRegion:     cognitoRegion,
 		cognitoUserPoolID: cognitoUserPoolID,
+		options:           options,
+		rules:             options.Rules,
 		log:               logger,
 	}
 
@@ -51,7 +100,53 @@ func NewAuth(cognitoRegion, cognitoUserPoolID string, logger logger.Logger) JWTV
 	return a
 }
 
-func (a *jwtVerify) CacheJWK() error { // Check when we need to cache the JWK
+// NewAuthWithJWK returns a verifier for a fixed key set, used when this service signs its own tokens.
+func NewAuthWithJWK(jwk *JWK, rules ValidationRules, logger logger.Logger) JWTVerify {
+	a := &jwtVerify{
+		rules: rules,
+		log:   logger,
+	}
+	if err := a.setJWK(jwk); err != nil {
+		logger.Error("Error parsing JWK %v", err)
+	}
+	return a
+}
+
+func (a *jwtVerify) CacheJWK() error {
+	if a.jwkURL == "" {
+		return nil
+	}
+
+	a.fetchMu.Lock()
+	defer a.fetchMu.Unlock()
+	return a.fetch()
+}
+
+func (a *jwtVerify) StartRefresh(ctx context.Context) {
+	if a.jwkURL == "" {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(a.options.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// On failure the previous key set is kept until the next tick
+				_ = a.CacheJWK()
+			}
+		}
+	}()
+}
+
+// fetch must be called with fetchMu held.
+func (a *jwtVerify) fetch() error {
+	a.lastFetchAt = time.Now()
+
 	req, err := http.NewRequest("GET", a.jwkURL, nil)
 	if err != nil {
 		a.log.Error("Error creating JWK request %v", err)
@@ -59,14 +154,19 @@ func (a *jwtVerify) CacheJWK() error { // Check when we need to cache the JWK
 	}
 
 	req.Header.Add("Accept", "application/json")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := a.options.HTTPClient.Do(req)
 	if err != nil {
 		a.log.Error("Error getting JWK response %v", err)
 		return err
 	}
 
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("unexpected JWK response status %d", resp.StatusCode)
+		a.log.Error("Error getting JWK response %v", err)
+		return err
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		a.log.Error("Error reading JWK response body %v", err)
@@ -80,23 +180,90 @@ func (a *jwtVerify) CacheJWK() error { // Check when we need to cache the JWK
 		return err
 	}
 
+	if err := a.setJWK(jwk); err != nil {
+		a.log.Error("Error parsing JWK %v", err)
+		return err
+	}
+	return nil
+}
+
+// setJWK parses every RSA key up front so verifying a token is a map lookup.
+func (a *jwtVerify) setJWK(jwk *JWK) error {
+	keys := make(map[string]*rsa.PublicKey, len(jwk.Keys))
+	for _, k := range jwk.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		key, err := convertKey(k.E, k.N)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("the key set has no RSA keys")
+	}
+
+	a.mu.Lock()
 	a.jwk = jwk
+	a.keys = keys
+	a.mu.Unlock()
 	return nil
 }
 
+func (a *jwtVerify) lookupKey(kid string) (*rsa.PublicKey, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// Tokens without kid are accepted only when there is no ambiguity
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, true
+		}
+	}
+	key, ok := a.keys[kid]
+	return key, ok
+}
+
+// key returns the key for kid, refetching the key set at most once per MinRefetchInterval when kid is unknown.
+func (a *jwtVerify) key(kid string) (*rsa.PublicKey, error) {
+	if key, ok := a.lookupKey(kid); ok {
+		return key, nil
+	}
+	if a.jwkURL == "" || kid == "" {
+		return nil, ErrUnknownKey
+	}
+
+	a.fetchMu.Lock()
+	if time.Since(a.lastFetchAt) >= a.options.MinRefetchInterval {
+		_ = a.fetch()
+	}
+	a.fetchMu.Unlock()
+
+	if key, ok := a.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
 func (a *jwtVerify) ParseJWT(tokenString string) (*jwt.Token, *Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		key, err := convertKey(a.jwk.Keys[0].E, a.jwk.Keys[0].N)
-		return key, err
-	})
+		kid, _ := token.Header["kid"].(string)
+		return a.key(kid)
+	}, a.rules.parserOptions()...)
 	if err != nil {
 		a.log.Error("Error parsing JWT %v", err)
-		return token, nil, err
+		return token, nil, parseError(err)
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok && !token.Valid {
-		a.log.Error("Error getting claims from JWT %v", err)
+	if !ok || !token.Valid {
+		a.log.Error("Error getting claims from JWT")
+		return token, nil, ErrMalformedToken
+	}
+
+	if err := a.rules.validate(claims); err != nil {
+		a.log.Error("Error validating JWT claims %v", err)
 		return token, nil, err
 	}
 
@@ -104,6 +271,8 @@ func (a *jwtVerify) ParseJWT(tokenString string) (*jwt.Token, *Claims, error) {
 }
 
 func (a *jwtVerify) JWK() *JWK {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return a.jwk
 }
 
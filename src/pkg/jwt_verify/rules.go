@@ -0,0 +1,83 @@
+package jwt_verify
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotYetValid = errors.New("token not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("token issued to another client")
+	ErrInvalidTokenUse  = errors.New("invalid token use")
+	ErrTokenTooOld      = errors.New("authentication too old")
+)
+
+// ValidationRules are checked on top of the signature and expiry. Empty fields are not checked.
+type ValidationRules struct {
+	Issuer string
+	// ClientIDs are matched against client_id on access tokens and aud on ID tokens.
+	ClientIDs []string
+	TokenUse  string
+	// Leeway tolerates clock skew on exp, iat and auth_time.
+	Leeway time.Duration
+	// MaxAge rejects tokens whose auth_time is older, forcing the user to sign in again.
+	MaxAge time.Duration
+}
+
+func (r ValidationRules) parserOptions() []jwt.ParserOption {
+	return []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(r.Leeway),
+	}
+}
+
+func (r ValidationRules) validate(claims *Claims) error {
+	if r.Issuer != "" && claims.Iss != r.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	if r.TokenUse != "" && claims.TokenUse != r.TokenUse {
+		return ErrInvalidTokenUse
+	}
+
+	if len(r.ClientIDs) > 0 {
+		clientID := claims.ClientId
+		if clientID == "" {
+			clientID = claims.Aud
+		}
+		if !slices.Contains(r.ClientIDs, clientID) {
+			return ErrInvalidAudience
+		}
+	}
+
+	if r.MaxAge > 0 {
+		if claims.AuthTime == 0 || time.Since(time.Unix(claims.AuthTime, 0)) > r.MaxAge+r.Leeway {
+			return ErrTokenTooOld
+		}
+	}
+	return nil
+}
+
+// parseError maps the jwt library errors to the package ones.
+func parseError(err error) error {
+	switch {
+	case errors.Is(err, ErrUnknownKey):
+		return ErrUnknownKey
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenUsedBeforeIssued), errors.Is(err, jwt.ErrTokenNotValidYet):
+		return ErrTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return ErrInvalidSignature
+	default:
+		return ErrMalformedToken
+	}
+}
@@ -0,0 +1,317 @@
+package migrate
+
+import (
+	"auth-api/src/pkg/logger"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockID is the key of the advisory lock held while migrating, so that instances starting together migrate one at a time.
+const lockID int64 = 4283015520649411
+
+var fileRegex = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+	// Modified is set when the applied migration no longer matches its file.
+	Modified bool `json:"modified,omitempty"`
+	// Unknown is set for applied migrations missing from the binary, applied by a newer release.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+type Migrator interface {
+	// Up applies the pending migrations up to target, 0 applies them all. Each migration runs in its own transaction.
+	Up(ctx context.Context, target int64) (int, error)
+	// Down reverts the last steps applied migrations. The first migration is the baseline and is never reverted,
+	// Down fails without reverting anything when steps reaches it.
+	Down(ctx context.Context, steps int) (int, error)
+	// Status lists the migrations without taking the advisory lock, so it answers while another process migrates.
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+type migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     logger.Logger
+}
+
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// New loads the NNNN_name.up.sql and NNNN_name.down.sql files at the root of fsys, every migration needs both.
+func New(db *sql.DB, fsys fs.FS, logger logger.Logger) (Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func (m *migrator) Up(ctx context.Context, target int64) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if target > 0 && migration.Version > target {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Info("Applying migration %d_%s", migration.Version, migration.Name)
+			if err := m.run(ctx, conn, migration.Up, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		var reverted []Migration
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if i == 0 {
+				return fmt.Errorf("migration %d_%s is the baseline and cannot be reverted", migration.Version, migration.Name)
+			}
+			reverted = append(reverted, migration)
+		}
+
+		for _, migration := range reverted {
+			m.logger.Info("Reverting migration %d_%s", migration.Version, migration.Name)
+			if err := m.run(ctx, conn, migration.Down, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// The table is only created by the migrating commands, a database never migrated has every migration pending.
+	var exists bool
+	if err := conn.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		m.logger.Error("Error checking the schema_migrations table: %v", err)
+		return nil, err
+	}
+	applied := map[int64]appliedMigration{}
+	if exists {
+		if applied, err = m.applied(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []MigrationStatus
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied, status.AppliedAt = true, &row.appliedAt
+			status.Modified = row.checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range applied {
+		if !known[row.version] {
+			statuses = append(statuses, MigrationStatus{Version: row.version, Name: row.name, Applied: true, AppliedAt: &row.appliedAt, Unknown: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the advisory lock, creating the schema_migrations table if needed.
+func (m *migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		m.logger.Error("Error acquiring the migration lock: %v", err)
+		return err
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			m.logger.Error("Error releasing the migration lock: %v", err)
+		}
+	}()
+
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum VARCHAR(64) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		m.logger.Error("Error creating the schema_migrations table: %v", err)
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		m.logger.Error("Error listing applied migrations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var row appliedMigration
+		if err := rows.Scan(&row.version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[row.version] = row
+	}
+	return applied, rows.Err()
+}
+
+// verify refuses to migrate when an applied migration was edited since, the database would not match the files.
+// Migrations applied by a newer release are only reported.
+func (m *migrator) verify(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		if row, ok := applied[migration.Version]; ok && row.checksum != migration.Checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after being applied", migration.Version, migration.Name)
+		}
+	}
+	for _, row := range applied {
+		if !known[row.version] {
+			m.logger.Warning("Migration %d_%s is applied but unknown to this release", row.version, row.name)
+		}
+	}
+	return applied, nil
+}
+
+// run executes the migration script and records it in one transaction.
+func (m *migrator) run(ctx context.Context, conn *sql.Conn, script string, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
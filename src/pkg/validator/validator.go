@@ -3,6 +3,7 @@ package validator
 import (
 	"errors"
 	"regexp"
+	"strings"
 )
 
 func ValidateEmail(email string) error {
@@ -35,3 +36,18 @@ func ValidateNumeric(str string) error {
 	}
 	return nil
 }
+
+// NormalizePhone returns the E.164 form of a phone number, e.g. "+351 912-345-678" gives "+351912345678".
+// Spaces, dashes, dots and parentheses are dropped and a leading 00 is read as +, the country code is required.
+func NormalizePhone(phone string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+
+	re := regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	if !re.MatchString(normalized) {
+		return "", errors.New("invalid phone number, expected the E.164 format")
+	}
+	return normalized, nil
+}